@@ -8,6 +8,7 @@ import (
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 	"log/slog"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -99,23 +100,57 @@ func (sentinel *Sentinel) DeleteDoc(docId string) int {
 	return int(atomic.LoadInt32(&n))
 }
 
-func (sentinel *Sentinel) Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlag []uint64) []*types.Document {
+// Suggest 向所有worker请求补全，合并各个分片的结果。每个分片返回它的前Limit个，合并后的排序是近似的
+func (sentinel *Sentinel) Suggest(request *SuggestRequest) (*SuggestResult, error) {
 	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
 	if len(endpoints) == 0 {
-		return nil
+		return nil, fmt.Errorf("there is no alive index worker")
+	}
+	results := make([]*SuggestResult, len(endpoints))
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				return
+			}
+			result, err := NewIndexServiceClient(conn).Suggest(context.Background(), request)
+			if err != nil {
+				slog.Warn("suggest from worker failed", slog.Any("endpoint", endpoint), slog.Any("err", err))
+				return
+			}
+			results[i] = result
+		}(i, endpoint)
+	}
+	wg.Wait()
+	return mergeSuggestions(results, float64(request.PopularityWeight), suggestLimit(request.Limit)), nil
+}
+
+// Search 在所有分片上检索。一篇也没命中时，结果里带上纠正拼写后的查询(DidYouMean)。
+// 有分片没有返回结果时不纠错，因为词可能只在这些分片上存在
+func (sentinel *Sentinel) Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlag []uint64) *SearchResult {
+	endpoints := sentinel.hub.GetServiceEndpoints(INDEX_SERVICE)
+	if len(endpoints) == 0 {
+		return &SearchResult{}
 	}
 	docs := make([]*types.Document, 0, 1000)
 	resultCh := make(chan *types.Document, 1000)
+	var failed atomic.Bool
 	wg := sync.WaitGroup{}
 	wg.Add(len(endpoints))
 	for _, endpoint := range endpoints {
 		func(endpoint string) {
 			defer wg.Done()
 			conn := sentinel.GetGrpcConn(endpoint)
-			if conn != nil {
+			if conn == nil {
+				failed.Store(true)
+			} else {
 				client := NewIndexServiceClient(conn)
 				result, err := client.Search(context.Background(), &SearchRequest{Query: query})
 				if err != nil {
+					failed.Store(true)
 					slog.Info("search from cluster failed", slog.Any("err", err))
 				} else {
 					if len(result.Results) > 0 {
@@ -144,6 +179,37 @@ func (sentinel *Sentinel) Search(query *types.TermQuery, onFlag uint64, offFlag
 	wg.Wait()
 	close(resultCh)
 	<-receiveFinish
-	return docs
+	result := &SearchResult{Results: docs, Total: int64(len(docs))}
+	if len(docs) == 0 && query != nil && !failed.Load() {
+		result.DidYouMean = sentinel.spellCheck(endpoints, query)
+	}
+	return result
+}
 
+// spellCheck 从各个分片收集纠错候选，合并后生成纠正后的查询。有分片失败时返回nil：
+// 只看其余分片的话，一个只在失败分片上存在的词也会被当成拼错的
+func (sentinel *Sentinel) spellCheck(endpoints []string, query *types.TermQuery) []*QuerySuggestion {
+	results := make([]*SpellCheckResult, len(endpoints))
+	wg := sync.WaitGroup{}
+	wg.Add(len(endpoints))
+	for i, endpoint := range endpoints {
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			conn := sentinel.GetGrpcConn(endpoint)
+			if conn == nil {
+				return
+			}
+			result, err := NewIndexServiceClient(conn).SpellCheck(context.Background(), &SpellCheckRequest{Query: query})
+			if err != nil {
+				slog.Warn("spell check from worker failed", slog.Any("endpoint", endpoint), slog.Any("err", err))
+				return
+			}
+			results[i] = result
+		}(i, endpoint)
+	}
+	wg.Wait()
+	if slices.Contains(results, nil) {
+		return nil
+	}
+	return didYouMean(query, results)
 }
@@ -0,0 +1,63 @@
+package index_service
+
+import (
+	"RADIC/internal/reverse_index"
+	"RADIC/internal/vector_index"
+	"RADIC/types"
+	"fmt"
+	"time"
+)
+
+// hybridSearch 混合检索：向量检索召回request.Vector.K个文档，与关键词检索的结果按request.Vector.Fusion合并，再按Offset、Limit分页。
+// 两路都受位过滤和范围过滤的约束；没有Query和QueryString时只做向量检索。
+// FUSION_RRF时关键词检索也按BM25召回前K个，结果的得分是融合后的得分；FUSION_FILTER时只在命中Query的文档里做向量检索，得分是余弦相似度。
+// 不支持游标和SortBy，聚合统计只针对关键词检索命中的文档
+func (indexer *Indexer) hybridSearch(request *SearchRequest, start time.Time) (*SearchResult, error) {
+	if request.Cursor != "" || len(request.SortBy) > 0 {
+		return nil, fmt.Errorf("cursor and sort by are not supported with vector query")
+	}
+	query, err := indexer.query(request)
+	if err != nil {
+		return nil, err
+	}
+	vq := request.Vector
+	filter := &vector_index.Filter{OnFlag: request.OnFlag, OffFlag: request.OffFlag, OrFlags: request.OrFlags, Ranges: request.Ranges}
+
+	var docs []reverse_index.ScoredDoc
+	var keyword reverse_index.Hits
+	switch vq.Fusion {
+	case types.FusionMode_FUSION_FILTER:
+		if query != nil {
+			keyword = indexer.reverseIndex.Search(query, request.OnFlag, request.OffFlag, request.OrFlags, request.Ranges, request.Facets, request.Profile, reverse_index.Page{})
+			filter.IntIds = make(map[uint64]struct{}, len(keyword.Docs))
+			for _, doc := range keyword.Docs {
+				filter.IntIds[doc.IntId] = struct{}{}
+			}
+		}
+		if docs, err = indexer.vectors.Search(vq.Vector, vq.Limit(), int(vq.Ef), filter); err != nil {
+			return nil, err
+		}
+	default:
+		ann, err := indexer.vectors.Search(vq.Vector, vq.Limit(), int(vq.Ef), filter)
+		if err != nil {
+			return nil, err
+		}
+		lists := [][]reverse_index.ScoredDoc{ann}
+		if query != nil {
+			keyword = indexer.reverseIndex.SearchTopK(query, request.OnFlag, request.OffFlag, request.OrFlags, request.Ranges, request.Facets, request.Profile, reverse_index.Page{Limit: vq.Limit()})
+			lists = append(lists, keyword.Docs)
+		}
+		docs = vector_index.FuseRRF(lists...)
+	}
+
+	result := &SearchResult{Total: int64(len(docs)), Facets: keyword.Facets, Profile: keyword.Profile}
+	docs = docs[min(int(request.Offset), len(docs)):]
+	if request.Limit > 0 {
+		docs = docs[:min(int(request.Limit), len(docs))]
+	}
+	result.Results, result.Scores = indexer.fetch(docs, true, keyword.Profile)
+	if result.Profile != nil {
+		result.Profile.TotalNanos = time.Since(start).Nanoseconds()
+	}
+	return result, nil
+}
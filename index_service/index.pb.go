@@ -6,6 +6,7 @@ package index_service
 import (
 	types "RADIC/types"
 	context "context"
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	grpc "google.golang.org/grpc"
@@ -116,10 +117,22 @@ func (m *AffectedCount) GetCount() int32 {
 }
 
 type SearchRequest struct {
-	Query   *types.TermQuery `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
-	OnFlag  uint64           `protobuf:"varint,2,opt,name=OnFlag,proto3" json:"OnFlag,omitempty"`
-	OffFlag uint64           `protobuf:"varint,3,opt,name=OffFlag,proto3" json:"OffFlag,omitempty"`
-	OrFlags []uint64         `protobuf:"varint,4,rep,packed,name=OrFlags,proto3" json:"OrFlags,omitempty"`
+	Query           *types.TermQuery    `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	OnFlag          uint64              `protobuf:"varint,2,opt,name=OnFlag,proto3" json:"OnFlag,omitempty"`
+	OffFlag         uint64              `protobuf:"varint,3,opt,name=OffFlag,proto3" json:"OffFlag,omitempty"`
+	OrFlags         []uint64            `protobuf:"varint,4,rep,packed,name=OrFlags,proto3" json:"OrFlags,omitempty"`
+	TopK            int32               `protobuf:"varint,5,opt,name=TopK,proto3" json:"TopK,omitempty"`
+	Ranges          *types.RangeFilter  `protobuf:"bytes,6,opt,name=Ranges,proto3" json:"Ranges,omitempty"`
+	Limit           int32               `protobuf:"varint,7,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	Offset          int32               `protobuf:"varint,8,opt,name=Offset,proto3" json:"Offset,omitempty"`
+	Cursor          string              `protobuf:"bytes,9,opt,name=Cursor,proto3" json:"Cursor,omitempty"`
+	SortBy          []*types.SortBy     `protobuf:"bytes,10,rep,name=SortBy,proto3" json:"SortBy,omitempty"`
+	Facets          *types.FacetRequest `protobuf:"bytes,11,opt,name=Facets,proto3" json:"Facets,omitempty"`
+	Profile         bool                `protobuf:"varint,12,opt,name=Profile,proto3" json:"Profile,omitempty"`
+	DisableSynonyms bool                `protobuf:"varint,13,opt,name=DisableSynonyms,proto3" json:"DisableSynonyms,omitempty"`
+	QueryString     string              `protobuf:"bytes,14,opt,name=QueryString,proto3" json:"QueryString,omitempty"`
+	Vector          *types.VectorQuery  `protobuf:"bytes,15,opt,name=Vector,proto3" json:"Vector,omitempty"`
+	Rerank          *RerankRequest      `protobuf:"bytes,16,opt,name=Rerank,proto3" json:"Rerank,omitempty"`
 }
 
 func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
@@ -183,15 +196,157 @@ func (m *SearchRequest) GetOrFlags() []uint64 {
 	return nil
 }
 
+func (m *SearchRequest) GetTopK() int32 {
+	if m != nil {
+		return m.TopK
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetRanges() *types.RangeFilter {
+	if m != nil {
+		return m.Ranges
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *SearchRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetSortBy() []*types.SortBy {
+	if m != nil {
+		return m.SortBy
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetFacets() *types.FacetRequest {
+	if m != nil {
+		return m.Facets
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetProfile() bool {
+	if m != nil {
+		return m.Profile
+	}
+	return false
+}
+
+func (m *SearchRequest) GetDisableSynonyms() bool {
+	if m != nil {
+		return m.DisableSynonyms
+	}
+	return false
+}
+
+func (m *SearchRequest) GetQueryString() string {
+	if m != nil {
+		return m.QueryString
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetVector() *types.VectorQuery {
+	if m != nil {
+		return m.Vector
+	}
+	return nil
+}
+
+func (m *SearchRequest) GetRerank() *RerankRequest {
+	if m != nil {
+		return m.Rerank
+	}
+	return nil
+}
+
+type RerankRequest struct {
+	Names  []string `protobuf:"bytes,1,rep,name=Names,proto3" json:"Names,omitempty"`
+	Window int32    `protobuf:"varint,2,opt,name=Window,proto3" json:"Window,omitempty"`
+}
+
+func (m *RerankRequest) Reset()         { *m = RerankRequest{} }
+func (m *RerankRequest) String() string { return proto.CompactTextString(m) }
+func (*RerankRequest) ProtoMessage()    {}
+func (*RerankRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{3}
+}
+func (m *RerankRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RerankRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RerankRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RerankRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RerankRequest.Merge(m, src)
+}
+func (m *RerankRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RerankRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RerankRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RerankRequest proto.InternalMessageInfo
+
+func (m *RerankRequest) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
+func (m *RerankRequest) GetWindow() int32 {
+	if m != nil {
+		return m.Window
+	}
+	return 0
+}
+
 type SearchResult struct {
-	Results []*types.Document `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+	Results    []*types.Document    `protobuf:"bytes,1,rep,name=Results,proto3" json:"Results,omitempty"`
+	Scores     []float64            `protobuf:"fixed64,2,rep,packed,name=Scores,proto3" json:"Scores,omitempty"`
+	Total      int64                `protobuf:"varint,3,opt,name=Total,proto3" json:"Total,omitempty"`
+	NextCursor string               `protobuf:"bytes,4,opt,name=NextCursor,proto3" json:"NextCursor,omitempty"`
+	Facets     *types.FacetResult   `protobuf:"bytes,5,opt,name=Facets,proto3" json:"Facets,omitempty"`
+	Profile    *types.SearchProfile `protobuf:"bytes,6,opt,name=Profile,proto3" json:"Profile,omitempty"`
+	DidYouMean []*QuerySuggestion   `protobuf:"bytes,7,rep,name=DidYouMean,proto3" json:"DidYouMean,omitempty"`
 }
 
 func (m *SearchResult) Reset()         { *m = SearchResult{} }
 func (m *SearchResult) String() string { return proto.CompactTextString(m) }
 func (*SearchResult) ProtoMessage()    {}
 func (*SearchResult) Descriptor() ([]byte, []int) {
-	return fileDescriptor_f750e0f7889345b5, []int{3}
+	return fileDescriptor_f750e0f7889345b5, []int{4}
 }
 func (m *SearchResult) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -227,435 +382,3632 @@ func (m *SearchResult) GetResults() []*types.Document {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*DocId)(nil), "index_service.DocId")
-	proto.RegisterType((*AffectedCount)(nil), "index_service.AffectedCount")
-	proto.RegisterType((*SearchRequest)(nil), "index_service.SearchRequest")
-	proto.RegisterType((*SearchResult)(nil), "index_service.SearchResult")
+func (m *SearchResult) GetScores() []float64 {
+	if m != nil {
+		return m.Scores
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("index.proto", fileDescriptor_f750e0f7889345b5) }
-
-var fileDescriptor_f750e0f7889345b5 = []byte{
-	// 348 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0xc1, 0x6a, 0xf2, 0x40,
-	0x14, 0x85, 0x9d, 0x5f, 0x8d, 0x78, 0x55, 0xfc, 0x19, 0x44, 0x42, 0xda, 0x86, 0x10, 0x68, 0xb1,
-	0x9b, 0x14, 0xec, 0xa2, 0x74, 0x55, 0xac, 0xa1, 0xe0, 0x4a, 0x1a, 0xbb, 0x17, 0x3b, 0xb9, 0x69,
-	0x05, 0xcd, 0xe8, 0x64, 0x52, 0xea, 0xba, 0x2f, 0xd0, 0xc7, 0xea, 0xa6, 0xe0, 0xb2, 0xcb, 0xa2,
-	0x2f, 0x52, 0x32, 0xa3, 0x0b, 0x03, 0xa5, 0xbb, 0x7b, 0xce, 0x77, 0x86, 0x3b, 0x73, 0x06, 0x6a,
-	0xd3, 0x38, 0xc4, 0x57, 0x6f, 0x21, 0xb8, 0xe4, 0xb4, 0xa1, 0xc4, 0x38, 0x41, 0xf1, 0x32, 0x65,
-	0x68, 0x35, 0xe5, 0x6a, 0x81, 0xc9, 0x45, 0xc8, 0x99, 0xe6, 0x56, 0x5b, 0x1b, 0x12, 0xc5, 0x7c,
-	0xbc, 0x4c, 0x51, 0xac, 0xb4, 0xef, 0x9e, 0x40, 0xd9, 0xe7, 0x6c, 0x10, 0xd2, 0xd6, 0x6e, 0x30,
-	0x89, 0x43, 0x3a, 0xd5, 0x40, 0x0b, 0xf7, 0x14, 0x1a, 0xbd, 0x28, 0x42, 0x26, 0x31, 0xec, 0xf3,
-	0x34, 0x96, 0x59, 0x4c, 0x0d, 0x2a, 0x56, 0x0e, 0xb4, 0x70, 0xdf, 0x08, 0x34, 0x46, 0x38, 0x11,
-	0xec, 0x39, 0xc0, 0x65, 0x8a, 0x89, 0xa4, 0x67, 0x50, 0xbe, 0xcf, 0xd6, 0xa8, 0x5c, 0xad, 0xfb,
-	0xdf, 0x53, 0xfb, 0xbd, 0x07, 0x14, 0x73, 0xe5, 0x07, 0x1a, 0xd3, 0x36, 0x18, 0xc3, 0xf8, 0x6e,
-	0x36, 0x79, 0x32, 0xff, 0x39, 0xa4, 0x53, 0x0a, 0x76, 0x8a, 0x9a, 0x50, 0x19, 0x46, 0x91, 0x02,
-	0x45, 0x05, 0xf6, 0x52, 0x11, 0x91, 0x4d, 0x89, 0x59, 0x72, 0x8a, 0x8a, 0x68, 0xe9, 0x5e, 0x43,
-	0x7d, 0x7f, 0x89, 0x24, 0x9d, 0x49, 0x7a, 0x0e, 0x15, 0x3d, 0x25, 0x26, 0x71, 0x8a, 0x9d, 0x5a,
-	0xb7, 0xb9, 0xbb, 0x85, 0xcf, 0x59, 0x3a, 0xc7, 0x58, 0x06, 0x7b, 0xde, 0xfd, 0x24, 0x50, 0x1f,
-	0x64, 0x0d, 0x8e, 0x74, 0x81, 0xf4, 0x06, 0xaa, 0x3e, 0xce, 0x50, 0xa2, 0xcf, 0x19, 0x6d, 0x79,
-	0x07, 0xed, 0x7a, 0xaa, 0x1b, 0xeb, 0x38, 0xe7, 0x1e, 0x16, 0x75, 0x05, 0x46, 0x2f, 0x0c, 0xb3,
-	0xd3, 0xf9, 0xad, 0x7f, 0x1c, 0xec, 0x83, 0xa1, 0x5f, 0x41, 0xf3, 0xb9, 0x83, 0x86, 0xad, 0xa3,
-	0x5f, 0x68, 0xf6, 0xa0, 0x5b, 0xf3, 0x63, 0x63, 0x93, 0xf5, 0xc6, 0x26, 0xdf, 0x1b, 0x9b, 0xbc,
-	0x6f, 0xed, 0xc2, 0x7a, 0x6b, 0x17, 0xbe, 0xb6, 0x76, 0xe1, 0xd1, 0x50, 0xff, 0x7e, 0xf9, 0x13,
-	0x00, 0x00, 0xff, 0xff, 0x17, 0xa7, 0x0f, 0xe2, 0x3e, 0x02, 0x00, 0x00,
+func (m *SearchResult) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+func (m *SearchResult) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *SearchResult) GetFacets() *types.FacetResult {
+	if m != nil {
+		return m.Facets
+	}
+	return nil
+}
 
-// IndexServiceClient is the client API for IndexService service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IndexServiceClient interface {
-	DeleteDoc(ctx context.Context, in *DocId, opts ...grpc.CallOption) (*AffectedCount, error)
-	AddDoc(ctx context.Context, in *types.Document, opts ...grpc.CallOption) (*AffectedCount, error)
-	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResult, error)
+func (m *SearchResult) GetProfile() *types.SearchProfile {
+	if m != nil {
+		return m.Profile
+	}
+	return nil
 }
 
-type indexServiceClient struct {
-	cc *grpc.ClientConn
+func (m *SearchResult) GetDidYouMean() []*QuerySuggestion {
+	if m != nil {
+		return m.DidYouMean
+	}
+	return nil
 }
 
-func NewIndexServiceClient(cc *grpc.ClientConn) IndexServiceClient {
-	return &indexServiceClient{cc}
+type ExplainRequest struct {
+	DocId   string         `protobuf:"bytes,1,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	Request *SearchRequest `protobuf:"bytes,2,opt,name=Request,proto3" json:"Request,omitempty"`
 }
 
-func (c *indexServiceClient) DeleteDoc(ctx context.Context, in *DocId, opts ...grpc.CallOption) (*AffectedCount, error) {
-	out := new(AffectedCount)
-	err := c.cc.Invoke(ctx, "/index_service.IndexService/DeleteDoc", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExplainRequest) Reset()         { *m = ExplainRequest{} }
+func (m *ExplainRequest) String() string { return proto.CompactTextString(m) }
+func (*ExplainRequest) ProtoMessage()    {}
+func (*ExplainRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{5}
+}
+func (m *ExplainRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ExplainRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ExplainRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *ExplainRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExplainRequest.Merge(m, src)
+}
+func (m *ExplainRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ExplainRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExplainRequest.DiscardUnknown(m)
 }
 
-func (c *indexServiceClient) AddDoc(ctx context.Context, in *types.Document, opts ...grpc.CallOption) (*AffectedCount, error) {
-	out := new(AffectedCount)
-	err := c.cc.Invoke(ctx, "/index_service.IndexService/AddDoc", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ExplainRequest proto.InternalMessageInfo
+
+func (m *ExplainRequest) GetDocId() string {
+	if m != nil {
+		return m.DocId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *indexServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResult, error) {
-	out := new(SearchResult)
-	err := c.cc.Invoke(ctx, "/index_service.IndexService/Search", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ExplainRequest) GetRequest() *SearchRequest {
+	if m != nil {
+		return m.Request
 	}
-	return out, nil
+	return nil
 }
 
-// IndexServiceServer is the server API for IndexService service.
-type IndexServiceServer interface {
-	DeleteDoc(context.Context, *DocId) (*AffectedCount, error)
-	AddDoc(context.Context, *types.Document) (*AffectedCount, error)
-	Search(context.Context, *SearchRequest) (*SearchResult, error)
+type SuggestRequest struct {
+	Prefix           string  `protobuf:"bytes,1,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	Field            string  `protobuf:"bytes,2,opt,name=Field,proto3" json:"Field,omitempty"`
+	Limit            int32   `protobuf:"varint,3,opt,name=Limit,proto3" json:"Limit,omitempty"`
+	PopularityWeight float32 `protobuf:"fixed32,4,opt,name=PopularityWeight,proto3" json:"PopularityWeight,omitempty"`
 }
 
-// UnimplementedIndexServiceServer can be embedded to have forward compatible implementations.
-type UnimplementedIndexServiceServer struct {
+func (m *SuggestRequest) Reset()         { *m = SuggestRequest{} }
+func (m *SuggestRequest) String() string { return proto.CompactTextString(m) }
+func (*SuggestRequest) ProtoMessage()    {}
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{6}
 }
-
-func (*UnimplementedIndexServiceServer) DeleteDoc(ctx context.Context, req *DocId) (*AffectedCount, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteDoc not implemented")
+func (m *SuggestRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedIndexServiceServer) AddDoc(ctx context.Context, req *types.Document) (*AffectedCount, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddDoc not implemented")
+func (m *SuggestRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SuggestRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-func (*UnimplementedIndexServiceServer) Search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+func (m *SuggestRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestRequest.Merge(m, src)
 }
-
-func RegisterIndexServiceServer(s *grpc.Server, srv IndexServiceServer) {
-	s.RegisterService(&_IndexService_serviceDesc, srv)
+func (m *SuggestRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SuggestRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestRequest.DiscardUnknown(m)
 }
 
-func _IndexService_DeleteDoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DocId)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IndexServiceServer).DeleteDoc(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/index_service.IndexService/DeleteDoc",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IndexServiceServer).DeleteDoc(ctx, req.(*DocId))
+var xxx_messageInfo_SuggestRequest proto.InternalMessageInfo
+
+func (m *SuggestRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _IndexService_AddDoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(types.Document)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IndexServiceServer).AddDoc(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/index_service.IndexService/AddDoc",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IndexServiceServer).AddDoc(ctx, req.(*types.Document))
+func (m *SuggestRequest) GetField() string {
+	if m != nil {
+		return m.Field
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _IndexService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SearchRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IndexServiceServer).Search(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/index_service.IndexService/Search",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IndexServiceServer).Search(ctx, req.(*SearchRequest))
+func (m *SuggestRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	return interceptor(ctx, in, info, handler)
+	return 0
 }
 
-var _IndexService_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "index_service.IndexService",
-	HandlerType: (*IndexServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "DeleteDoc",
-			Handler:    _IndexService_DeleteDoc_Handler,
-		},
-		{
-			MethodName: "AddDoc",
-			Handler:    _IndexService_AddDoc_Handler,
-		},
-		{
-			MethodName: "Search",
-			Handler:    _IndexService_Search_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "index.proto",
-}
-
-func (m *DocId) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *SuggestRequest) GetPopularityWeight() float32 {
+	if m != nil {
+		return m.PopularityWeight
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *DocId) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Suggestion struct {
+	Word       string  `protobuf:"bytes,1,opt,name=Word,proto3" json:"Word,omitempty"`
+	DocFreq    int64   `protobuf:"varint,2,opt,name=DocFreq,proto3" json:"DocFreq,omitempty"`
+	Popularity int64   `protobuf:"varint,3,opt,name=Popularity,proto3" json:"Popularity,omitempty"`
+	Score      float64 `protobuf:"fixed64,4,opt,name=Score,proto3" json:"Score,omitempty"`
 }
 
-func (m *DocId) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.DocId) > 0 {
-		i -= len(m.DocId)
-		copy(dAtA[i:], m.DocId)
-		i = encodeVarintIndex(dAtA, i, uint64(len(m.DocId)))
-		i--
-		dAtA[i] = 0xa
+func (m *Suggestion) Reset()         { *m = Suggestion{} }
+func (m *Suggestion) String() string { return proto.CompactTextString(m) }
+func (*Suggestion) ProtoMessage()    {}
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{7}
+}
+func (m *Suggestion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Suggestion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Suggestion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Suggestion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Suggestion.Merge(m, src)
+}
+func (m *Suggestion) XXX_Size() int {
+	return m.Size()
+}
+func (m *Suggestion) XXX_DiscardUnknown() {
+	xxx_messageInfo_Suggestion.DiscardUnknown(m)
 }
 
-func (m *AffectedCount) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Suggestion proto.InternalMessageInfo
+
+func (m *Suggestion) GetWord() string {
+	if m != nil {
+		return m.Word
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *AffectedCount) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Suggestion) GetDocFreq() int64 {
+	if m != nil {
+		return m.DocFreq
+	}
+	return 0
 }
 
-func (m *AffectedCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Count != 0 {
-		i = encodeVarintIndex(dAtA, i, uint64(m.Count))
-		i--
-		dAtA[i] = 0x8
+func (m *Suggestion) GetPopularity() int64 {
+	if m != nil {
+		return m.Popularity
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *SearchRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Suggestion) GetScore() float64 {
+	if m != nil {
+		return m.Score
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *SearchRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SuggestResult struct {
+	Suggestions []*Suggestion `protobuf:"bytes,1,rep,name=Suggestions,proto3" json:"Suggestions,omitempty"`
 }
 
-func (m *SearchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.OrFlags) > 0 {
-		dAtA2 := make([]byte, len(m.OrFlags)*10)
-		var j1 int
-		for _, num := range m.OrFlags {
-			for num >= 1<<7 {
-				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j1++
-			}
-			dAtA2[j1] = uint8(num)
-			j1++
+func (m *SuggestResult) Reset()         { *m = SuggestResult{} }
+func (m *SuggestResult) String() string { return proto.CompactTextString(m) }
+func (*SuggestResult) ProtoMessage()    {}
+func (*SuggestResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{8}
+}
+func (m *SuggestResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SuggestResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SuggestResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i -= j1
-		copy(dAtA[i:], dAtA2[:j1])
-		i = encodeVarintIndex(dAtA, i, uint64(j1))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.OffFlag != 0 {
-		i = encodeVarintIndex(dAtA, i, uint64(m.OffFlag))
-		i--
-		dAtA[i] = 0x18
+		return b[:n], nil
 	}
-	if m.OnFlag != 0 {
-		i = encodeVarintIndex(dAtA, i, uint64(m.OnFlag))
-		i--
-		dAtA[i] = 0x10
+}
+func (m *SuggestResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SuggestResult.Merge(m, src)
+}
+func (m *SuggestResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *SuggestResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SuggestResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SuggestResult proto.InternalMessageInfo
+
+func (m *SuggestResult) GetSuggestions() []*Suggestion {
+	if m != nil {
+		return m.Suggestions
 	}
-	if m.Query != nil {
-		{
-			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIndex(dAtA, i, uint64(size))
+	return nil
+}
+
+type SpellCheckRequest struct {
+	Query *types.TermQuery `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+}
+
+func (m *SpellCheckRequest) Reset()         { *m = SpellCheckRequest{} }
+func (m *SpellCheckRequest) String() string { return proto.CompactTextString(m) }
+func (*SpellCheckRequest) ProtoMessage()    {}
+func (*SpellCheckRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{9}
+}
+func (m *SpellCheckRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpellCheckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpellCheckRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SpellCheckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpellCheckRequest.Merge(m, src)
+}
+func (m *SpellCheckRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpellCheckRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpellCheckRequest.DiscardUnknown(m)
 }
 
-func (m *SearchResult) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_SpellCheckRequest proto.InternalMessageInfo
+
+func (m *SpellCheckRequest) GetQuery() *types.TermQuery {
+	if m != nil {
+		return m.Query
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *SearchResult) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type SpellCandidate struct {
+	Word     string `protobuf:"bytes,1,opt,name=Word,proto3" json:"Word,omitempty"`
+	DocFreq  int64  `protobuf:"varint,2,opt,name=DocFreq,proto3" json:"DocFreq,omitempty"`
+	Distance int32  `protobuf:"varint,3,opt,name=Distance,proto3" json:"Distance,omitempty"`
 }
 
-func (m *SearchResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIndex(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+func (m *SpellCandidate) Reset()         { *m = SpellCandidate{} }
+func (m *SpellCandidate) String() string { return proto.CompactTextString(m) }
+func (*SpellCandidate) ProtoMessage()    {}
+func (*SpellCandidate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{10}
+}
+func (m *SpellCandidate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpellCandidate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpellCandidate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *SpellCandidate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpellCandidate.Merge(m, src)
+}
+func (m *SpellCandidate) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpellCandidate) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpellCandidate.DiscardUnknown(m)
 }
 
-func encodeVarintIndex(dAtA []byte, offset int, v uint64) int {
-	offset -= sovIndex(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+var xxx_messageInfo_SpellCandidate proto.InternalMessageInfo
+
+func (m *SpellCandidate) GetWord() string {
+	if m != nil {
+		return m.Word
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return ""
 }
-func (m *DocId) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.DocId)
-	if l > 0 {
-		n += 1 + l + sovIndex(uint64(l))
+
+func (m *SpellCandidate) GetDocFreq() int64 {
+	if m != nil {
+		return m.DocFreq
 	}
-	return n
+	return 0
 }
 
-func (m *AffectedCount) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Count != 0 {
-		n += 1 + sovIndex(uint64(m.Count))
+func (m *SpellCandidate) GetDistance() int32 {
+	if m != nil {
+		return m.Distance
 	}
-	return n
+	return 0
 }
 
-func (m *SearchRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
+type TermCandidates struct {
+	Key        string            `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	DocFreq    int64             `protobuf:"varint,2,opt,name=DocFreq,proto3" json:"DocFreq,omitempty"`
+	Candidates []*SpellCandidate `protobuf:"bytes,3,rep,name=Candidates,proto3" json:"Candidates,omitempty"`
+}
+
+func (m *TermCandidates) Reset()         { *m = TermCandidates{} }
+func (m *TermCandidates) String() string { return proto.CompactTextString(m) }
+func (*TermCandidates) ProtoMessage()    {}
+func (*TermCandidates) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{11}
+}
+func (m *TermCandidates) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TermCandidates) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TermCandidates.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TermCandidates) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TermCandidates.Merge(m, src)
+}
+func (m *TermCandidates) XXX_Size() int {
+	return m.Size()
+}
+func (m *TermCandidates) XXX_DiscardUnknown() {
+	xxx_messageInfo_TermCandidates.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TermCandidates proto.InternalMessageInfo
+
+func (m *TermCandidates) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *TermCandidates) GetDocFreq() int64 {
+	if m != nil {
+		return m.DocFreq
+	}
+	return 0
+}
+
+func (m *TermCandidates) GetCandidates() []*SpellCandidate {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
+type SpellCheckResult struct {
+	Terms []*TermCandidates `protobuf:"bytes,1,rep,name=Terms,proto3" json:"Terms,omitempty"`
+}
+
+func (m *SpellCheckResult) Reset()         { *m = SpellCheckResult{} }
+func (m *SpellCheckResult) String() string { return proto.CompactTextString(m) }
+func (*SpellCheckResult) ProtoMessage()    {}
+func (*SpellCheckResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{12}
+}
+func (m *SpellCheckResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SpellCheckResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SpellCheckResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SpellCheckResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SpellCheckResult.Merge(m, src)
+}
+func (m *SpellCheckResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *SpellCheckResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_SpellCheckResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SpellCheckResult proto.InternalMessageInfo
+
+func (m *SpellCheckResult) GetTerms() []*TermCandidates {
+	if m != nil {
+		return m.Terms
+	}
+	return nil
+}
+
+type QuerySuggestion struct {
+	Query       *types.TermQuery `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	QueryString string           `protobuf:"bytes,2,opt,name=QueryString,proto3" json:"QueryString,omitempty"`
+	Score       float64          `protobuf:"fixed64,3,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (m *QuerySuggestion) Reset()         { *m = QuerySuggestion{} }
+func (m *QuerySuggestion) String() string { return proto.CompactTextString(m) }
+func (*QuerySuggestion) ProtoMessage()    {}
+func (*QuerySuggestion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_f750e0f7889345b5, []int{13}
+}
+func (m *QuerySuggestion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuerySuggestion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuerySuggestion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QuerySuggestion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuerySuggestion.Merge(m, src)
+}
+func (m *QuerySuggestion) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuerySuggestion) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuerySuggestion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuerySuggestion proto.InternalMessageInfo
+
+func (m *QuerySuggestion) GetQuery() *types.TermQuery {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *QuerySuggestion) GetQueryString() string {
+	if m != nil {
+		return m.QueryString
+	}
+	return ""
+}
+
+func (m *QuerySuggestion) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*DocId)(nil), "index_service.DocId")
+	proto.RegisterType((*AffectedCount)(nil), "index_service.AffectedCount")
+	proto.RegisterType((*SearchRequest)(nil), "index_service.SearchRequest")
+	proto.RegisterType((*RerankRequest)(nil), "index_service.RerankRequest")
+	proto.RegisterType((*SearchResult)(nil), "index_service.SearchResult")
+	proto.RegisterType((*ExplainRequest)(nil), "index_service.ExplainRequest")
+	proto.RegisterType((*SuggestRequest)(nil), "index_service.SuggestRequest")
+	proto.RegisterType((*Suggestion)(nil), "index_service.Suggestion")
+	proto.RegisterType((*SuggestResult)(nil), "index_service.SuggestResult")
+	proto.RegisterType((*SpellCheckRequest)(nil), "index_service.SpellCheckRequest")
+	proto.RegisterType((*SpellCandidate)(nil), "index_service.SpellCandidate")
+	proto.RegisterType((*TermCandidates)(nil), "index_service.TermCandidates")
+	proto.RegisterType((*SpellCheckResult)(nil), "index_service.SpellCheckResult")
+	proto.RegisterType((*QuerySuggestion)(nil), "index_service.QuerySuggestion")
+}
+
+func init() { proto.RegisterFile("index.proto", fileDescriptor_f750e0f7889345b5) }
+
+var fileDescriptor_f750e0f7889345b5 = []byte{
+	// 1031 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x51, 0x6f, 0x1b, 0x45,
+	0x10, 0xce, 0xf9, 0x62, 0x3b, 0x19, 0xc7, 0x49, 0xba, 0x8d, 0xa2, 0xc5, 0xb4, 0xc6, 0x3a, 0xa9,
+	0xc8, 0x14, 0xc9, 0x48, 0x29, 0x82, 0x87, 0x52, 0x50, 0x1b, 0x63, 0x54, 0xb5, 0x34, 0x61, 0x1d,
+	0x11, 0xc1, 0x03, 0xd1, 0xe5, 0x6e, 0xed, 0x9c, 0x7a, 0xbe, 0x75, 0xf6, 0xd6, 0x25, 0x16, 0x2f,
+	0xfc, 0x04, 0xfe, 0x03, 0xf0, 0x5f, 0x78, 0xec, 0x23, 0x8f, 0x28, 0xf9, 0x23, 0x68, 0x67, 0xf7,
+	0xec, 0xbb, 0x4b, 0x1b, 0xe8, 0xdb, 0x7d, 0xdf, 0xcc, 0xee, 0xcc, 0x7c, 0x33, 0xb3, 0x36, 0x34,
+	0xa2, 0x24, 0xe4, 0x17, 0xbd, 0xa9, 0x14, 0x4a, 0x90, 0x26, 0x82, 0x93, 0x94, 0xcb, 0x57, 0x51,
+	0xc0, 0x5b, 0x5b, 0x6a, 0x3e, 0xe5, 0xe9, 0x27, 0xa1, 0x08, 0x8c, 0xbd, 0xb5, 0x6b, 0x08, 0xc5,
+	0xe5, 0xe4, 0xe4, 0x7c, 0xc6, 0xe5, 0xdc, 0xf2, 0xd4, 0xf0, 0xd2, 0x4f, 0xc6, 0xfc, 0x64, 0x14,
+	0xc5, 0x8a, 0x4b, 0x6b, 0xb9, 0x6d, 0x2c, 0xa9, 0x90, 0xea, 0xe4, 0x34, 0x73, 0xbf, 0x65, 0xc8,
+	0x91, 0x1f, 0x70, 0x55, 0xf4, 0xe3, 0x17, 0xd3, 0xd8, 0x8f, 0x92, 0x22, 0x39, 0x95, 0x62, 0x14,
+	0xc5, 0xdc, 0x92, 0xc4, 0x90, 0xaf, 0x78, 0xa0, 0x84, 0x8d, 0xe2, 0xdd, 0x85, 0x6a, 0x5f, 0x04,
+	0x4f, 0x43, 0xb2, 0x63, 0x3f, 0xa8, 0xd3, 0x71, 0xba, 0xeb, 0xcc, 0x00, 0xef, 0x1e, 0x34, 0x1f,
+	0x8f, 0x46, 0x3c, 0x50, 0x3c, 0xdc, 0x17, 0xb3, 0x44, 0x69, 0x37, 0xfc, 0x40, 0xb7, 0x2a, 0x33,
+	0xc0, 0xfb, 0x73, 0x15, 0x9a, 0x43, 0xee, 0xcb, 0xe0, 0x8c, 0xf1, 0xf3, 0x19, 0x4f, 0x15, 0xf9,
+	0x10, 0xaa, 0xdf, 0xe9, 0x32, 0xd1, 0xaf, 0xb1, 0xb7, 0xdd, 0xc3, 0xd8, 0xbd, 0x23, 0x2e, 0x27,
+	0xc8, 0x33, 0x63, 0x26, 0xbb, 0x50, 0x3b, 0x48, 0x06, 0xb1, 0x3f, 0xa6, 0x95, 0x8e, 0xd3, 0x5d,
+	0x65, 0x16, 0x11, 0x0a, 0xf5, 0x83, 0xd1, 0x08, 0x0d, 0x2e, 0x1a, 0x32, 0x88, 0x16, 0xa9, 0xbf,
+	0x52, 0xba, 0xda, 0x71, 0xd1, 0x62, 0x20, 0x21, 0xb0, 0x7a, 0x24, 0xa6, 0xcf, 0x68, 0x15, 0x53,
+	0xc3, 0x6f, 0x72, 0x1f, 0x6a, 0x4c, 0x6b, 0x9b, 0xd2, 0x1a, 0x26, 0x42, 0x6c, 0x22, 0x48, 0x0e,
+	0x50, 0x6f, 0x66, 0x3d, 0x74, 0x6d, 0xcf, 0xa3, 0x49, 0xa4, 0x68, 0xdd, 0xd4, 0x86, 0x00, 0x33,
+	0x1c, 0x8d, 0x52, 0xae, 0xe8, 0x1a, 0xd2, 0x16, 0x69, 0x7e, 0x7f, 0x26, 0x53, 0x21, 0xe9, 0x3a,
+	0x2a, 0x66, 0x11, 0xb9, 0x07, 0xb5, 0xa1, 0x90, 0xea, 0xc9, 0x9c, 0x42, 0xc7, 0xed, 0x36, 0xf6,
+	0x9a, 0x36, 0xa2, 0x21, 0x99, 0x35, 0x92, 0x8f, 0xa1, 0x36, 0xd0, 0x5d, 0x4c, 0x69, 0x03, 0x13,
+	0xbb, 0x6d, 0xdd, 0x90, 0xb4, 0x2a, 0x32, 0xeb, 0xa2, 0x6b, 0x3e, 0x34, 0xad, 0xa4, 0x1b, 0x1d,
+	0xa7, 0xbb, 0xc6, 0x32, 0x48, 0xba, 0xb0, 0xd5, 0x8f, 0x52, 0xff, 0x34, 0xe6, 0xc3, 0x79, 0x22,
+	0x92, 0xf9, 0x24, 0xa5, 0x4d, 0xf4, 0x28, 0xd3, 0xa4, 0x03, 0x0d, 0x94, 0x7c, 0xa8, 0x64, 0x94,
+	0x8c, 0xe9, 0x26, 0x26, 0x9d, 0xa7, 0xb4, 0x56, 0xdf, 0xe3, 0x6c, 0xd0, 0xad, 0x82, 0x56, 0x86,
+	0x34, 0x6d, 0xb3, 0x1e, 0xe4, 0x53, 0xa8, 0x31, 0x2e, 0xfd, 0xe4, 0x25, 0xdd, 0x46, 0xdf, 0x3b,
+	0xbd, 0xc2, 0x02, 0xf4, 0x8c, 0x71, 0x51, 0x87, 0x81, 0xde, 0x23, 0x68, 0x16, 0x0c, 0x5a, 0xf2,
+	0x17, 0xfe, 0x84, 0xa7, 0xd4, 0xe9, 0xb8, 0x7a, 0xea, 0x10, 0x68, 0x69, 0x8f, 0xa3, 0x24, 0x14,
+	0x3f, 0xe3, 0x50, 0x54, 0x99, 0x45, 0xde, 0xef, 0x15, 0xd8, 0xc8, 0xc6, 0x2c, 0x9d, 0xc5, 0x8a,
+	0x7c, 0x04, 0x75, 0xf3, 0x65, 0x2e, 0x68, 0xec, 0x6d, 0xd9, 0x94, 0xfb, 0x22, 0x98, 0x4d, 0x78,
+	0xa2, 0x58, 0x66, 0xd7, 0x77, 0x0e, 0x03, 0x21, 0x79, 0x4a, 0x2b, 0x1d, 0xb7, 0xeb, 0x30, 0x8b,
+	0x74, 0x06, 0x47, 0x42, 0xf9, 0x31, 0x8e, 0x99, 0xcb, 0x0c, 0x20, 0x6d, 0x80, 0x17, 0xfc, 0x42,
+	0xd9, 0x06, 0xaf, 0xa2, 0x56, 0x39, 0x46, 0x4b, 0x65, 0xbb, 0x57, 0x2d, 0x48, 0x65, 0xbb, 0xa7,
+	0x43, 0x2e, 0x9a, 0xd7, 0x5b, 0x36, 0xcf, 0xcc, 0xe0, 0x4e, 0x36, 0x11, 0x58, 0x8a, 0xb5, 0x2d,
+	0x5b, 0xfa, 0x25, 0x40, 0x3f, 0x0a, 0x7f, 0x10, 0xb3, 0x6f, 0xb9, 0x9f, 0xd0, 0x3a, 0xd6, 0xd5,
+	0x2e, 0xc9, 0x6b, 0xda, 0x36, 0x1b, 0x8f, 0x79, 0xaa, 0x22, 0x91, 0xb0, 0xdc, 0x09, 0xef, 0x27,
+	0xd8, 0xfc, 0xda, 0x3c, 0x06, 0x39, 0x95, 0xaf, 0xef, 0x36, 0xf9, 0x0c, 0xea, 0xd6, 0x81, 0x56,
+	0xde, 0xd8, 0xc3, 0xc2, 0x46, 0xb3, 0xcc, 0xd9, 0xfb, 0xd5, 0x81, 0x4d, 0x1b, 0x3a, 0x0b, 0xb0,
+	0x0b, 0xb5, 0x43, 0xc9, 0x47, 0xd1, 0x85, 0x8d, 0x60, 0x91, 0x0e, 0x3c, 0x88, 0x78, 0x1c, 0x62,
+	0x80, 0x75, 0x66, 0xc0, 0x72, 0xcf, 0xdc, 0xfc, 0x9e, 0xdd, 0x87, 0xed, 0x43, 0x31, 0x9d, 0xc5,
+	0xbe, 0x8c, 0xd4, 0xfc, 0x98, 0x47, 0xe3, 0x33, 0x85, 0xc2, 0x57, 0xd8, 0x35, 0xde, 0x9b, 0x02,
+	0x2c, 0x8b, 0xd7, 0x7b, 0x7f, 0x2c, 0x64, 0x56, 0x1d, 0x7e, 0xeb, 0x8d, 0xe9, 0x8b, 0x60, 0x20,
+	0xf9, 0x39, 0xc6, 0x76, 0x59, 0x06, 0x75, 0x6b, 0x97, 0xf7, 0xd9, 0xae, 0xe7, 0x18, 0x9d, 0x1d,
+	0x8e, 0x06, 0x06, 0x77, 0x98, 0x01, 0xde, 0x73, 0x68, 0x2e, 0x6a, 0xc6, 0xd1, 0x7b, 0x08, 0x8d,
+	0x65, 0x0a, 0xd9, 0xf8, 0xbd, 0x57, 0x56, 0x70, 0xd9, 0xa1, 0xbc, 0xb7, 0xf7, 0x10, 0x6e, 0x0d,
+	0xa7, 0x3c, 0x8e, 0xf7, 0xcf, 0x78, 0xf0, 0xf2, 0x1d, 0x9f, 0x4c, 0xef, 0x47, 0xd8, 0x34, 0x87,
+	0xfd, 0x24, 0x8c, 0x42, 0x5f, 0xf1, 0x77, 0x14, 0xa0, 0x05, 0x6b, 0xfd, 0x28, 0x55, 0x7e, 0x12,
+	0x70, 0xdb, 0x81, 0x05, 0xf6, 0x7e, 0x81, 0x4d, 0x1d, 0x6f, 0x71, 0x75, 0x4a, 0xb6, 0xc1, 0x7d,
+	0xc6, 0xe7, 0xf6, 0x6a, 0xfd, 0x79, 0xc3, 0xcd, 0x8f, 0x00, 0x96, 0x27, 0xa9, 0x8b, 0x92, 0xdc,
+	0x2d, 0x4b, 0x52, 0x48, 0x9d, 0xe5, 0x0e, 0x78, 0xdf, 0xc0, 0x76, 0x5e, 0x15, 0x94, 0xf9, 0x01,
+	0x54, 0x75, 0x42, 0x99, 0xc0, 0xe5, 0xdb, 0x8a, 0xc9, 0x32, 0xe3, 0xeb, 0x9d, 0xc3, 0x56, 0x69,
+	0x41, 0xfe, 0xf7, 0xef, 0x51, 0xe9, 0x95, 0xac, 0x5c, 0x7f, 0x25, 0x17, 0xf3, 0xe1, 0xe6, 0xe6,
+	0x63, 0xef, 0x0f, 0x17, 0x36, 0x9e, 0xea, 0xd4, 0x86, 0x26, 0x33, 0xf2, 0x15, 0xac, 0xf7, 0x79,
+	0xcc, 0x15, 0xef, 0x8b, 0x80, 0xec, 0x94, 0xd2, 0xc6, 0x05, 0x6c, 0x95, 0xf7, 0xad, 0xf8, 0x4b,
+	0xfb, 0x39, 0xd4, 0x1e, 0x87, 0xa1, 0x3e, 0x5d, 0x7e, 0xd4, 0xfe, 0xe3, 0xe0, 0x3e, 0xd4, 0xcc,
+	0xe6, 0x92, 0x1b, 0x17, 0xba, 0xf5, 0xfe, 0x5b, 0xac, 0xa8, 0xfb, 0x17, 0x50, 0xb7, 0x8f, 0x08,
+	0x29, 0x6b, 0x5e, 0x7c, 0x5c, 0x5a, 0xd9, 0xd3, 0x87, 0x74, 0xe2, 0xa3, 0xda, 0x03, 0xa8, 0x5b,
+	0xed, 0xaf, 0x9d, 0x2e, 0xbe, 0x1c, 0xad, 0x3b, 0x6f, 0x33, 0x63, 0x16, 0x07, 0x00, 0xcb, 0x89,
+	0x20, 0x9d, 0x37, 0x8e, 0x52, 0x6e, 0x85, 0x5a, 0x1f, 0xdc, 0xe0, 0xa1, 0x2f, 0x7c, 0x42, 0xff,
+	0xba, 0x6c, 0x3b, 0xaf, 0x2f, 0xdb, 0xce, 0x3f, 0x97, 0x6d, 0xe7, 0xb7, 0xab, 0xf6, 0xca, 0xeb,
+	0xab, 0xf6, 0xca, 0xdf, 0x57, 0xed, 0x95, 0xd3, 0x1a, 0xfe, 0x1f, 0x7a, 0xf0, 0xef, 0x00, 0x7b,
+	0xb3, 0xa5, 0xdf, 0xd6, 0x09, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IndexServiceClient is the client API for IndexService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IndexServiceClient interface {
+	DeleteDoc(ctx context.Context, in *DocId, opts ...grpc.CallOption) (*AffectedCount, error)
+	AddDoc(ctx context.Context, in *types.Document, opts ...grpc.CallOption) (*AffectedCount, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResult, error)
+	Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*types.Explanation, error)
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResult, error)
+	SpellCheck(ctx context.Context, in *SpellCheckRequest, opts ...grpc.CallOption) (*SpellCheckResult, error)
+}
+
+type indexServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIndexServiceClient(cc *grpc.ClientConn) IndexServiceClient {
+	return &indexServiceClient{cc}
+}
+
+func (c *indexServiceClient) DeleteDoc(ctx context.Context, in *DocId, opts ...grpc.CallOption) (*AffectedCount, error) {
+	out := new(AffectedCount)
+	err := c.cc.Invoke(ctx, "/index_service.IndexService/DeleteDoc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) AddDoc(ctx context.Context, in *types.Document, opts ...grpc.CallOption) (*AffectedCount, error) {
+	out := new(AffectedCount)
+	err := c.cc.Invoke(ctx, "/index_service.IndexService/AddDoc", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResult, error) {
+	out := new(SearchResult)
+	err := c.cc.Invoke(ctx, "/index_service.IndexService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Explain(ctx context.Context, in *ExplainRequest, opts ...grpc.CallOption) (*types.Explanation, error) {
+	out := new(types.Explanation)
+	err := c.cc.Invoke(ctx, "/index_service.IndexService/Explain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResult, error) {
+	out := new(SuggestResult)
+	err := c.cc.Invoke(ctx, "/index_service.IndexService/Suggest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexServiceClient) SpellCheck(ctx context.Context, in *SpellCheckRequest, opts ...grpc.CallOption) (*SpellCheckResult, error) {
+	out := new(SpellCheckResult)
+	err := c.cc.Invoke(ctx, "/index_service.IndexService/SpellCheck", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IndexServiceServer is the server API for IndexService service.
+type IndexServiceServer interface {
+	DeleteDoc(context.Context, *DocId) (*AffectedCount, error)
+	AddDoc(context.Context, *types.Document) (*AffectedCount, error)
+	Search(context.Context, *SearchRequest) (*SearchResult, error)
+	Explain(context.Context, *ExplainRequest) (*types.Explanation, error)
+	Suggest(context.Context, *SuggestRequest) (*SuggestResult, error)
+	SpellCheck(context.Context, *SpellCheckRequest) (*SpellCheckResult, error)
+}
+
+// UnimplementedIndexServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedIndexServiceServer struct {
+}
+
+func (*UnimplementedIndexServiceServer) DeleteDoc(ctx context.Context, req *DocId) (*AffectedCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteDoc not implemented")
+}
+func (*UnimplementedIndexServiceServer) AddDoc(ctx context.Context, req *types.Document) (*AffectedCount, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDoc not implemented")
+}
+func (*UnimplementedIndexServiceServer) Search(ctx context.Context, req *SearchRequest) (*SearchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedIndexServiceServer) Explain(ctx context.Context, req *ExplainRequest) (*types.Explanation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Explain not implemented")
+}
+func (*UnimplementedIndexServiceServer) Suggest(ctx context.Context, req *SuggestRequest) (*SuggestResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (*UnimplementedIndexServiceServer) SpellCheck(ctx context.Context, req *SpellCheckRequest) (*SpellCheckResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SpellCheck not implemented")
+}
+
+func RegisterIndexServiceServer(s *grpc.Server, srv IndexServiceServer) {
+	s.RegisterService(&_IndexService_serviceDesc, srv)
+}
+
+func _IndexService_DeleteDoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).DeleteDoc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index_service.IndexService/DeleteDoc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).DeleteDoc(ctx, req.(*DocId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_AddDoc_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.Document)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).AddDoc(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index_service.IndexService/AddDoc",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).AddDoc(ctx, req.(*types.Document))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index_service.IndexService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Explain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExplainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Explain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index_service.IndexService/Explain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Explain(ctx, req.(*ExplainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index_service.IndexService/Suggest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexService_SpellCheck_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpellCheckRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexServiceServer).SpellCheck(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/index_service.IndexService/SpellCheck",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexServiceServer).SpellCheck(ctx, req.(*SpellCheckRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _IndexService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "index_service.IndexService",
+	HandlerType: (*IndexServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeleteDoc",
+			Handler:    _IndexService_DeleteDoc_Handler,
+		},
+		{
+			MethodName: "AddDoc",
+			Handler:    _IndexService_AddDoc_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _IndexService_Search_Handler,
+		},
+		{
+			MethodName: "Explain",
+			Handler:    _IndexService_Explain_Handler,
+		},
+		{
+			MethodName: "Suggest",
+			Handler:    _IndexService_Suggest_Handler,
+		},
+		{
+			MethodName: "SpellCheck",
+			Handler:    _IndexService_SpellCheck_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "index.proto",
+}
+
+func (m *DocId) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DocId) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DocId) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DocId) > 0 {
+		i -= len(m.DocId)
+		copy(dAtA[i:], m.DocId)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.DocId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *AffectedCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *AffectedCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *AffectedCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Count != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Rerank != nil {
+		{
+			size, err := m.Rerank.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.Vector != nil {
+		{
+			size, err := m.Vector.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.QueryString) > 0 {
+		i -= len(m.QueryString)
+		copy(dAtA[i:], m.QueryString)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.QueryString)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.DisableSynonyms {
+		i--
+		if m.DisableSynonyms {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.Profile {
+		i--
+		if m.Profile {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Facets != nil {
+		{
+			size, err := m.Facets.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.SortBy) > 0 {
+		for iNdEx := len(m.SortBy) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SortBy[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIndex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Cursor) > 0 {
+		i -= len(m.Cursor)
+		copy(dAtA[i:], m.Cursor)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Cursor)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Offset != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Offset))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Limit != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Ranges != nil {
+		{
+			size, err := m.Ranges.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.TopK != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.TopK))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.OrFlags) > 0 {
+		dAtA6 := make([]byte, len(m.OrFlags)*10)
+		var j5 int
+		for _, num := range m.OrFlags {
+			for num >= 1<<7 {
+				dAtA6[j5] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j5++
+			}
+			dAtA6[j5] = uint8(num)
+			j5++
+		}
+		i -= j5
+		copy(dAtA[i:], dAtA6[:j5])
+		i = encodeVarintIndex(dAtA, i, uint64(j5))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.OffFlag != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.OffFlag))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.OnFlag != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.OnFlag))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Query != nil {
+		{
+			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RerankRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RerankRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RerankRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Window != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Window))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Names) > 0 {
+		for iNdEx := len(m.Names) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Names[iNdEx])
+			copy(dAtA[i:], m.Names[iNdEx])
+			i = encodeVarintIndex(dAtA, i, uint64(len(m.Names[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.DidYouMean) > 0 {
+		for iNdEx := len(m.DidYouMean) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.DidYouMean[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIndex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.Profile != nil {
+		{
+			size, err := m.Profile.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Facets != nil {
+		{
+			size, err := m.Facets.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.NextCursor) > 0 {
+		i -= len(m.NextCursor)
+		copy(dAtA[i:], m.NextCursor)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.NextCursor)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Total != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Scores) > 0 {
+		for iNdEx := len(m.Scores) - 1; iNdEx >= 0; iNdEx-- {
+			f10 := math.Float64bits(float64(m.Scores[iNdEx]))
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f10))
+		}
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Scores)*8))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIndex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExplainRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExplainRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExplainRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Request != nil {
+		{
+			size, err := m.Request.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.DocId) > 0 {
+		i -= len(m.DocId)
+		copy(dAtA[i:], m.DocId)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.DocId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SuggestRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SuggestRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SuggestRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.PopularityWeight != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.PopularityWeight))))
+		i--
+		dAtA[i] = 0x25
+	}
+	if m.Limit != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Suggestion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Suggestion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Suggestion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.Popularity != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Popularity))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.DocFreq != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.DocFreq))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Word) > 0 {
+		i -= len(m.Word)
+		copy(dAtA[i:], m.Word)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Word)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SuggestResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SuggestResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SuggestResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Suggestions) > 0 {
+		for iNdEx := len(m.Suggestions) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Suggestions[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIndex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SpellCheckRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpellCheckRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpellCheckRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Query != nil {
+		{
+			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SpellCandidate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpellCandidate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpellCandidate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Distance != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.Distance))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.DocFreq != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.DocFreq))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Word) > 0 {
+		i -= len(m.Word)
+		copy(dAtA[i:], m.Word)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Word)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TermCandidates) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TermCandidates) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TermCandidates) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Candidates) > 0 {
+		for iNdEx := len(m.Candidates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Candidates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIndex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.DocFreq != 0 {
+		i = encodeVarintIndex(dAtA, i, uint64(m.DocFreq))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SpellCheckResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SpellCheckResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SpellCheckResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Terms) > 0 {
+		for iNdEx := len(m.Terms) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Terms[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIndex(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuerySuggestion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuerySuggestion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuerySuggestion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if len(m.QueryString) > 0 {
+		i -= len(m.QueryString)
+		copy(dAtA[i:], m.QueryString)
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.QueryString)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Query != nil {
+		{
+			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIndex(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIndex(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIndex(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *DocId) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	return n
+}
+
+func (m *AffectedCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Count != 0 {
+		n += 1 + sovIndex(uint64(m.Count))
+	}
+	return n
+}
+
+func (m *SearchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.OnFlag != 0 {
+		n += 1 + sovIndex(uint64(m.OnFlag))
+	}
+	if m.OffFlag != 0 {
+		n += 1 + sovIndex(uint64(m.OffFlag))
+	}
+	if len(m.OrFlags) > 0 {
+		l = 0
+		for _, e := range m.OrFlags {
+			l += sovIndex(uint64(e))
+		}
+		n += 1 + sovIndex(uint64(l)) + l
+	}
+	if m.TopK != 0 {
+		n += 1 + sovIndex(uint64(m.TopK))
+	}
+	if m.Ranges != nil {
+		l = m.Ranges.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovIndex(uint64(m.Limit))
+	}
+	if m.Offset != 0 {
+		n += 1 + sovIndex(uint64(m.Offset))
+	}
+	l = len(m.Cursor)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if len(m.SortBy) > 0 {
+		for _, e := range m.SortBy {
+			l = e.Size()
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	if m.Facets != nil {
+		l = m.Facets.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Profile {
+		n += 2
+	}
+	if m.DisableSynonyms {
+		n += 2
+	}
+	l = len(m.QueryString)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Vector != nil {
+		l = m.Vector.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Rerank != nil {
+		l = m.Rerank.Size()
+		n += 2 + l + sovIndex(uint64(l))
+	}
+	return n
+}
+
+func (m *RerankRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Names) > 0 {
+		for _, s := range m.Names {
+			l = len(s)
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	if m.Window != 0 {
+		n += 1 + sovIndex(uint64(m.Window))
+	}
+	return n
+}
+
+func (m *SearchResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	if len(m.Scores) > 0 {
+		n += 1 + sovIndex(uint64(len(m.Scores)*8)) + len(m.Scores)*8
+	}
+	if m.Total != 0 {
+		n += 1 + sovIndex(uint64(m.Total))
+	}
+	l = len(m.NextCursor)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Facets != nil {
+		l = m.Facets.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Profile != nil {
+		l = m.Profile.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if len(m.DidYouMean) > 0 {
+		for _, e := range m.DidYouMean {
+			l = e.Size()
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExplainRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	return n
+}
+
+func (m *SuggestRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Prefix)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovIndex(uint64(m.Limit))
+	}
+	if m.PopularityWeight != 0 {
+		n += 5
+	}
+	return n
+}
+
+func (m *Suggestion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Word)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.DocFreq != 0 {
+		n += 1 + sovIndex(uint64(m.DocFreq))
+	}
+	if m.Popularity != 0 {
+		n += 1 + sovIndex(uint64(m.Popularity))
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *SuggestResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Suggestions) > 0 {
+		for _, e := range m.Suggestions {
+			l = e.Size()
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SpellCheckRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	return n
+}
+
+func (m *SpellCandidate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Word)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.DocFreq != 0 {
+		n += 1 + sovIndex(uint64(m.DocFreq))
+	}
+	if m.Distance != 0 {
+		n += 1 + sovIndex(uint64(m.Distance))
+	}
+	return n
+}
+
+func (m *TermCandidates) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.DocFreq != 0 {
+		n += 1 + sovIndex(uint64(m.DocFreq))
+	}
+	if len(m.Candidates) > 0 {
+		for _, e := range m.Candidates {
+			l = e.Size()
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SpellCheckResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Terms) > 0 {
+		for _, e := range m.Terms {
+			l = e.Size()
+			n += 1 + l + sovIndex(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QuerySuggestion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
 	_ = l
 	if m.Query != nil {
 		l = m.Query.Size()
 		n += 1 + l + sovIndex(uint64(l))
 	}
-	if m.OnFlag != 0 {
-		n += 1 + sovIndex(uint64(m.OnFlag))
+	l = len(m.QueryString)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovIndex(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIndex(x uint64) (n int) {
+	return sovIndex(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DocId) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DocId: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DocId: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *AffectedCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: AffectedCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: AffectedCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Query == nil {
+				m.Query = &types.TermQuery{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OnFlag", wireType)
+			}
+			m.OnFlag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OnFlag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OffFlag", wireType)
+			}
+			m.OffFlag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.OffFlag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType == 0 {
+				var v uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIndex
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.OrFlags = append(m.OrFlags, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIndex
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIndex
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIndex
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.OrFlags) == 0 {
+					m.OrFlags = make([]uint64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIndex
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.OrFlags = append(m.OrFlags, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrFlags", wireType)
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TopK", wireType)
+			}
+			m.TopK = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TopK |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ranges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ranges == nil {
+				m.Ranges = &types.RangeFilter{}
+			}
+			if err := m.Ranges.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Offset", wireType)
+			}
+			m.Offset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Offset |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SortBy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SortBy = append(m.SortBy, &types.SortBy{})
+			if err := m.SortBy[len(m.SortBy)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Facets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Facets == nil {
+				m.Facets = &types.FacetRequest{}
+			}
+			if err := m.Facets.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Profile = bool(v != 0)
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DisableSynonyms", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DisableSynonyms = bool(v != 0)
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueryString = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vector", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vector == nil {
+				m.Vector = &types.VectorQuery{}
+			}
+			if err := m.Vector.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rerank", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Rerank == nil {
+				m.Rerank = &RerankRequest{}
+			}
+			if err := m.Rerank.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RerankRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RerankRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RerankRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Names", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Names = append(m.Names, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			m.Window = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Window |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Results = append(m.Results, &types.Document{})
+			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.Scores = append(m.Scores, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIndex
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIndex
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIndex
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.Scores) == 0 {
+					m.Scores = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.Scores = append(m.Scores, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Scores", wireType)
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Facets", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Facets == nil {
+				m.Facets = &types.FacetResult{}
+			}
+			if err := m.Facets.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Profile", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Profile == nil {
+				m.Profile = &types.SearchProfile{}
+			}
+			if err := m.Profile.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DidYouMean", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DidYouMean = append(m.DidYouMean, &QuerySuggestion{})
+			if err := m.DidYouMean[len(m.DidYouMean)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ExplainRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ExplainRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ExplainRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &SearchRequest{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SuggestRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SuggestRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SuggestRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PopularityWeight", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.PopularityWeight = float32(math.Float32frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Suggestion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Suggestion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Suggestion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Word", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Word = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocFreq", wireType)
+			}
+			m.DocFreq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DocFreq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Popularity", wireType)
+			}
+			m.Popularity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Popularity |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	if m.OffFlag != 0 {
-		n += 1 + sovIndex(uint64(m.OffFlag))
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	if len(m.OrFlags) > 0 {
-		l = 0
-		for _, e := range m.OrFlags {
-			l += sovIndex(uint64(e))
+	return nil
+}
+func (m *SuggestResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SuggestResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SuggestResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Suggestions", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Suggestions = append(m.Suggestions, &Suggestion{})
+			if err := m.Suggestions[len(m.Suggestions)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
-		n += 1 + sovIndex(uint64(l)) + l
 	}
-	return n
-}
 
-func (m *SearchResult) Size() (n int) {
-	if m == nil {
-		return 0
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for _, e := range m.Results {
-			l = e.Size()
-			n += 1 + l + sovIndex(uint64(l))
+	return nil
+}
+func (m *SpellCheckRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndex
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SpellCheckRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SpellCheckRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Query == nil {
+				m.Query = &types.TermQuery{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndex(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
 		}
 	}
-	return n
-}
 
-func sovIndex(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozIndex(x uint64) (n int) {
-	return sovIndex(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *DocId) Unmarshal(dAtA []byte) error {
+func (m *SpellCandidate) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -678,17 +4030,68 @@ func (m *DocId) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DocId: wiretype end group for non-group")
+			return fmt.Errorf("proto: SpellCandidate: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DocId: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SpellCandidate: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Word", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Word = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocFreq", wireType)
+			}
+			m.DocFreq = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DocFreq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			var stringLen uint64
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Distance", wireType)
+			}
+			m.Distance = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIndex
@@ -698,24 +4101,11 @@ func (m *DocId) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Distance |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthIndex
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIndex
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.DocId = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -737,7 +4127,7 @@ func (m *DocId) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *AffectedCount) Unmarshal(dAtA []byte) error {
+func (m *TermCandidates) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -760,17 +4150,49 @@ func (m *AffectedCount) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: AffectedCount: wiretype end group for non-group")
+			return fmt.Errorf("proto: TermCandidates: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: AffectedCount: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TermCandidates: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field DocFreq", wireType)
 			}
-			m.Count = 0
+			m.DocFreq = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIndex
@@ -780,11 +4202,45 @@ func (m *AffectedCount) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Count |= int32(b&0x7F) << shift
+				m.DocFreq |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidates = append(m.Candidates, &SpellCandidate{})
+			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -806,7 +4262,7 @@ func (m *AffectedCount) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SearchRequest) Unmarshal(dAtA []byte) error {
+func (m *SpellCheckResult) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -829,15 +4285,15 @@ func (m *SearchRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SearchRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SpellCheckResult: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SearchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SpellCheckResult: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Terms", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -864,127 +4320,11 @@ func (m *SearchRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Query == nil {
-				m.Query = &types.TermQuery{}
-			}
-			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Terms = append(m.Terms, &TermCandidates{})
+			if err := m.Terms[len(m.Terms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OnFlag", wireType)
-			}
-			m.OnFlag = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIndex
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.OnFlag |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field OffFlag", wireType)
-			}
-			m.OffFlag = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIndex
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.OffFlag |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 4:
-			if wireType == 0 {
-				var v uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowIndex
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					v |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				m.OrFlags = append(m.OrFlags, v)
-			} else if wireType == 2 {
-				var packedLen int
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowIndex
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					packedLen |= int(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				if packedLen < 0 {
-					return ErrInvalidLengthIndex
-				}
-				postIndex := iNdEx + packedLen
-				if postIndex < 0 {
-					return ErrInvalidLengthIndex
-				}
-				if postIndex > l {
-					return io.ErrUnexpectedEOF
-				}
-				var elementCount int
-				var count int
-				for _, integer := range dAtA[iNdEx:postIndex] {
-					if integer < 128 {
-						count++
-					}
-				}
-				elementCount = count
-				if elementCount != 0 && len(m.OrFlags) == 0 {
-					m.OrFlags = make([]uint64, 0, elementCount)
-				}
-				for iNdEx < postIndex {
-					var v uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowIndex
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						v |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					m.OrFlags = append(m.OrFlags, v)
-				}
-			} else {
-				return fmt.Errorf("proto: wrong wireType = %d for field OrFlags", wireType)
-			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -1006,7 +4346,7 @@ func (m *SearchRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SearchResult) Unmarshal(dAtA []byte) error {
+func (m *QuerySuggestion) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1029,15 +4369,15 @@ func (m *SearchResult) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SearchResult: wiretype end group for non-group")
+			return fmt.Errorf("proto: QuerySuggestion: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SearchResult: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: QuerySuggestion: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Results", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1064,11 +4404,56 @@ func (m *SearchResult) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Results = append(m.Results, &types.Document{})
-			if err := m.Results[len(m.Results)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if m.Query == nil {
+				m.Query = &types.TermQuery{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field QueryString", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndex
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.QueryString = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -1,11 +1,16 @@
 package index_service
 
 import (
+	"RADIC/internal/rerank"
 	"RADIC/types"
 	"RADIC/util"
 	"context"
+	"errors"
 	"fmt"
 	etcdv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"math"
 	"strconv"
 	"time"
 )
@@ -32,9 +37,9 @@ type IndexServiceWorker struct {
 }
 
 // Init 初始化索引
-func (service *IndexServiceWorker) Init(DocNumEstimate int, dbtype int, DataDir string, etcdServers []string, servicePort int) error {
+func (service *IndexServiceWorker) Init(DocNumEstimate int, dbtype int, indexType int, DataDir string, etcdServers []string, servicePort int) error {
 	service.Indexer = new(Indexer)
-	service.Indexer.Init(DocNumEstimate, dbtype, DataDir)
+	service.Indexer.Init(DocNumEstimate, dbtype, indexType, DataDir)
 
 	// 向注册中心注册自己
 	if len(etcdServers) > 0 {
@@ -94,8 +99,93 @@ func (service *IndexServiceWorker) AddDoc(ctx context.Context, doc *types.Docume
 	return &AffectedCount{int32(n)}, err
 }
 
+// validateSearchRequest 倒排索引对不合法的查询只会返回空结果，调用方分不清是没命中还是写错了，所以在入口处检查
+func validateSearchRequest(request *SearchRequest) error {
+	if request.QueryString != "" {
+		if _, err := types.ParseQuery(request.QueryString); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query string: %v", err)
+		}
+	} else if request.Query != nil || request.Vector == nil { // 只做向量检索时可以没有Query
+		if err := request.Query.Validate(); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query: %v", err)
+		}
+	}
+	if err := request.Vector.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid vector query: %v", err)
+	}
+	if err := request.Ranges.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid ranges: %v", err)
+	}
+	if err := types.ValidateSortBy(request.SortBy); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid sort: %v", err)
+	}
+	if err := request.Facets.Validate(); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid facets: %v", err)
+	}
+	if request.Limit < 0 || request.Offset < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid page: limit %d, offset %d", request.Limit, request.Offset)
+	}
+	if r := request.Rerank; r != nil {
+		if len(r.Names) == 0 || r.Window < 0 || r.Window > rerank.MAX_RERANK_WINDOW {
+			return status.Errorf(codes.InvalidArgument, "invalid rerank: names %v, window %d", r.Names, r.Window)
+		}
+		if request.Cursor != "" {
+			return status.Errorf(codes.InvalidArgument, "cursor is not supported with rerank")
+		}
+		if !rerankScored(request) {
+			return status.Errorf(codes.InvalidArgument, "rerank needs a scored search: set TopK, sort by _score or search with a vector")
+		}
+	}
+	return nil
+}
+
+// Search 检索，查询不合法时返回InvalidArgument
 func (service *IndexServiceWorker) Search(ctx context.Context, request *SearchRequest) (*SearchResult, error) {
-	result := service.Indexer.Search(request.Query, request.OnFlag, request.OffFlag, request.OrFlags)
-	return &SearchResult{Results: result}, nil
+	if err := validateSearchRequest(request); err != nil {
+		return nil, err
+	}
+	result, err := service.Indexer.Search(request)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return result, nil
+}
+
+// Explain 解释一篇文档为什么命中或没命中一次检索，文档不存在时返回NotFound
+func (service *IndexServiceWorker) Explain(ctx context.Context, request *ExplainRequest) (*types.Explanation, error) {
+	if request.Request == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "missing search request")
+	}
+	if err := validateSearchRequest(request.Request); err != nil {
+		return nil, err
+	}
+	explanation, err := service.Indexer.Explain(request.DocId, request.Request)
+	if errors.Is(err, ErrDocNotFound) {
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	} else if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return explanation, nil
+}
 
+// Suggest 搜索框的自动补全，参数不合法时返回InvalidArgument
+func (service *IndexServiceWorker) Suggest(ctx context.Context, request *SuggestRequest) (*SuggestResult, error) {
+	if request.Limit < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid limit %d", request.Limit)
+	}
+	if w := float64(request.PopularityWeight); !(w >= 0) || math.IsInf(w, 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid popularity weight %v", request.PopularityWeight)
+	}
+	return service.Indexer.Suggest(request), nil
+}
+
+// SpellCheck 拼写纠错的候选，由Sentinel在零命中时调用
+func (service *IndexServiceWorker) SpellCheck(ctx context.Context, request *SpellCheckRequest) (*SpellCheckResult, error) {
+	if request.Query == nil {
+		return nil, status.Error(codes.InvalidArgument, "query is required")
+	}
+	if err := request.Query.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return service.Indexer.SpellCheck(request), nil
 }
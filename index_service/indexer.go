@@ -1,14 +1,24 @@
 package index_service
 
 import (
+	"RADIC/internal/analyzer"
 	"RADIC/internal/kvdb"
+	"RADIC/internal/rerank"
 	"RADIC/internal/reverse_index"
+	"RADIC/internal/suggest"
+	"RADIC/internal/synonym"
+	"RADIC/internal/vector_index"
 	"RADIC/types"
 	"bytes"
 	"encoding/gob"
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
 	"strings"
 	"sync/atomic"
+	"time"
 )
 
 // 外观模式：把正排和倒排2个子系统封装在一起，对外提供更简单的接口
@@ -18,21 +28,73 @@ type Indexer struct {
 	forwardIndex kvdb.IKeyVakyeDB
 	reverseIndex reverse_index.IReverseIndexer
 	maxIntId     uint64
+	synonyms     *synonym.Dict // 为nil时不做同义词扩展
+	analyzer     *analyzer.Analyzer
+	popularity   *suggest.Popularity // 检索日志里每个词的热度，补全时加权
+	vectors      *vector_index.HNSW  // Document.Vector的近似最近邻索引
+	rerankers    *rerank.Registry    // 按名字注册的重排器
 }
 
-// Init 初始化索引
-func (indexer *Indexer) Init(DocNumEstimate, dbtype int, path string) error {
+// Init 初始化索引，dbtype选择正排索引的kv数据库，indexType选择倒排索引的实现(reverse_index.SKIPLIST或reverse_index.BITMAP)
+func (indexer *Indexer) Init(DocNumEstimate, dbtype, indexType int, path string) error {
 	db, err := kvdb.GetKvDb(dbtype, path)
 	if err != nil {
 		return err
 	}
 	indexer.forwardIndex = db
-	indexer.reverseIndex = reverse_index.NewSkipListReverseIndex(DocNumEstimate)
+	indexer.reverseIndex = reverse_index.GetReverseIndex(indexType, DocNumEstimate)
+	indexer.analyzer = analyzer.NewAnalyzer(nil)
+	indexer.popularity = suggest.NewPopularity()
+	indexer.vectors = vector_index.NewHNSW(DocNumEstimate)
+	indexer.rerankers = rerank.NewRegistry()
 
 	return nil
 }
 
+// SetAnalyzer 替换分析文档TextFields和查询字符串用的分析器。需在加载数据之前调用，换了分析器要重建索引
+func (indexer *Indexer) SetAnalyzer(a *analyzer.Analyzer) {
+	indexer.analyzer = a
+}
+
+// LoadSynonyms 加载同义词文件，检索时把查询里的词扩展成同义词。reloadInterval大于0时定期检查文件，变了就重新加载。
+// 需在开始检索之前调用
+func (indexer *Indexer) LoadSynonyms(path string, reloadInterval time.Duration) error {
+	dict, err := synonym.LoadDict(path)
+	if err != nil {
+		return err
+	}
+	dict.Watch(reloadInterval)
+	indexer.synonyms.Close()
+	indexer.synonyms = dict
+	return nil
+}
+
+// ReloadSynonyms 立即重新加载同义词文件，失败时继续使用之前的同义词
+func (indexer *Indexer) ReloadSynonyms() error {
+	return indexer.synonyms.Reload()
+}
+
+// query 实际检索的查询树：request.QueryString解析、分析后的结果(为空时用request.Query)，再做同义词扩展
+func (indexer *Indexer) query(request *SearchRequest) (*types.TermQuery, error) {
+	query := request.Query
+	if request.QueryString != "" {
+		parsed, err := types.ParseQuery(request.QueryString)
+		if err != nil {
+			return nil, fmt.Errorf("query string %q: %w", request.QueryString, err)
+		}
+		query = indexer.analyzer.AnalyzeQuery(parsed)
+		if err := query.Validate(); err != nil {
+			return nil, fmt.Errorf("query string %q: %w", request.QueryString, err)
+		}
+	}
+	if request.DisableSynonyms {
+		return query, nil
+	}
+	return indexer.synonyms.Expand(query), nil
+}
+
 func (indexer *Indexer) Close() error {
+	indexer.synonyms.Close()
 	return indexer.forwardIndex.Close()
 }
 
@@ -52,9 +114,8 @@ func (indexer *Indexer) DeleteDoc(docId string) int {
 			var doc types.Document
 			err := decoder.Decode(&doc)
 			if err == nil {
-				for _, kw := range doc.Keywords {
-					indexer.reverseIndex.Delete(doc.IntId, kw)
-				}
+				indexer.reverseIndex.DeleteDoc(doc.IntId, doc.Keywords)
+				indexer.vectors.Delete(doc.IntId)
 			}
 		}
 	}
@@ -69,16 +130,22 @@ func (indexer *Indexer) AddDoc(doc types.Document) (int, error) {
 	if len(docId) == 0 {
 		return 0, nil
 	}
+	doc.IntId = atomic.AddUint64(&indexer.maxIntId, 1) // 原子性+1，支持并发
+	// 向量的检查和插入在同一把锁里完成，放在最前面：不合法的文档不会把旧的冲掉，也不会留下只写了一半的正排、倒排
+	if err := indexer.vectors.Add(doc); err != nil {
+		return 0, err
+	}
 
 	// 删除存在的doc
 	indexer.DeleteDoc(docId)
 
-	doc.IntId = atomic.AddUint64(&doc.IntId, 1) // 原子性+1，支持并发
+	indexer.analyzer.AnalyzeDocument(&doc) // 切出的词和原文一起存进正排，删除时才能从倒排上删干净
 
 	// 写入正排索引
 	var value bytes.Buffer
 	encoder := gob.NewEncoder(&value)
 	if err := encoder.Encode(doc); err != nil {
+		indexer.vectors.Delete(doc.IntId)
 		return 0, nil
 	} else {
 		indexer.forwardIndex.Set([]byte(docId), value.Bytes())
@@ -104,17 +171,178 @@ func (indexer *Indexer) LoadFromIndexFile() int {
 			)
 			return err
 		}
+		// 加载的文档沿用存下来的IntId，新增的文档要排在它们后面，否则会与加载的文档撞号
+		for maxIntId := atomic.LoadUint64(&indexer.maxIntId); doc.IntId > maxIntId; maxIntId = atomic.LoadUint64(&indexer.maxIntId) {
+			if atomic.CompareAndSwapUint64(&indexer.maxIntId, maxIntId, doc.IntId) {
+				break
+			}
+		}
+		indexer.analyzer.WithTextFields(slices.Collect(maps.Keys(doc.TextFields))...) // 加载时不再分析文档，检索时仍要知道哪些field分过词
 		indexer.reverseIndex.Add(doc)
-		return err
+		if err := indexer.vectors.Add(doc); err != nil {
+			slog.Warn("add vector failed", slog.Any("err", err)) // 维度不一致等，文档照样可以用关键词检索
+		}
+		return nil
 	})
 	slog.Info("load data from forward index",
 		slog.Any("dataNum", n))
 	return int(n)
 }
 
-// Search 检索，返回文档列表
-func (indexer *Indexer) Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlag []uint64) []*types.Document {
-	docIds := indexer.reverseIndex.Search(query, onFlag, offFlag, orFlag)
+// Search 检索，返回一页文档和命中总数，只有这一页的文档才会从正排索引里读出来。
+// request.TopK大于0时按BM25得分从高到低排序并返回得分，否则按入库顺序(IntId)排序，得分为nil。
+// 指定了request.SortBy时按SortBy排序，SortBy里有_score时也会打分。游标不合法时返回error。
+// request.Profile为true时在结果里返回各阶段的耗时
+// request.QueryString不为空时先解析并用建索引时的分析器处理，代替request.Query。
+// 加载了同义词时再做同义词扩展，request.DisableSynonyms为true时不扩展。
+// request.Vector不为空时做混合检索，见hybridSearch；request.Rerank不为空时检索之后再重排，见rerankSearch
+func (indexer *Indexer) Search(request *SearchRequest) (*SearchResult, error) {
+	if request.Rerank != nil {
+		return indexer.rerankSearch(request)
+	}
+	start := time.Now()
+	if request.Vector != nil {
+		return indexer.hybridSearch(request, start)
+	}
+	scored := request.TopK > 0 || types.SortsByScore(request.SortBy)
+	page := reverse_index.Page{Offset: int(request.Offset), Limit: int(request.Limit), SortBy: request.SortBy}
+	if request.TopK > 0 && page.Limit <= 0 {
+		page.Limit = int(request.TopK) // 兼容只传TopK的旧客户端
+	}
+	cursor, err := reverse_index.DecodeCursor(request.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.Check(request.SortBy, scored); err != nil {
+		return nil, err
+	}
+	page.After = cursor
+
+	query, err := indexer.query(request)
+	if err != nil {
+		return nil, err
+	}
+	var hits reverse_index.Hits
+	if scored {
+		hits = indexer.reverseIndex.SearchTopK(query, request.OnFlag, request.OffFlag, request.OrFlags, request.Ranges, request.Facets, request.Profile, page)
+	} else {
+		hits = indexer.reverseIndex.Search(query, request.OnFlag, request.OffFlag, request.OrFlags, request.Ranges, request.Facets, request.Profile, page)
+	}
+
+	result := &SearchResult{Total: int64(hits.Total), Facets: hits.Facets, Profile: hits.Profile}
+	result.Results, result.Scores = indexer.fetch(hits.Docs, scored, hits.Profile)
+	if hits.Next != nil {
+		result.NextCursor = hits.Next.Encode()
+	}
+	if result.Profile != nil {
+		result.Profile.TotalNanos = time.Since(start).Nanoseconds()
+	}
+	return result, nil
+}
+
+// fetch 从正排索引读出docs对应的文档，scored为true时同时返回与文档一一对应的得分，否则得分为nil
+func (indexer *Indexer) fetch(docs []reverse_index.ScoredDoc, scored bool, profile *types.SearchProfile) ([]*types.Document, []float64) {
+	docIds := make([]string, 0, len(docs))
+	for _, sd := range docs {
+		docIds = append(docIds, sd.Id)
+	}
+	results := indexer.getDocs(docIds, profile)
+	if !scored {
+		return results, nil
+	}
+	// 正排里可能已经读不到某些文档了，得分要跟着实际返回的文档走
+	scoreOf := make(map[string]float64, len(docs))
+	for _, sd := range docs {
+		scoreOf[sd.Id] = sd.Score
+	}
+	scores := make([]float64, 0, len(results))
+	for _, doc := range results {
+		scores = append(scores, scoreOf[doc.Id])
+	}
+	return results, scores
+}
+
+// ErrDocNotFound 正排索引里没有这个文档
+var ErrDocNotFound = errors.New("document not found")
+
+// Explain 解释docId对应的文档在request这次检索中为什么命中或没命中：查询树每个节点的求值结果、每个位过滤和范围过滤条件的判断、得分。
+// request中的分页、排序、聚合不影响文档是否命中，不起作用
+func (indexer *Indexer) Explain(docId string, request *SearchRequest) (*types.Explanation, error) {
+	docs := indexer.getDocs([]string{docId}, nil)
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrDocNotFound, docId)
+	}
+	doc := docs[0]
+	scored := request.TopK > 0 || types.SortsByScore(request.SortBy)
+	query, err := indexer.query(request)
+	if err != nil {
+		return nil, err
+	}
+	if query == nil {
+		return nil, fmt.Errorf("explain needs a query, vector search is not explained") // 只做向量检索的请求
+	}
+
+	explanation := &types.Explanation{
+		DocId:       doc.Id,
+		Query:       indexer.reverseIndex.Explain(doc.IntId, query, scored),
+		BitsFeature: doc.BitsFeature,
+		Flags:       explainFlags(doc.BitsFeature, request.OnFlag, request.OffFlag, request.OrFlags),
+		Ranges:      explainRanges(doc, request.Ranges),
+	}
+	explanation.Matched = explanation.Query.Matched
+	for _, flag := range explanation.Flags {
+		explanation.Matched = explanation.Matched && flag.Passed
+	}
+	for _, r := range explanation.Ranges {
+		explanation.Matched = explanation.Matched && r.Passed
+	}
+	if scored {
+		explanation.Score = explanation.Query.Score
+	}
+	return explanation, nil
+}
+
+// explainFlags 与reverse_index.FilterByBits的判断一致，为0的条件不起作用，不列出
+func explainFlags(bits uint64, onFlag uint64, offFlag uint64, orFlags []uint64) []*types.FlagExplanation {
+	flags := make([]*types.FlagExplanation, 0, 2+len(orFlags))
+	if onFlag > 0 {
+		flags = append(flags, &types.FlagExplanation{Name: "onFlag", Flag: onFlag, Passed: bits&onFlag == onFlag})
+	}
+	if offFlag > 0 {
+		flags = append(flags, &types.FlagExplanation{Name: "offFlag", Flag: offFlag, Passed: bits&offFlag == 0})
+	}
+	for i, orFlag := range orFlags {
+		if orFlag > 0 {
+			flags = append(flags, &types.FlagExplanation{Name: fmt.Sprintf("orFlags[%d]", i), Flag: orFlag, Passed: bits&orFlag != 0})
+		}
+	}
+	return flags
+}
+
+// explainRanges 用正排里的数值属性判断每个范围条件
+func explainRanges(doc *types.Document, ranges *types.RangeFilter) []*types.RangeExplanation {
+	if ranges.Empty() {
+		return nil
+	}
+	result := make([]*types.RangeExplanation, 0, len(ranges.Ints)+len(ranges.Floats))
+	for _, r := range ranges.Ints {
+		v, ok := doc.IntFeatures[r.Field]
+		result = append(result, &types.RangeExplanation{Field: r.Field, HasValue: ok, Passed: ok && r.Contains(v)})
+	}
+	for _, r := range ranges.Floats {
+		v, ok := doc.FloatFeatures[r.Field]
+		result = append(result, &types.RangeExplanation{Field: r.Field, HasValue: ok, Passed: ok && r.Contains(v)})
+	}
+	return result
+}
+
+// CacheStats 倒排索引结果缓存的命中、未命中次数等统计
+func (indexer *Indexer) CacheStats() reverse_index.CacheStats {
+	return indexer.reverseIndex.CacheStats()
+}
+
+// getDocs 从正排索引中批量读取文档，返回顺序与docIds一致，读不到的文档会被跳过。profile不为nil时记录读取和解码的开销
+func (indexer *Indexer) getDocs(docIds []string, profile *types.SearchProfile) []*types.Document {
 	if len(docIds) == 0 {
 		return nil
 	}
@@ -122,24 +350,45 @@ func (indexer *Indexer) Search(query *types.TermQuery, onFlag uint64, offFlag ui
 	for _, docId := range docIds {
 		keys = append(keys, []byte(docId))
 	}
+	start := time.Now()
 	data, err := indexer.forwardIndex.BatchGet(keys)
 	if err != nil {
 		slog.Warn("read kvdb failed", slog.Any("err", err))
 		return nil
 	}
-	result := make([]*types.Document, 0, len(data))
+	if profile != nil {
+		profile.FetchNanos = time.Since(start).Nanoseconds()
+		start = time.Now()
+	}
+	docMap := make(map[string]*types.Document, len(data))
 	reader := bytes.NewReader([]byte{})
 
 	for _, docBs := range data {
 		if len(docBs) > 0 {
+			if profile != nil {
+				profile.FetchedDocs++
+				profile.FetchedBytes += int64(len(docBs))
+			}
 			reader.Reset(docBs)
 			decoder := gob.NewDecoder(reader)
 			var doc types.Document
 			err := decoder.Decode(&doc)
 			if err == nil {
-				result = append(result, &doc)
+				docMap[doc.Id] = &doc
 			}
 		}
 	}
+
+	if profile != nil {
+		profile.DecodeNanos = time.Since(start).Nanoseconds()
+	}
+
+	// BatchGet不保证顺序，按docIds的顺序重新排列
+	result := make([]*types.Document, 0, len(docMap))
+	for _, docId := range docIds {
+		if doc, exists := docMap[docId]; exists {
+			result = append(result, doc)
+		}
+	}
 	return result
 }
@@ -0,0 +1,70 @@
+package index_service
+
+import (
+	"RADIC/internal/rerank"
+	"RADIC/types"
+	"fmt"
+	"time"
+)
+
+// RegisterReranker 注册重排器，检索时通过SearchRequest.Rerank按名字选用。名字重复时返回error
+func (indexer *Indexer) RegisterReranker(name string, reranker rerank.Reranker) error {
+	return indexer.rerankers.Register(name, reranker)
+}
+
+// rerankSearch 先从头取出候选(至少Window个，不够请求的这一页时取到这一页为止)，重排前Window个，剩下的保持原来的顺序，再按Offset、Limit分页。
+// Limit和TopK都没有设置时只取Window个候选，返回重排后的整个窗口
+// 重排器在得分的基础上调整，所以检索必须打分(TopK大于0、按_score排序或者带向量查询)
+func (indexer *Indexer) rerankSearch(request *SearchRequest) (*SearchResult, error) {
+	start := time.Now()
+	if request.Cursor != "" {
+		return nil, fmt.Errorf("cursor is not supported with rerank")
+	}
+	if !rerankScored(request) {
+		return nil, fmt.Errorf("rerank needs a scored search: set TopK, sort by _score or search with a vector")
+	}
+	chain, err := indexer.rerankers.Chain(request.Rerank.Names)
+	if err != nil {
+		return nil, err
+	}
+	window := rerank.Window(request.Rerank.Window)
+	limit := int(request.Limit)
+	if limit <= 0 {
+		limit = int(request.TopK) // 与Search一样兼容只传TopK的旧客户端
+	}
+
+	candidates := *request // 只改分页，其他条件不变
+	candidates.Rerank, candidates.Offset, candidates.Limit = nil, 0, int32(window)
+	if limit > 0 {
+		candidates.Limit = int32(max(window, int(request.Offset)+limit))
+	}
+	result, err := indexer.Search(&candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	docs, scores := result.Results, result.Scores
+	rerankStart := time.Now()
+	n := min(window, len(docs))
+	reranked, rerankedScores := chain.Rerank(docs[:n], scores[:n])
+	docs, scores = append(reranked, docs[n:]...), append(rerankedScores, scores[n:]...)
+	if result.Profile != nil {
+		result.Profile.RerankNanos = time.Since(rerankStart).Nanoseconds()
+	}
+
+	offset := min(int(request.Offset), len(docs))
+	docs, scores = docs[offset:], scores[offset:]
+	if limit > 0 {
+		docs, scores = docs[:min(limit, len(docs))], scores[:min(limit, len(scores))]
+	}
+	result.Results, result.Scores, result.NextCursor = docs, scores, ""
+	if result.Profile != nil {
+		result.Profile.TotalNanos = time.Since(start).Nanoseconds()
+	}
+	return result, nil
+}
+
+// rerankScored 检索是否打分。不打分时候选的顺序是入库顺序，也没有得分可以调整
+func rerankScored(request *SearchRequest) bool {
+	return request.TopK > 0 || types.SortsByScore(request.SortBy) || request.Vector != nil
+}
@@ -0,0 +1,86 @@
+package index_service
+
+import (
+	"RADIC/internal/suggest"
+	"RADIC/types"
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SpellCheck 拼写纠错的候选：查询里参与打分的每个词在本分片的文档频率，原词在本分片不存在时再从词典里找编辑距离小的词
+func (indexer *Indexer) SpellCheck(request *SpellCheckRequest) *SpellCheckResult {
+	terms := request.Query.LeafTerms()
+	result := &SpellCheckResult{Terms: make([]*TermCandidates, 0, len(terms))}
+	for _, term := range terms {
+		candidates := &TermCandidates{Key: term.Key, DocFreq: int64(indexer.reverseIndex.DocFreq(term.Key))}
+		if candidates.DocFreq == 0 { // 本分片没有这个词时才找候选。有的话整个集群也就有，不需要纠正
+			field, word, _ := strings.Cut(term.Key, "\001")
+			for _, similar := range indexer.reverseIndex.SimilarTerms(field, word, suggest.SpellMaxEdits(word), suggest.MAX_SPELL_CANDIDATES) {
+				candidates.Candidates = append(candidates.Candidates, &SpellCandidate{
+					Word:     similar.Word,
+					DocFreq:  int64(similar.DocFreq),
+					Distance: int32(similar.Distance),
+				})
+			}
+		}
+		result.Terms = append(result.Terms, candidates)
+	}
+	return result
+}
+
+// didYouMean 根据各个分片的纠错候选生成纠正后的查询，最多MAX_SPELL_SUGGESTIONS个
+func didYouMean(query *types.TermQuery, results []*SpellCheckResult) []*QuerySuggestion {
+	corrected := suggest.Correct(query, mergeSpellCheck(results), suggest.MAX_SPELL_SUGGESTIONS)
+	suggestions := make([]*QuerySuggestion, 0, len(corrected))
+	for _, c := range corrected {
+		suggestions = append(suggestions, &QuerySuggestion{Query: c.Query, QueryString: c.Query.ToQueryString(), Score: c.Score})
+	}
+	return suggestions
+}
+
+// mergeSpellCheck 合并各个分片的纠错候选，返回在所有分片上都不存在的词和它们的候选。候选的文档频率要加总，
+// 加总后按文档频率从高到低，相同时编辑距离小的在前。每个分片只返回了自己的前几个候选，合并结果是近似的
+func mergeSpellCheck(results []*SpellCheckResult) []suggest.Correction {
+	keys := make([]string, 0, 4)
+	docFreq := make(map[string]int64, 4)
+	candidates := make(map[string]map[string]*suggest.Candidate, 4)
+	for _, result := range results {
+		for _, term := range result.GetTerms() {
+			if _, exists := docFreq[term.Key]; !exists {
+				keys = append(keys, term.Key)
+				candidates[term.Key] = make(map[string]*suggest.Candidate, len(term.Candidates))
+			}
+			docFreq[term.Key] += term.DocFreq
+			for _, c := range term.Candidates {
+				if m, exists := candidates[term.Key][c.Word]; exists {
+					m.DocFreq += c.DocFreq
+				} else {
+					candidates[term.Key][c.Word] = &suggest.Candidate{Word: c.Word, DocFreq: c.DocFreq, Distance: int(c.Distance)}
+				}
+			}
+		}
+	}
+
+	corrections := make([]suggest.Correction, 0, len(keys))
+	for _, key := range keys {
+		if docFreq[key] > 0 {
+			continue
+		}
+		merged := make([]suggest.Candidate, 0, len(candidates[key]))
+		for _, c := range candidates[key] {
+			merged = append(merged, *c)
+		}
+		slices.SortFunc(merged, func(a, b suggest.Candidate) int {
+			if c := cmp.Compare(b.DocFreq, a.DocFreq); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.Distance, b.Distance); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Word, b.Word)
+		})
+		corrections = append(corrections, suggest.Correction{Key: key, Candidates: merged[:min(suggest.MAX_SPELL_CANDIDATES, len(merged))]})
+	}
+	return corrections
+}
@@ -0,0 +1,104 @@
+package index_service
+
+import (
+	"RADIC/internal/suggest"
+	"RADIC/types"
+	"cmp"
+	"log/slog"
+	"os"
+	"slices"
+)
+
+const (
+	DEFAULT_SUGGEST_LIMIT = 10  // SuggestRequest.Limit为0时返回的词数
+	MAX_SUGGEST_LIMIT     = 100 // 一次补全最多返回的词数
+)
+
+// suggestLimit 没指定时用默认值，且不能超过上限
+func suggestLimit(limit int32) int {
+	if limit <= 0 {
+		return DEFAULT_SUGGEST_LIMIT
+	}
+	return min(int(limit), MAX_SUGGEST_LIMIT)
+}
+
+// LoadSearchLog 从检索日志(格式见suggest.ReadSearchLog)统计每个词的热度，累加到之前的统计上。
+// 查询字符串经过与检索时相同的分析器处理，解析失败的行跳过。返回统计了多少个查询
+func (indexer *Indexer) LoadSearchLog(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	n, skipped := 0, 0
+	err = suggest.ReadSearchLog(f, func(entry suggest.LogEntry) {
+		q, err := types.ParseQuery(entry.Query)
+		if err != nil {
+			skipped++
+			return
+		}
+		indexer.popularity.RecordQuery(indexer.analyzer.AnalyzeQuery(q), entry.Count)
+		n++
+	})
+	if skipped > 0 {
+		slog.Warn("skip invalid queries in search log", slog.String("path", path), slog.Int("skipped", skipped))
+	}
+	return n, err
+}
+
+// Suggest 补全：按文档频率从高到低返回request.Field下以request.Prefix开头的词，request.PopularityWeight大于0时再用检索热度加权
+func (indexer *Indexer) Suggest(request *SuggestRequest) *SuggestResult {
+	weight := float64(request.PopularityWeight)
+	popularity := func(word string) int64 {
+		if weight == 0 {
+			return 0 // 不加权时不用查热度
+		}
+		return indexer.popularity.Count(request.Field, word)
+	}
+	prefix := request.Prefix
+	if indexer.analyzer.IsTextField(request.Field) {
+		prefix = indexer.analyzer.Normalize(prefix)
+	}
+	terms := indexer.reverseIndex.TopTerms(request.Field, prefix, suggestLimit(request.Limit),
+		func(word string, docFreq int) float64 {
+			return suggest.Score(int64(docFreq), popularity(word), weight)
+		})
+	result := &SuggestResult{Suggestions: make([]*Suggestion, 0, len(terms))}
+	for _, term := range terms {
+		result.Suggestions = append(result.Suggestions, &Suggestion{
+			Word:       term.Word,
+			DocFreq:    int64(term.DocFreq),
+			Popularity: popularity(term.Word),
+			Score:      term.Score,
+		})
+	}
+	return result
+}
+
+// mergeSuggestions 合并各个分片的补全结果。文档分散在各个分片上，文档频率要加总；检索请求会发给所有分片，
+// 每个分片统计的热度是同一份日志，取最大的。加总后重新打分排序，取前limit个
+func mergeSuggestions(results []*SuggestResult, weight float64, limit int) *SuggestResult {
+	merged := make(map[string]*Suggestion, limit)
+	for _, result := range results {
+		for _, s := range result.GetSuggestions() {
+			if m, exists := merged[s.Word]; exists {
+				m.DocFreq += s.DocFreq
+				m.Popularity = max(m.Popularity, s.Popularity)
+			} else {
+				merged[s.Word] = &Suggestion{Word: s.Word, DocFreq: s.DocFreq, Popularity: s.Popularity}
+			}
+		}
+	}
+	suggestions := make([]*Suggestion, 0, len(merged))
+	for _, s := range merged {
+		s.Score = suggest.Score(s.DocFreq, s.Popularity, weight)
+		suggestions = append(suggestions, s)
+	}
+	slices.SortFunc(suggestions, func(a, b *Suggestion) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Word, b.Word)
+	})
+	return &SuggestResult{Suggestions: suggestions[:min(limit, len(suggestions))]}
+}
@@ -0,0 +1,106 @@
+package test
+
+import (
+	"RADIC/index_service"
+	"RADIC/internal/kvdb"
+	"RADIC/internal/reverse_index"
+	"RADIC/types"
+	"cmp"
+	"slices"
+	"testing"
+)
+
+// newIndexer 正排放在临时目录里的Indexer，测试结束时关闭
+func newIndexer(t *testing.T) *index_service.Indexer {
+	indexer := new(index_service.Indexer)
+	if err := indexer.Init(10, kvdb.BOLT, reverse_index.SKIPLIST, t.TempDir()+"/db"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { indexer.Close() })
+	return indexer
+}
+
+func addDoc(t *testing.T, indexer *index_service.Indexer, doc types.Document) {
+	if _, err := indexer.AddDoc(doc); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func keywords(words ...string) []*types.Keyword {
+	result := make([]*types.Keyword, 0, len(words))
+	for _, word := range words {
+		result = append(result, &types.Keyword{Field: "t", Word: word})
+	}
+	return result
+}
+
+func search(t *testing.T, indexer *index_service.Indexer, request *index_service.SearchRequest) ([]string, []float64, int64) {
+	result, err := indexer.Search(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, 0, len(result.Results))
+	for _, doc := range result.Results {
+		ids = append(ids, doc.Id)
+	}
+	return ids, result.Scores, result.Total
+}
+
+// newVectorIndexer 向量与[0,1]的相似度从高到低依次是d3、d4、d5、d2、d1；包含go的是d1和d4，d1更长，BM25得分低
+func newVectorIndexer(t *testing.T) *index_service.Indexer {
+	indexer := newIndexer(t)
+	addDoc(t, indexer, types.Document{Id: "d1", Vector: []float32{1, 0}, Keywords: keywords("go", "x", "y")})
+	addDoc(t, indexer, types.Document{Id: "d2", Vector: []float32{0.9, 0.1}, Keywords: keywords("java")})
+	addDoc(t, indexer, types.Document{Id: "d3", Vector: []float32{0, 1}, Keywords: keywords("java")})
+	addDoc(t, indexer, types.Document{Id: "d4", Vector: []float32{0.1, 0.9}, Keywords: keywords("go")})
+	addDoc(t, indexer, types.Document{Id: "d5", Vector: []float32{0.6, 0.8}, Keywords: keywords("java")})
+	return indexer
+}
+
+func TestHybridSearch(t *testing.T) {
+	indexer := newVectorIndexer(t)
+	golang := types.NewTermQuery("t", "go")
+	tests := []struct {
+		name    string
+		request *index_service.SearchRequest
+		want    []string
+		total   int64
+	}{
+		{"vector only", &index_service.SearchRequest{Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_RRF)}, []string{"d3", "d4", "d5"}, 3},
+		// d4在两路里都有，排第一；d3和d1分别是向量检索和关键词检索的第一名、第二名
+		{"rrf", &index_service.SearchRequest{Query: golang, Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_RRF)}, []string{"d4", "d3", "d1", "d5"}, 4},
+		{"rrf page", &index_service.SearchRequest{Query: golang, Offset: 1, Limit: 2, Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_RRF)}, []string{"d3", "d1"}, 4},
+		{"filter", &index_service.SearchRequest{Query: golang, Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_FILTER)}, []string{"d4", "d1"}, 2},
+		{"filter page", &index_service.SearchRequest{Query: golang, Offset: 1, Limit: 5, Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_FILTER)}, []string{"d1"}, 2},
+		{"offset beyond total", &index_service.SearchRequest{Query: golang, Offset: 9, Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_RRF)}, []string{}, 4},
+	}
+	for _, test := range tests {
+		ids, scores, total := search(t, indexer, test.request)
+		if !slices.Equal(ids, test.want) || total != test.total {
+			t.Errorf("%s: got %v total %d, want %v total %d", test.name, ids, total, test.want, test.total)
+		}
+		if len(scores) != len(ids) || !slices.IsSortedFunc(scores, func(a, b float64) int { return cmp.Compare(b, a) }) {
+			t.Errorf("%s: scores %v should be descending", test.name, scores)
+		}
+	}
+	if _, err := indexer.Search(&index_service.SearchRequest{Cursor: "x", Vector: types.NewVectorQuery([]float32{0, 1}, 3, types.FusionMode_FUSION_RRF)}); err == nil {
+		t.Error("cursor with vector query should fail")
+	}
+}
+
+func TestAddDocVector(t *testing.T) {
+	indexer := newVectorIndexer(t)
+	// 维度不对的文档整个不写入，也不会把同Id的旧文档冲掉
+	if _, err := indexer.AddDoc(types.Document{Id: "d1", Vector: []float32{1, 0, 0}, Keywords: keywords("z")}); err == nil {
+		t.Fatal("vector with wrong dimensions should fail")
+	}
+	if ids, _, _ := search(t, indexer, &index_service.SearchRequest{Query: types.NewTermQuery("t", "z")}); len(ids) != 0 {
+		t.Errorf("rejected document is searchable: %v", ids)
+	}
+	if ids, _, _ := search(t, indexer, &index_service.SearchRequest{Query: types.NewTermQuery("t", "go")}); !slices.Equal(ids, []string{"d1", "d4"}) {
+		t.Errorf("old document lost: %v", ids)
+	}
+	if ids, _, _ := search(t, indexer, &index_service.SearchRequest{Vector: types.NewVectorQuery([]float32{1, 0}, 1, types.FusionMode_FUSION_RRF)}); !slices.Equal(ids, []string{"d1"}) {
+		t.Errorf("old vector lost: %v", ids)
+	}
+}
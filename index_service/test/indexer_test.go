@@ -0,0 +1,43 @@
+package test
+
+import (
+	"RADIC/index_service"
+	"RADIC/internal/kvdb"
+	"RADIC/internal/reverse_index"
+	"RADIC/types"
+	"slices"
+	"testing"
+)
+
+func TestLoadFromIndexFile(t *testing.T) {
+	path := t.TempDir() + "/db"
+	indexer := new(index_service.Indexer)
+	if err := indexer.Init(10, kvdb.BOLT, reverse_index.SKIPLIST, path); err != nil {
+		t.Fatal(err)
+	}
+	addDoc(t, indexer, types.Document{Id: "a", Vector: []float32{1, 0}, Keywords: keywords("go")})
+	addDoc(t, indexer, types.Document{Id: "b", Vector: []float32{0, 1}, Keywords: keywords("go", "java")})
+	if err := indexer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重启后新增的文档不能复用加载的文档的IntId
+	reloaded := new(index_service.Indexer)
+	if err := reloaded.Init(10, kvdb.BOLT, reverse_index.SKIPLIST, path); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { reloaded.Close() })
+	if n := reloaded.LoadFromIndexFile(); n != 2 {
+		t.Fatalf("loaded %d docs, want 2", n)
+	}
+	addDoc(t, reloaded, types.Document{Id: "c", Vector: []float32{0.6, 0.8}, Keywords: keywords("go")})
+	if ids, _, _ := search(t, reloaded, &index_service.SearchRequest{Query: types.NewTermQuery("t", "go")}); !slices.Equal(ids, []string{"a", "b", "c"}) {
+		t.Errorf("keyword search after reload got %v", ids)
+	}
+	if ids, _, _ := search(t, reloaded, &index_service.SearchRequest{Query: types.NewTermQuery("t", "java")}); !slices.Equal(ids, []string{"b"}) {
+		t.Errorf("keyword search after reload got %v", ids)
+	}
+	if ids, _, _ := search(t, reloaded, &index_service.SearchRequest{Vector: types.NewVectorQuery([]float32{0.6, 0.8}, 1, types.FusionMode_FUSION_RRF)}); !slices.Equal(ids, []string{"c"}) {
+		t.Errorf("vector of the new doc got %v", ids)
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"RADIC/index_service"
+	"RADIC/internal/rerank"
+	"RADIC/types"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestRerankSearch(t *testing.T) {
+	indexer := newIndexer(t)
+	// r1到r6依次变长，go的BM25得分依次降低；r1被降权到0
+	for i := 1; i <= 6; i++ {
+		words := []string{"go"}
+		for j := 1; j < i; j++ {
+			words = append(words, fmt.Sprint("f", j))
+		}
+		doc := types.Document{Id: fmt.Sprint("r", i), Keywords: keywords(words...)}
+		if i == 1 {
+			doc.BitsFeature = 1
+		}
+		addDoc(t, indexer, doc)
+	}
+	if err := indexer.RegisterReranker("demote", rerank.FlagBoost(1, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	golang := types.NewTermQuery("t", "go")
+	tests := []struct {
+		name   string
+		window int32
+		offset int32
+		limit  int32
+		want   []string
+	}{
+		{"only TopK", 3, 0, 0, []string{"r2", "r3", "r1", "r4", "r5", "r6"}},
+		{"page inside window", 3, 1, 2, []string{"r3", "r1"}},
+		// 候选取到这一页为止，窗口之外的保持原来的顺序
+		{"page outside window", 2, 2, 3, []string{"r3", "r4", "r5"}},
+		{"window larger than hits", 10, 4, 5, []string{"r6", "r1"}},
+		{"offset beyond hits", 3, 9, 2, []string{}},
+	}
+	for _, test := range tests {
+		ids, scores, total := search(t, indexer, &index_service.SearchRequest{
+			Query:  golang,
+			TopK:   10,
+			Offset: test.offset,
+			Limit:  test.limit,
+			Rerank: &index_service.RerankRequest{Names: []string{"demote"}, Window: test.window},
+		})
+		if !slices.Equal(ids, test.want) || total != 6 {
+			t.Errorf("%s: got %v total %d, want %v total 6", test.name, ids, total, test.want)
+		}
+		if len(scores) != len(ids) {
+			t.Errorf("%s: %d scores for %d docs", test.name, len(scores), len(ids))
+		}
+		if i := slices.Index(ids, "r1"); i >= 0 && scores[i] != 0 {
+			t.Errorf("%s: r1 is not demoted: %v", test.name, scores)
+		}
+	}
+
+	// 只按得分排序、没有分页大小时只取窗口内的候选
+	ids, _, total := search(t, indexer, &index_service.SearchRequest{
+		Query:  golang,
+		SortBy: []*types.SortBy{types.NewSortBy(types.SORT_BY_SCORE, true)},
+		Rerank: &index_service.RerankRequest{Names: []string{"demote"}, Window: 3},
+	})
+	if !slices.Equal(ids, []string{"r2", "r3", "r1"}) || total != 6 {
+		t.Errorf("sort by score without limit: got %v total %d, want [r2 r3 r1] total 6", ids, total)
+	}
+
+	// 不打分的检索没有得分可以调整
+	if _, err := indexer.Search(&index_service.SearchRequest{Query: golang, Limit: 2, Rerank: &index_service.RerankRequest{Names: []string{"demote"}}}); err == nil {
+		t.Error("rerank without scoring should fail")
+	}
+	if _, err := indexer.Search(&index_service.SearchRequest{Query: golang, TopK: 10, Rerank: &index_service.RerankRequest{Names: []string{"unknown"}}}); err == nil {
+		t.Error("unknown reranker should fail")
+	}
+}
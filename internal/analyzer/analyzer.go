@@ -0,0 +1,341 @@
+package analyzer
+
+import (
+	"RADIC/types"
+	"bufio"
+	_ "embed"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// 文本分析：把原始文本切成建索引用的词。建索引和检索必须用同一个Analyzer，切出的词才能对得上
+
+//go:embed stopwords.txt
+var defaultStopwordsData string
+
+var (
+	defaultStopwords     map[string]struct{}
+	defaultStopwordsOnce sync.Once
+)
+
+// DefaultStopwords 内置的中英文停用词
+func DefaultStopwords() []string {
+	defaultStopwordsOnce.Do(func() {
+		defaultStopwords = make(map[string]struct{}, 128)
+		scanner := bufio.NewScanner(strings.NewReader(defaultStopwordsData))
+		for scanner.Scan() {
+			if word := strings.TrimSpace(scanner.Text()); word != "" && !strings.HasPrefix(word, "#") {
+				defaultStopwords[word] = struct{}{}
+			}
+		}
+	})
+	return slices.Sorted(maps.Keys(defaultStopwords))
+}
+
+// Token 分析出的一个词。Position是去掉停用词之后的序号(从0开始连续编号)，建索引和检索的编号方式一致，短语查询才能匹配
+type Token struct {
+	Word     string
+	Position int32
+}
+
+// Analyzer 依次做：全角转半角 -> 切词(连续的汉字用词典分词，连续的字母、数字作为一个词，其他字符都是分隔符) -> 转小写 -> 去停用词
+type Analyzer struct {
+	dict      *Dict
+	stopwords map[string]struct{}
+	lowercase bool
+	foldWidth bool
+	ngrams    map[string]int // field -> n，这些field的原文还要切成n-gram，用于子串查询
+
+	textFields   map[string]struct{} // 分词建索引的field，AnalyzeQuery只分析这些field上的词
+	textFieldsMu sync.RWMutex
+}
+
+// NewAnalyzer dict为nil时使用内置词典。默认转小写、全角转半角、去掉内置的停用词
+func NewAnalyzer(dict *Dict) *Analyzer {
+	if dict == nil {
+		dict = DefaultDict()
+	}
+	a := &Analyzer{dict: dict, lowercase: true, foldWidth: true}
+	return a.WithStopwords(DefaultStopwords()...)
+}
+
+// WithStopwords 替换停用词表，不传参数表示不去停用词。开启了转小写时停用词也要是小写的
+func (a *Analyzer) WithStopwords(words ...string) *Analyzer {
+	a.stopwords = make(map[string]struct{}, len(words))
+	for _, word := range words {
+		a.stopwords[word] = struct{}{}
+	}
+	return a
+}
+
+// WithLowercase 是否转小写
+func (a *Analyzer) WithLowercase(lowercase bool) *Analyzer {
+	a.lowercase = lowercase
+	return a
+}
+
+// WithWidthFolding 是否把全角字母、数字、标点和全角空格转成半角
+func (a *Analyzer) WithWidthFolding(foldWidth bool) *Analyzer {
+	a.foldWidth = foldWidth
+	return a
+}
+
+// WithNgram field除了分词，还把原文切成长度为n的gram建索引(n为2即bigram，3即trigram)，这样才能用types.SubstringQuery查任意子串。
+// gram是对原文切的，不做转小写、全角转半角。n<=0时取消
+func (a *Analyzer) WithNgram(field string, n int) *Analyzer {
+	if n <= 0 {
+		delete(a.ngrams, field)
+		return a
+	}
+	if a.ngrams == nil {
+		a.ngrams = make(map[string]int, 4)
+	}
+	a.ngrams[field] = n
+	return a
+}
+
+// WithTextFields 声明哪些field是分词建索引的。AnalyzeDocument会自动记下处理过的field，
+// 没有经过AnalyzeDocument的(比如重启后直接从正排加载的)需要在这里声明
+func (a *Analyzer) WithTextFields(fields ...string) *Analyzer {
+	a.textFieldsMu.Lock()
+	defer a.textFieldsMu.Unlock()
+	if a.textFields == nil {
+		a.textFields = make(map[string]struct{}, len(fields))
+	}
+	for _, field := range fields {
+		a.textFields[field] = struct{}{}
+	}
+	return a
+}
+
+// IsTextField field是否分词建索引。其他field上的keyword是调用方自己给的，检索时要原样使用
+func (a *Analyzer) IsTextField(field string) bool {
+	a.textFieldsMu.RLock()
+	defer a.textFieldsMu.RUnlock()
+	_, exists := a.textFields[field]
+	return exists
+}
+
+// foldWidth 全角ASCII字符(U+FF01~U+FF5E)转成对应的半角字符，全角空格转成半角空格
+func foldWidth(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 0xFF01 && r <= 0xFF5E:
+			return r - 0xFEE0
+		case r == 0x3000:
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// Normalize 只做全角转半角和转小写，不切词。用于前缀、通配符、模糊查询、补全这类不能切开的词
+func (a *Analyzer) Normalize(text string) string {
+	if a.foldWidth {
+		text = foldWidth(text)
+	}
+	if a.lowercase {
+		text = strings.ToLower(text)
+	}
+	return text
+}
+
+// Analyze 把文本切成词
+func (a *Analyzer) Analyze(text string) []Token {
+	if a.foldWidth {
+		text = foldWidth(text)
+	}
+	runes := []rune(text)
+	tokens := make([]Token, 0, len(runes)/2+1)
+	emit := func(word string) {
+		if a.lowercase {
+			word = strings.ToLower(word)
+		}
+		if _, stop := a.stopwords[word]; !stop {
+			tokens = append(tokens, Token{Word: word, Position: int32(len(tokens))})
+		}
+	}
+	isWord := func(r rune) bool {
+		return (unicode.IsLetter(r) || unicode.IsDigit(r)) && !unicode.Is(unicode.Han, r)
+	}
+	for i := 0; i < len(runes); {
+		j := i + 1
+		switch {
+		case unicode.Is(unicode.Han, runes[i]):
+			for j < len(runes) && unicode.Is(unicode.Han, runes[j]) {
+				j++
+			}
+			for _, word := range a.dict.Segment(string(runes[i:j])) {
+				emit(word)
+			}
+		case isWord(runes[i]):
+			for j < len(runes) && isWord(runes[j]) {
+				j++
+			}
+			emit(string(runes[i:j]))
+		}
+		i = j
+	}
+	return tokens
+}
+
+// Keywords 把field的文本切成Keyword，每出现一次是一个Keyword，带上位置。倒排索引会把同一个词合并，
+// 文档长度是Keyword的个数，所以不能在这里合并
+func (a *Analyzer) Keywords(field string, text string) []*types.Keyword {
+	tokens := a.Analyze(text)
+	keywords := make([]*types.Keyword, 0, len(tokens))
+	for _, token := range tokens {
+		keywords = append(keywords, &types.Keyword{Field: field, Word: token.Word, Positions: []int32{token.Position}})
+	}
+	return keywords
+}
+
+// AnalyzeDocument 把doc.TextFields里的文本切词后追加到doc.Keywords，按field名的顺序处理，结果是确定的。
+// 配置了WithNgram的field再追加它的n-gram
+func (a *Analyzer) AnalyzeDocument(doc *types.Document) {
+	if len(doc.TextFields) == 0 {
+		return
+	}
+	keywords := slices.Clip(doc.Keywords) // 不能写到调用方的切片底层数组里
+	fields := slices.Sorted(maps.Keys(doc.TextFields))
+	if slices.ContainsFunc(fields, func(field string) bool { return !a.IsTextField(field) }) {
+		a.WithTextFields(fields...) // 大部分文档的field都已经记下了，出现新field时才加写锁
+	}
+	for _, field := range fields {
+		keywords = append(keywords, a.Keywords(field, doc.TextFields[field])...)
+	}
+	for _, field := range fields {
+		if n := a.ngrams[field]; n > 0 {
+			keywords = append(keywords, types.NgramKeywords(field, doc.TextFields[field], n)...)
+		}
+	}
+	doc.Keywords = keywords
+}
+
+// Query 把一段文本分析成查询：所有词都要命中(AND)。全是停用词时返回空查询
+func (a *Analyzer) Query(field string, text string) *types.TermQuery {
+	musts := make([]*types.TermQuery, 0, 4)
+	for _, token := range a.Analyze(text) {
+		musts = append(musts, types.NewTermQuery(field, token.Word))
+	}
+	if len(musts) == 1 {
+		return musts[0]
+	}
+	return &types.TermQuery{Must: musts}
+}
+
+// AnalyzeQuery 对查询树(一般是types.ParseQuery的结果)里的词做与建索引时相同的分析，不修改q：
+// 切出多个词的keyword变成精确短语，短语里的每个词切开后依次拼接，前缀、通配符、模糊查询只做全角转半角和转小写，子串查询原样保留。
+// 只分析IsTextField的field上的词，其他field上的词原样保留。
+// 全是停用词的子句会被去掉，整个查询都被去掉时返回空查询。去掉了Should子句时，不带%的MinimumShouldMatch相应减小
+func (a *Analyzer) AnalyzeQuery(q *types.TermQuery) *types.TermQuery {
+	if analyzed := a.analyzeQuery(q); analyzed != nil {
+		return analyzed
+	}
+	return &types.TermQuery{}
+}
+
+// keywords 把field上的一个词切开，不分词的field原样返回
+func (a *Analyzer) keywords(field string, word string) []*types.Keyword {
+	if !a.IsTextField(field) {
+		return []*types.Keyword{{Field: field, Word: word}}
+	}
+	return a.Keywords(field, word)
+}
+
+// normalize 不分词的field原样返回
+func (a *Analyzer) normalize(field string, text string) string {
+	if !a.IsTextField(field) {
+		return text
+	}
+	return a.Normalize(text)
+}
+
+func (a *Analyzer) analyzeQuery(q *types.TermQuery) *types.TermQuery {
+	if q == nil {
+		return nil
+	}
+	var result *types.TermQuery
+	if key := q.Key(); key != "" {
+		field, word, found := strings.Cut(key, "\001")
+		if !found {
+			word = key
+		}
+		result = newPhrase(0, a.keywords(field, word))
+	} else if q.Phrase != nil {
+		keywords := make([]*types.Keyword, 0, 4)
+		for _, key := range q.Phrase.Keys() {
+			field, word, _ := strings.Cut(key, "\001")
+			keywords = append(keywords, a.keywords(field, word)...)
+		}
+		result = newPhrase(q.Phrase.Slop, keywords)
+	} else if q.Prefix != nil {
+		result = types.NewPrefixQuery(q.Prefix.Field, a.normalize(q.Prefix.Field, q.Prefix.Prefix), q.Prefix.MaxExpansions)
+	} else if q.Wildcard != nil {
+		result = types.NewWildcardQuery(q.Wildcard.Field, a.normalize(q.Wildcard.Field, q.Wildcard.Pattern), q.Wildcard.MaxExpansions)
+	} else if fuzzy := q.Fuzzy; fuzzy != nil {
+		result = types.NewFuzzyQuery(fuzzy.Field, a.normalize(fuzzy.Field, fuzzy.Word), fuzzy.MaxEdits, fuzzy.PrefixLength)
+		result.Fuzzy.MaxExpansions = fuzzy.MaxExpansions
+	} else if q.Substring != nil {
+		result = types.NewSubstringQuery(q.Substring.Field, q.Substring.Text)
+	} else {
+		// 叶子节点上的Must、Should不参与求值，只有非叶子节点才需要处理
+		should := a.analyzeChildren(q.Should)
+		result = &types.TermQuery{
+			Must:               a.analyzeChildren(q.Must),
+			Should:             should,
+			MinimumShouldMatch: minimumShouldMatch(q.MinimumShouldMatch, len(q.Should)-len(should)),
+		}
+		if len(result.Must) == 0 && len(result.Should) == 0 {
+			return nil
+		}
+	}
+	if result == nil {
+		return nil
+	}
+	result.MustNot = a.analyzeChildren(q.MustNot)
+	result.Boost = q.Boost
+	return result
+}
+
+// minimumShouldMatch 去掉了dropped个全是停用词的Should子句之后的MinimumShouldMatch。停用词不建索引，
+// 当作已经命中，不带%的msm减去dropped，比如(the OR go OR java)~2变成(go OR java)~1；减到1以下时不再需要
+func minimumShouldMatch(spec string, dropped int) string {
+	n, err := strconv.Atoi(spec)
+	if dropped == 0 || err != nil { // 没设置、百分比或格式不对的交给Validate
+		return spec
+	}
+	if n -= dropped; n <= 1 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// analyzeChildren 去掉全是停用词的子句
+func (a *Analyzer) analyzeChildren(children []*types.TermQuery) []*types.TermQuery {
+	result := make([]*types.TermQuery, 0, len(children))
+	for _, child := range children {
+		if analyzed := a.analyzeQuery(child); analyzed != nil {
+			result = append(result, analyzed)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// newPhrase 一个词时是keyword查询，多个词时是短语查询，没有词时返回nil
+func newPhrase(slop int32, keywords []*types.Keyword) *types.TermQuery {
+	switch len(keywords) {
+	case 0:
+		return nil
+	case 1:
+		return types.NewTermQuery(keywords[0].Field, keywords[0].Word)
+	}
+	return types.NewPhraseQuery(slop, keywords...)
+}
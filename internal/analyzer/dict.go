@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:embed dict.txt
+var defaultDictData string
+
+var (
+	defaultDict     *Dict
+	defaultDictOnce sync.Once
+)
+
+// Dict 中文分词词典：词 -> 词频。分词时在所有切分方式中选词频乘积最大(对数概率之和最大)的一种
+type Dict struct {
+	freqs    map[string]int64
+	total    int64
+	maxRunes int     // 最长的词有几个字，切分时不用尝试更长的子串
+	logTotal float64 // log(total)，每次切分都要用，提前算好
+}
+
+// DefaultDict 内置的词典，只收录了常用词，需要更好的分词效果时用LoadDict加载完整的词典
+func DefaultDict() *Dict {
+	defaultDictOnce.Do(func() {
+		dict, err := ParseDict(strings.NewReader(defaultDictData))
+		if err != nil {
+			panic(err) // 内置词典是编译进来的，解析失败说明词典文件写错了
+		}
+		defaultDict = dict
+	})
+	return defaultDict
+}
+
+// LoadDict 从文件加载词典，格式与jieba的dict.txt兼容
+func LoadDict(path string) (*Dict, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	dict, err := ParseDict(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return dict, nil
+}
+
+// ParseDict 每行：词 词频 [词性]，#开头的行是注释。词频省略时为1
+func ParseDict(reader io.Reader) (*Dict, error) {
+	dict := &Dict{freqs: make(map[string]int64, 1024)}
+	scanner := bufio.NewScanner(reader)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		freq := int64(1)
+		if len(fields) > 1 {
+			n, err := strconv.ParseInt(fields[1], 10, 64)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("line %d: invalid frequency %q", lineNo, fields[1])
+			}
+			freq = n
+		}
+		dict.AddWord(fields[0], freq)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+// AddWord 增加一个词，已存在时覆盖词频。需在开始分词之前调用
+func (dict *Dict) AddWord(word string, freq int64) {
+	dict.total += freq - dict.freqs[word]
+	dict.freqs[word] = freq
+	dict.maxRunes = max(dict.maxRunes, utf8.RuneCountInString(word))
+	dict.logTotal = math.Log(float64(max(dict.total, 1)))
+}
+
+// Segment 把一段连续的汉字切分成词。动态规划：route[i]是从第i个字到末尾的最大对数概率，
+// 词典里没有的单字按词频1计算，所以任何文本都能切分
+func (dict *Dict) Segment(text string) []string {
+	runes := []rune(text)
+	n := len(runes)
+	if n == 0 {
+		return nil
+	}
+	route := make([]float64, n+1)
+	next := make([]int, n+1) // next[i]：从第i个字开始的词在哪里结束
+	for i := n - 1; i >= 0; i-- {
+		route[i] = math.Inf(-1)
+		for j := i + 1; j <= n && j-i <= max(dict.maxRunes, 1); j++ {
+			freq, exists := dict.freqs[string(runes[i:j])]
+			if !exists {
+				if j > i+1 {
+					continue
+				}
+				freq = 1
+			}
+			if score := math.Log(float64(freq)) - dict.logTotal + route[j]; score > route[i] {
+				route[i], next[i] = score, j
+			}
+		}
+	}
+	words := make([]string, 0, n/2+1)
+	for i := 0; i < n; i = next[i] {
+		words = append(words, string(runes[i:next[i]]))
+	}
+	return words
+}
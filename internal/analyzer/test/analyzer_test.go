@@ -0,0 +1,181 @@
+package test
+
+import (
+	"RADIC/internal/analyzer"
+	"RADIC/internal/reverse_index"
+	"RADIC/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func words(tokens []analyzer.Token) []string {
+	result := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		result = append(result, token.Word)
+	}
+	return result
+}
+
+func TestAnalyze(t *testing.T) {
+	a := analyzer.NewAnalyzer(nil)
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"分布式搜索引擎", []string{"分布式", "搜索", "引擎"}},
+		{"Go语言入门", []string{"go", "语言", "入门"}},
+		{"ＧＯ　Ｌａｎｇ１２３", []string{"go", "lang123"}},
+		{"the Art of 数据库", []string{"art", "数据库"}},
+		{"我的数据库", []string{"我", "数据库"}},
+		{"，。！", []string{}},
+	}
+	for _, test := range tests {
+		if got := words(a.Analyze(test.text)); !slices.Equal(got, test.want) {
+			t.Errorf("Analyze(%q) = %v, want %v", test.text, got, test.want)
+		}
+	}
+
+	// 去掉停用词后位置仍然连续
+	for i, token := range a.Analyze("the 分布式 的 搜索 of 引擎") {
+		if token.Position != int32(i) {
+			t.Errorf("token %s at position %d, want %d", token.Word, token.Position, i)
+		}
+	}
+
+	raw := analyzer.NewAnalyzer(nil).WithStopwords().WithLowercase(false).WithWidthFolding(false)
+	if got := words(raw.Analyze("The Ｇｏ")); !slices.Equal(got, []string{"The", "Ｇｏ"}) {
+		t.Errorf("Analyze without normalization = %v", got)
+	}
+}
+
+func TestDict(t *testing.T) {
+	dict, err := analyzer.ParseDict(strings.NewReader("# 自定义词典\n向量检索 100 n\n倒排\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dict.Segment("向量检索和倒排"); !slices.Equal(got, []string{"向量检索", "和", "倒排"}) {
+		t.Errorf("Segment = %v", got)
+	}
+	if _, err := analyzer.ParseDict(strings.NewReader("倒排 x\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("invalid frequency: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "dict.txt")
+	if err := os.WriteFile(path, []byte("倒排 -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := analyzer.LoadDict(path); err == nil || !strings.Contains(err.Error(), path) {
+		t.Errorf("LoadDict error should contain path: %v", err)
+	}
+}
+
+func TestAnalyzeDocument(t *testing.T) {
+	a := analyzer.NewAnalyzer(nil)
+	keywords := make([]*types.Keyword, 1, 8)
+	keywords[0] = &types.Keyword{Field: "tag", Word: "db"}
+	doc := types.Document{
+		Id:         "1",
+		Keywords:   keywords,
+		TextFields: map[string]string{"title": "分布式数据库", "body": "Go的引擎"},
+	}
+	a.AnalyzeDocument(&doc)
+	want := []string{"tag\001db", "body\001go", "body\001引擎", "title\001分布式", "title\001数据库"}
+	keys := make([]string, 0, len(doc.Keywords))
+	for _, kw := range doc.Keywords {
+		keys = append(keys, kw.ToString())
+	}
+	if !slices.Equal(keys, want) {
+		t.Errorf("keywords = %q, want %q", keys, want)
+	}
+	if got := doc.Keywords[4].Positions; !slices.Equal(got, []int32{1}) {
+		t.Errorf("position of 数据库 = %v", got)
+	}
+	if keywords[:cap(keywords)][1] != nil {
+		t.Error("AnalyzeDocument wrote into the caller's backing array")
+	}
+}
+
+func TestAnalyzeQuery(t *testing.T) {
+	a := analyzer.NewAnalyzer(nil).WithTextFields("title")
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"title:分布式搜索 -title:the", "title:[分布式 搜索]"},
+		{`title:"Go 引擎"~1 OR title:ＤＢ*`, `title:"go 引擎"~1 OR title:db*`},
+		{"title:数据库^2 AND title:的", "title:数据库^2"},
+		{"title:的", ""},
+		// 不分词的field原样保留
+		{`tag:Java OR tag:"C++ Primer" OR tag:ＧＯ* OR tag:the`, `tag:Java OR tag:"C++ Primer" OR tag:ＧＯ* OR tag:the`},
+		// 去掉的停用词当作已经命中，msm相应减小
+		{"(title:the OR title:go OR title:java)~2", "title:go OR title:java"},
+		{"(title:the OR title:a OR title:go OR title:java OR title:rust)~4", "(title:go OR title:java OR title:rust)~2"},
+		{"(title:the OR title:go OR title:java)~60%", "(title:go OR title:java)~60%"},
+		{"title:rust AND (title:the OR title:of)~2", "title:rust"},
+	}
+	for _, test := range tests {
+		q, err := types.ParseQuery(test.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		before := q.String()
+		if got := a.AnalyzeQuery(q).ToQueryString(); got != test.want {
+			t.Errorf("AnalyzeQuery(%s) = %s, want %s", test.query, got, test.want)
+		}
+		if q.String() != before {
+			t.Errorf("AnalyzeQuery modified the query: %s", q.String())
+		}
+	}
+}
+
+func TestSearchAnalyzed(t *testing.T) {
+	a := analyzer.NewAnalyzer(nil)
+	indexer := reverse_index.NewSkipListReverseIndex(10)
+	for i, text := range []string{"分布式搜索引擎", "搜索分布式的引擎", "ＧＯ语言"} {
+		doc := types.Document{Id: string(rune('a' + i)), IntId: uint64(i + 1), TextFields: map[string]string{"title": text}}
+		a.AnalyzeDocument(&doc)
+		indexer.Add(doc)
+	}
+	search := func(text string) []string {
+		q, err := types.ParseQuery(text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hits := indexer.Search(a.AnalyzeQuery(q), 0, 0, nil, nil, nil, false, reverse_index.Page{})
+		ids := make([]string, 0, len(hits.Docs))
+		for _, doc := range hits.Docs {
+			ids = append(ids, doc.Id)
+		}
+		return ids
+	}
+	if got := search("title:分布式搜索"); !slices.Equal(got, []string{"a"}) {
+		t.Errorf("phrase search got %v", got)
+	}
+	if got := search("title:引擎 AND title:搜索"); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("and search got %v", got)
+	}
+	if got := search("title:go语言"); !slices.Equal(got, []string{"c"}) {
+		t.Errorf("folded search got %v", got)
+	}
+}
+
+func TestAnalyzeNgram(t *testing.T) {
+	a := analyzer.NewAnalyzer(nil).WithNgram("title", 2)
+	doc := types.Document{Id: "1", TextFields: map[string]string{"title": "搜索引擎", "body": "搜索"}}
+	a.AnalyzeDocument(&doc)
+	grams := make([]string, 0, 4)
+	for _, kw := range doc.Keywords {
+		if kw.Field == types.NgramField("title", 2) {
+			grams = append(grams, kw.Word)
+		}
+	}
+	if !slices.Equal(grams, []string{"搜索", "索引", "引擎", "擎"}) {
+		t.Errorf("grams = %v", grams)
+	}
+	if field, n, ok := types.ParseNgramField(types.NgramField("title", 3)); !ok || field != "title" || n != 3 {
+		t.Errorf("ParseNgramField got %s %d %v", field, n, ok)
+	}
+}
@@ -54,9 +54,9 @@ func GetKvDb(dbtype int, path string) (IKeyVakyeDB, error) {
 	var db IKeyVakyeDB
 	switch dbtype {
 	case BADGER:
-		db = new(Badger)
+		db = new(Badger).WithDataPath(path)
 	default:
-		db = new(Bolt)
+		db = new(Bolt).WithDataPath(path).WithBucket("radic")
 
 	}
 	err = db.Open()
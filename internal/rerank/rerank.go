@@ -0,0 +1,111 @@
+package rerank
+
+import (
+	"RADIC/types"
+	"cmp"
+	"fmt"
+	"log/slog"
+	"math"
+	"slices"
+	"sync"
+)
+
+// 检索之后、结果返回之前的重排：业务规则(新视频加权、低质作者降权等)注册成有名字的Reranker，每次检索按名字选出一条链依次执行。
+// 只重排得分最高的前Window个候选，重排的开销与命中多少文档无关
+
+const (
+	DEFAULT_RERANK_WINDOW = 100  // RerankRequest.Window为0时重排的候选数
+	MAX_RERANK_WINDOW     = 1000 // 最多重排的候选数，候选都要从正排索引读出来
+)
+
+// Reranker 重排器。docs按当前得分从高到低排列，scores与docs一一对应，返回与docs一一对应的新得分，不能修改docs和scores。
+// 链上每个Reranker执行完都会按新得分重新排序(得分相同的保持原来的顺序)
+type Reranker interface {
+	Rerank(docs []*types.Document, scores []float64) []float64
+}
+
+// RerankerFunc 普通函数也可以当Reranker用
+type RerankerFunc func(docs []*types.Document, scores []float64) []float64
+
+func (f RerankerFunc) Rerank(docs []*types.Document, scores []float64) []float64 {
+	return f(docs, scores)
+}
+
+// Registry 按名字注册的Reranker，并发安全
+type Registry struct {
+	mu        sync.RWMutex
+	rerankers map[string]Reranker
+}
+
+func NewRegistry() *Registry {
+	return &Registry{rerankers: make(map[string]Reranker, 8)}
+}
+
+// Register 注册一个Reranker，名字不能为空也不能重复
+func (r *Registry) Register(name string, reranker Reranker) error {
+	if name == "" || reranker == nil {
+		return fmt.Errorf("reranker needs a name and an implementation")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.rerankers[name]; exists {
+		return fmt.Errorf("reranker %q already registered", name)
+	}
+	r.rerankers[name] = reranker
+	return nil
+}
+
+// Chain 按names的顺序取出Reranker，有没注册过的名字时返回error
+func (r *Registry) Chain(names []string) (Chain, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	chain := make(Chain, 0, len(names))
+	for _, name := range names {
+		reranker, exists := r.rerankers[name]
+		if !exists {
+			return nil, fmt.Errorf("unknown reranker %q", name)
+		}
+		chain = append(chain, namedReranker{name, reranker})
+	}
+	return chain, nil
+}
+
+type namedReranker struct {
+	name string
+	Reranker
+}
+
+// Chain 依次执行的一组Reranker
+type Chain []namedReranker
+
+// Rerank 依次执行链上的Reranker，返回重新排序后的文档和得分，不修改docs和scores。
+// 某个Reranker返回的得分个数不对或者有NaN时跳过它，记录日志，不影响后面的Reranker
+func (chain Chain) Rerank(docs []*types.Document, scores []float64) ([]*types.Document, []float64) {
+	docs, scores = slices.Clone(docs), slices.Clone(scores)
+	for _, reranker := range chain {
+		newScores := reranker.Rerank(docs, scores)
+		if len(newScores) != len(docs) || slices.ContainsFunc(newScores, math.IsNaN) {
+			slog.Warn("reranker returns invalid scores, skipped", slog.String("name", reranker.name), slog.Int("docs", len(docs)), slog.Int("scores", len(newScores)))
+			continue
+		}
+		order := make([]int, len(docs))
+		for i := range order {
+			order[i] = i
+		}
+		slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(newScores[b], newScores[a]) })
+		sortedDocs, sortedScores := make([]*types.Document, len(docs)), make([]float64, len(docs))
+		for i, j := range order {
+			sortedDocs[i], sortedScores[i] = docs[j], newScores[j]
+		}
+		docs, scores = sortedDocs, sortedScores
+	}
+	return docs, scores
+}
+
+// Window 重排的候选数，没指定时用默认值，且不能超过上限
+func Window(window int32) int {
+	if window <= 0 {
+		return DEFAULT_RERANK_WINDOW
+	}
+	return min(int(window), MAX_RERANK_WINDOW)
+}
@@ -0,0 +1,40 @@
+package rerank
+
+import (
+	"RADIC/types"
+	"math"
+	"time"
+)
+
+// 几个通用的业务规则，具体的权重由注册时的参数决定
+
+// FlagBoost BitsFeature包含flag的全部bit的文档，得分乘以factor。factor小于1时是降权，比如低质作者
+func FlagBoost(flag uint64, factor float64) Reranker {
+	return RerankerFunc(func(docs []*types.Document, scores []float64) []float64 {
+		result := make([]float64, len(scores))
+		for i, doc := range docs {
+			result[i] = scores[i]
+			if doc.BitsFeature&flag == flag {
+				result[i] *= factor
+			}
+		}
+		return result
+	})
+}
+
+// FreshnessBoost 新文档加权：field是IntFeatures里的发布时间(unix秒)，得分乘以1+weight*0.5^(文档年龄/halfLife)，
+// 刚发布的文档乘以1+weight，每过一个halfLife加权减半。没有发布时间的文档不加权
+func FreshnessBoost(field string, halfLife time.Duration, weight float64) Reranker {
+	return RerankerFunc(func(docs []*types.Document, scores []float64) []float64 {
+		now := time.Now().Unix()
+		result := make([]float64, len(scores))
+		for i, doc := range docs {
+			result[i] = scores[i]
+			if postTime, ok := doc.IntFeatures[field]; ok {
+				age := max(0, float64(now-postTime))
+				result[i] *= 1 + weight*math.Pow(0.5, age/halfLife.Seconds())
+			}
+		}
+		return result
+	})
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"RADIC/internal/rerank"
+	"RADIC/types"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestChain(t *testing.T) {
+	registry := rerank.NewRegistry()
+	const LOW_QUALITY = 1 << 3
+	if err := registry.Register("demote", rerank.FlagBoost(LOW_QUALITY, 0.1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register("fresh", rerank.FreshnessBoost("post_time", time.Hour, 1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := registry.Register("fresh", rerank.FreshnessBoost("post_time", time.Hour, 1)); err == nil {
+		t.Error("duplicate name should fail")
+	}
+	if err := registry.Register("broken", rerank.RerankerFunc(func(docs []*types.Document, scores []float64) []float64 {
+		return scores[:len(scores)-1]
+	})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := registry.Chain([]string{"fresh", "unknown"}); err == nil {
+		t.Error("unknown reranker should fail")
+	}
+
+	now := time.Now().Unix()
+	docs := []*types.Document{
+		{Id: "a", BitsFeature: LOW_QUALITY, IntFeatures: map[string]int64{"post_time": now}},
+		{Id: "b", IntFeatures: map[string]int64{"post_time": now - 10*3600}},
+		{Id: "c", IntFeatures: map[string]int64{"post_time": now}},
+		{Id: "d"},
+	}
+	scores := []float64{10, 8, 5, 5}
+	ids := func(docs []*types.Document) []string {
+		result := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			result = append(result, doc.Id)
+		}
+		return result
+	}
+
+	chain, err := registry.Chain([]string{"demote", "broken", "fresh"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reranked, newScores := chain.Rerank(docs, scores)
+	// a降权到1，再加权到2；b几乎不加权；c加权到10；d不变。broken被跳过
+	if got := ids(reranked); !slices.Equal(got, []string{"c", "b", "d", "a"}) {
+		t.Errorf("rerank got %v %v", got, newScores)
+	}
+	if newScores[0] < 9.9 || newScores[3] < 1.9 || newScores[3] > 2 {
+		t.Errorf("scores got %v", newScores)
+	}
+	if ids(docs)[0] != "a" || scores[0] != 10 {
+		t.Error("Rerank should not modify the input")
+	}
+
+	// 得分相同时保持原来的顺序
+	same := rerank.RerankerFunc(func(docs []*types.Document, scores []float64) []float64 { return make([]float64, len(docs)) })
+	registry.Register("same", same)
+	chain, _ = registry.Chain([]string{"same"})
+	if reranked, _ := chain.Rerank(docs, scores); !slices.Equal(ids(reranked), ids(docs)) {
+		t.Errorf("stable rerank got %v", ids(reranked))
+	}
+
+	if rerank.Window(0) != rerank.DEFAULT_RERANK_WINDOW || rerank.Window(1e6) != rerank.MAX_RERANK_WINDOW || rerank.Window(7) != 7 {
+		t.Error("Window")
+	}
+}
@@ -0,0 +1,479 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"RADIC/util"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BitmapReverseIndex 基于压缩位图的倒排索引：每个key的倒排链是一个IntId的位图
+// 跳表的每个节点都要装箱、key是interface{}，位图则是紧凑的[]uint16/[]uint64，内存小得多，交并差也是按块批量计算
+// 文档的Id、BitsFeature、长度不放在倒排链里，而是按IntId存放在旁路数组中(IntId是从1开始递增分配的，数组足够紧凑)
+type BitmapReverseIndex struct {
+	mu       sync.RWMutex
+	postings map[string]*bitmapPosting
+	ids      []string // IntId -> 业务侧的Id
+	bits     []uint64 // IntId -> BitsFeature
+	lengths  []int32  // IntId -> 文档长度，打分时用
+	stats    *CollectionStats
+	values   *DocValues // 数值属性，范围过滤时使用
+	dict     *TermDict
+	cache    *ResultCache[[]uint64] // 查询 -> 过滤后的命中文档
+}
+
+// bitmapPosting 一个key的倒排链。词频、位置、权重按IntId在位图中的排名(Rank)存放在数组里
+type bitmapPosting struct {
+	docs      *util.Bitmap
+	termFreqs []int32
+	positions [][]int32
+	weights   []float32 // 建索引时keyword的权重，默认为1
+}
+
+// NewBitmapReverseIndex 初始化倒排索引，DocNumEstimate是预估的doc数量
+func NewBitmapReverseIndex(DocNumEstimate int) *BitmapReverseIndex {
+	return &BitmapReverseIndex{
+		postings: make(map[string]*bitmapPosting, DocNumEstimate),
+		ids:      make([]string, 0, DocNumEstimate+1),
+		bits:     make([]uint64, 0, DocNumEstimate+1),
+		lengths:  make([]int32, 0, DocNumEstimate+1),
+		stats:    NewCollectionStats(),
+		values:   NewDocValues(),
+		dict:     NewTermDict(),
+		cache:    NewResultCache[[]uint64](DEFAULT_CACHE_ENTRIES, DEFAULT_CACHE_DOCS),
+	}
+}
+
+// Add 将文档增加到倒排索引中
+func (indexer *BitmapReverseIndex) Add(doc types.Document) {
+	termFreq := make(map[string]int32, len(doc.Keywords))
+	positions := make(map[string][]int32, len(doc.Keywords))
+	weights := make(map[string]float64, len(doc.Keywords))
+	for _, keyword := range doc.Keywords {
+		if key := keyword.ToString(); key != "" {
+			if len(keyword.Positions) > 0 {
+				termFreq[key] += int32(len(keyword.Positions))
+				positions[key] = append(positions[key], keyword.Positions...)
+			} else {
+				termFreq[key]++
+			}
+			weights[key] = max(weights[key], keyword.EffectiveWeight())
+		}
+	}
+	docLength := DocLength(doc.Keywords)
+	indexer.stats.AddDoc(doc.IntId, docLength)
+	indexer.values.Add(doc)
+
+	indexer.mu.Lock()
+	defer indexer.mu.Unlock()
+	for uint64(len(indexer.ids)) <= doc.IntId {
+		indexer.ids = append(indexer.ids, "")
+		indexer.bits = append(indexer.bits, 0)
+		indexer.lengths = append(indexer.lengths, 0)
+	}
+	indexer.ids[doc.IntId] = doc.Id
+	indexer.bits[doc.IntId] = doc.BitsFeature
+	indexer.lengths[doc.IntId] = docLength
+
+	for key, tf := range termFreq {
+		pos := positions[key]
+		slices.Sort(pos)
+		pos = slices.Compact(pos)
+
+		posting, exists := indexer.postings[key]
+		if !exists {
+			posting = &bitmapPosting{docs: util.NewBitmap()}
+			indexer.postings[key] = posting
+		}
+		if posting.docs.Add(doc.IntId) {
+			i := posting.docs.Rank(doc.IntId) - 1 // IntId递增分配，绝大多数时候i就是末尾
+			posting.termFreqs = slices.Insert(posting.termFreqs, i, tf)
+			posting.positions = slices.Insert(posting.positions, i, pos)
+			posting.weights = slices.Insert(posting.weights, i, float32(weights[key]))
+		} else {
+			i := posting.docs.Rank(doc.IntId) - 1
+			posting.termFreqs[i] = tf
+			posting.positions[i] = pos
+			posting.weights[i] = float32(weights[key])
+		}
+		indexer.dict.Add(key)
+	}
+	keys := make([]string, 0, len(termFreq))
+	for key := range termFreq {
+		keys = append(keys, key)
+	}
+	indexer.cache.Invalidate(keys...) // 持有写锁，不会有检索在读旧的倒排链
+}
+
+// Delete 根据IntId删除key上的对应的doc
+func (indexer *BitmapReverseIndex) Delete(IntId uint64, keyword *types.Keyword) {
+	key := keyword.ToString()
+	indexer.mu.Lock()
+	defer indexer.mu.Unlock()
+	posting, exists := indexer.postings[key]
+	if !exists || !posting.docs.Contains(IntId) {
+		return
+	}
+	defer indexer.cache.Invalidate(key)
+	i := posting.docs.Rank(IntId) - 1
+	posting.docs.Remove(IntId)
+	posting.termFreqs = slices.Delete(posting.termFreqs, i, i+1)
+	posting.positions = slices.Delete(posting.positions, i, i+1)
+	posting.weights = slices.Delete(posting.weights, i, i+1)
+	if posting.docs.IsEmpty() {
+		delete(indexer.postings, key)
+		indexer.dict.Remove(key)
+	}
+}
+
+// DeleteDoc 删除整篇文档。统计信息和数值属性在Add时总会写入，不能依赖逐个keyword的Delete来扣除
+func (indexer *BitmapReverseIndex) DeleteDoc(IntId uint64, keywords []*types.Keyword) {
+	for _, keyword := range keywords {
+		indexer.Delete(IntId, keyword)
+	}
+	indexer.stats.RemoveDoc(IntId)
+	indexer.values.Remove(IntId)
+}
+
+// Explain 解释IntId对应的文档为什么命中或没命中query，scored为true时给出每个节点的得分
+func (indexer *BitmapReverseIndex) Explain(intId uint64, query *types.TermQuery, scored bool) *types.QueryExplanation {
+	indexer.mu.RLock()
+	defer indexer.mu.RUnlock()
+	e := &explainer{
+		intId:        intId,
+		values:       indexer.values,
+		docFreq:      indexer.docFreq,
+		dict:         indexer.dict,
+		docCount:     indexer.stats.DocCount(),
+		avgDocLength: indexer.stats.AvgDocLength(),
+		lookup: func(key string) (termInfo, bool) {
+			return indexer.term(key, intId)
+		},
+	}
+	return e.explain(query, scored, 1)
+}
+
+// SetCacheSize 调整结果缓存的容量，任一参数<=0时不缓存。需在写入文档之前调用
+func (indexer *BitmapReverseIndex) SetCacheSize(maxEntries int, maxDocs int) {
+	indexer.cache = NewResultCache[[]uint64](maxEntries, maxDocs)
+}
+
+// CacheStats 结果缓存的统计
+func (indexer *BitmapReverseIndex) CacheStats() CacheStats {
+	return indexer.cache.Stats()
+}
+
+// TopTerms 按score从高到低返回field下以prefix开头的前k个词，score为nil时按文档频率
+func (indexer *BitmapReverseIndex) TopTerms(field string, prefix string, k int, score func(word string, docFreq int) float64) []TermStat {
+	indexer.mu.RLock()
+	defer indexer.mu.RUnlock()
+	return topTerms(indexer.dict, indexer.docFreq, field, prefix, k, score)
+}
+
+// SimilarTerms 拼写纠错的候选词，按文档频率从高到低取前k个
+func (indexer *BitmapReverseIndex) SimilarTerms(field string, word string, maxEdits int, k int) []TermStat {
+	indexer.mu.RLock()
+	defer indexer.mu.RUnlock()
+	return similarTerms(indexer.dict, indexer.docFreq, field, word, maxEdits, k)
+}
+
+// DocFreq 包含key的文档数
+func (indexer *BitmapReverseIndex) DocFreq(key string) int {
+	indexer.mu.RLock()
+	defer indexer.mu.RUnlock()
+	return indexer.docFreq(key)
+}
+
+// docFreq 调用方需持有读锁
+func (indexer *BitmapReverseIndex) docFreq(key string) int {
+	if posting, exists := indexer.postings[key]; exists {
+		return posting.docs.Cardinality()
+	}
+	return 0
+}
+
+// term 文档在key上的词频、位置和权重，不包含时返回false。调用方需持有读锁
+func (indexer *BitmapReverseIndex) term(key string, intId uint64) (termInfo, bool) {
+	posting, exists := indexer.postings[key]
+	if !exists || !posting.docs.Contains(intId) {
+		return termInfo{}, false
+	}
+	i := posting.docs.Rank(intId) - 1
+	return termInfo{
+		termFreq:  posting.termFreqs[i],
+		docLength: indexer.lengths[intId],
+		positions: posting.positions[i],
+		weight:    float64(posting.weights[i]),
+	}, true
+}
+
+// docs key对应的位图，不存在时返回空位图。调用方需持有读锁，且不能修改返回的位图
+func (indexer *BitmapReverseIndex) docs(key string) *util.Bitmap {
+	if posting, exists := indexer.postings[key]; exists {
+		return posting.docs
+	}
+	return util.NewBitmap()
+}
+
+// search 求查询树命中的文档集合，不做特征过滤和范围过滤。
+// 特征和数值属性只跟文档有关，先求集合、最后统一过滤与在每个叶子上过滤的结果一样，还省掉了中间结果的过滤。调用方需持有读锁。
+// prof不为nil时记录每个节点的耗时、倒排链长度和结果数
+func (indexer *BitmapReverseIndex) search(q *types.TermQuery, prof *queryProfiler) *util.Bitmap {
+	var result *util.Bitmap
+	if key := q.Key(); key != "" {
+		result = indexer.docs(key)
+		prof.posting(int(result.Cardinality()))
+	} else if q.Phrase != nil {
+		result = indexer.searchPhrase(q.Phrase, prof)
+	} else if q.Prefix != nil {
+		result = indexer.searchTerms(indexer.dict.PrefixTerms(q.Prefix.Field, q.Prefix.Prefix, int(q.Prefix.MaxExpansions)), prof)
+	} else if q.Wildcard != nil {
+		result = indexer.searchTerms(indexer.dict.WildcardTerms(q.Wildcard.Field, q.Wildcard.Pattern, int(q.Wildcard.MaxExpansions)), prof)
+	} else if q.Fuzzy != nil {
+		fuzzy := q.Fuzzy
+		result = indexer.searchTerms(indexer.dict.FuzzyTerms(fuzzy.Field, fuzzy.Word, int(fuzzy.MaxEdits), int(fuzzy.PrefixLength), int(fuzzy.MaxExpansions)), prof)
+	} else if q.Substring != nil {
+		result = indexer.searchSubstring(q.Substring, prof)
+	} else if len(q.Must) > 0 {
+		for _, q := range planMust(q.Must, indexer.docFreq) { // 预估结果小的先求，交集越早变小越好
+			sub := indexer.search(q, prof.must(q))
+			if result == nil {
+				result = sub
+			} else {
+				result = util.And(result, sub)
+			}
+			if result.IsEmpty() {
+				break // 交集已经为空，后面的子查询不用算了
+			}
+		}
+	} else if len(q.Should) > 0 {
+		minMatch, err := q.MinShouldMatch()
+		if err != nil {
+			slog.Warn("invalid query", slog.String("error", err.Error()))
+			prof.done(0)
+			return util.NewBitmap()
+		}
+		results := make([]*util.Bitmap, 0, len(q.Should))
+		for _, q := range q.Should {
+			results = append(results, indexer.search(q, prof.should(q)))
+		}
+		result = util.AtLeast(minMatch, results...)
+	}
+	if result == nil {
+		prof.done(0)
+		return util.NewBitmap()
+	}
+
+	if len(q.MustNot) > 0 && !result.IsEmpty() {
+		for _, q := range q.MustNot {
+			result = util.AndNot(result, indexer.search(q, prof.mustNot(q)))
+		}
+	}
+	if prof != nil {
+		prof.done(int(result.Cardinality()))
+	}
+	return result
+}
+
+// searchTerms 多个词的倒排链求并集
+func (indexer *BitmapReverseIndex) searchTerms(keys []string, prof *queryProfiler) *util.Bitmap {
+	result := util.NewBitmap()
+	for _, key := range keys {
+		docs := indexer.docs(key)
+		if prof != nil {
+			prof.posting(int(docs.Cardinality()))
+		}
+		result = util.Or(result, docs)
+	}
+	return result
+}
+
+// searchPhrase 短语查询：先求交集，再用位置校验
+func (indexer *BitmapReverseIndex) searchPhrase(phrase *types.PhraseQuery, prof *queryProfiler) *util.Bitmap {
+	result := util.NewBitmap()
+	keys := phrase.Keys()
+	if len(keys) == 0 {
+		return result
+	}
+	var candidates *util.Bitmap
+	for i, key := range keys {
+		docs := indexer.docs(key)
+		if prof != nil {
+			prof.posting(int(docs.Cardinality()))
+		}
+		if i == 0 {
+			candidates = docs
+		} else {
+			candidates = util.And(candidates, docs)
+		}
+	}
+	positions := make([][]int32, len(keys))
+	candidates.ForEach(func(intId uint64) bool {
+		for i, key := range keys {
+			info, _ := indexer.term(key, intId)
+			positions[i] = info.positions
+		}
+		if MatchPhrase(positions, phrase.Slop) {
+			result.Add(intId)
+		}
+		return true
+	})
+	return result
+}
+
+// searchSubstring 子串查询：先对gram求交集，再用原文校验
+func (indexer *BitmapReverseIndex) searchSubstring(sub *types.SubstringQuery, prof *queryProfiler) *util.Bitmap {
+	keys, prefix := substringTerms(sub, indexer.values, indexer.dict)
+	if prefix {
+		return indexer.searchTerms(keys, prof)
+	}
+	result := util.NewBitmap()
+	if len(keys) == 0 {
+		return result
+	}
+	var candidates *util.Bitmap
+	for i, key := range keys {
+		docs := indexer.docs(key)
+		if prof != nil {
+			prof.posting(int(docs.Cardinality()))
+		}
+		if i == 0 {
+			candidates = docs
+		} else {
+			candidates = util.And(candidates, docs)
+		}
+	}
+	candidates.ForEach(func(intId uint64) bool {
+		if indexer.values.ContainsText(sub.Field, intId, sub.Text) {
+			result.Add(intId)
+		}
+		return true
+	})
+	return result
+}
+
+// match 按IntId升序返回通过特征过滤和范围过滤的命中文档，热门查询直接取缓存。调用方需持有读锁，且不能修改返回的切片。
+// sp不为nil时要记录真实的求值开销，不读也不写缓存
+func (indexer *BitmapReverseIndex) match(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, sp *searchProfiler) []uint64 {
+	if sp != nil {
+		qp := newQueryProfiler(query, true)
+		docs := indexer.search(query, qp)
+		start := time.Now()
+		candidates := indexer.filter(docs, onFlag, offFlag, orFlags, ranges)
+		sp.filtered(start)
+		sp.matched(qp, len(candidates))
+		return candidates
+	}
+	key, deps := queryCacheKey(query, onFlag, offFlag, orFlags, ranges)
+	candidates, epoch, ok := indexer.cache.Get(key)
+	if ok {
+		return candidates
+	}
+	candidates = indexer.filter(indexer.search(query, nil), onFlag, offFlag, orFlags, ranges)
+	indexer.cache.Put(key, deps, candidates, len(candidates), epoch)
+	return candidates
+}
+
+// filter 对查询树命中的文档统一做特征过滤和范围过滤，按IntId升序返回。调用方需持有读锁
+func (indexer *BitmapReverseIndex) filter(docs *util.Bitmap, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter) []uint64 {
+	candidates := make([]uint64, 0, docs.Cardinality())
+	docs.ForEach(func(intId uint64) bool {
+		if intId > 0 && intId < uint64(len(indexer.bits)) && FilterByBits(indexer.bits[intId], onFlag, offFlag, orFlags) &&
+			indexer.values.Match(intId, ranges) {
+			candidates = append(candidates, intId)
+		}
+		return true
+	})
+	return candidates
+}
+
+// Search 搜索，不打分，按page.SortBy(为空时按IntId升序)返回一页docId
+func (indexer *BitmapReverseIndex) Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, facets *types.FacetRequest, profile bool, page Page) Hits {
+	indexer.mu.RLock()
+	defer indexer.mu.RUnlock()
+	sp := newSearchProfiler(profile)
+	candidates := indexer.match(query, onFlag, offFlag, orFlags, ranges, sp)
+	counter := newFacetCounter(facets, indexer.values)
+	for _, intId := range candidates {
+		counter.add(intId, indexer.bits[intId])
+	}
+
+	order := newOrder(page.SortBy, false, indexer.values)
+	if !order.byIntId() {
+		heap := newPageHeap(page, order, false, len(candidates))
+		for _, intId := range candidates {
+			heap.push(ScoredDoc{Id: indexer.ids[intId], IntId: intId})
+		}
+		hits := heap.hits()
+		hits.Facets = counter.result()
+		hits.Profile = sp.done()
+		return hits
+	}
+
+	collector := newPageCollector(page, order, false)
+	begin := 0
+	if page.After != nil {
+		begin, _ = slices.BinarySearch(candidates, page.After.IntId+1) // 候选文档按IntId有序，直接定位到游标之后
+	}
+	for _, intId := range candidates[begin:] {
+		if !collector.add(ScoredDoc{Id: indexer.ids[intId], IntId: intId}) {
+			break // 凑够一页就不再往后走
+		}
+	}
+	collector.hits.Total = len(candidates)
+	collector.hits.Facets = counter.result()
+	collector.hits.Profile = sp.done()
+	return collector.hits
+}
+
+// SearchTopK 搜索，按BM25得分从高到低(或按page.SortBy)返回一页文档
+func (indexer *BitmapReverseIndex) SearchTopK(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, facets *types.FacetRequest, profile bool, page Page) Hits {
+	indexer.mu.RLock()
+	defer indexer.mu.RUnlock()
+	sp := newSearchProfiler(profile)
+	candidates := indexer.match(query, onFlag, offFlag, orFlags, ranges, sp)
+	counter := newFacetCounter(facets, indexer.values)
+	if len(candidates) == 0 {
+		return Hits{Facets: counter.result(), Profile: sp.done()}
+	}
+	for _, intId := range candidates {
+		counter.add(intId, indexer.bits[intId])
+	}
+
+	// 每个词的倒排链与候选文档都是升序的，归并一遍就能累加得分，不需要对每篇文档做Rank查找
+	docCount := indexer.stats.DocCount()
+	avgDocLength := indexer.stats.AvgDocLength()
+	scores := make([]float64, len(candidates))
+	for _, term := range scoringTerms(query, indexer.values, indexer.dict) {
+		posting, exists := indexer.postings[term.Key]
+		if !exists {
+			continue
+		}
+		idf := IDF(posting.docs.Cardinality(), docCount) * term.Boost // 乘上查询里的boost
+		i, j := 0, 0                                                  // i是倒排链中的下标，j是候选文档的下标
+		posting.docs.ForEach(func(intId uint64) bool {
+			for j < len(candidates) && candidates[j] < intId {
+				j++
+			}
+			if j == len(candidates) {
+				return false
+			}
+			if candidates[j] == intId {
+				scores[j] += idf * BM25TermWeight(posting.termFreqs[i], indexer.lengths[intId], avgDocLength) * float64(posting.weights[i])
+			}
+			i++
+			return true
+		})
+	}
+
+	heap := newPageHeap(page, newOrder(page.SortBy, true, indexer.values), true, len(candidates))
+	for j, intId := range candidates {
+		heap.push(ScoredDoc{Id: indexer.ids[intId], IntId: intId, Score: scores[j]})
+	}
+	hits := heap.hits()
+	hits.Facets = counter.result()
+	hits.Profile = sp.done()
+	return hits
+}
@@ -0,0 +1,110 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"math"
+	"sync"
+)
+
+// BM25 的两个超参数
+const (
+	BM25_K1 = 1.2  // 控制词频饱和的速度，k1越大，词频对得分的影响越大
+	BM25_B  = 0.75 // 控制文档长度归一化的程度，0表示不考虑文档长度
+)
+
+// CollectionStats 倒排索引的集合统计信息：文档总数和文档总长度(用于计算平均文档长度)
+type CollectionStats struct {
+	mu          sync.RWMutex
+	docLength   map[uint64]int32 // IntId -> 文档长度，删除文档时要知道扣除多少长度
+	totalLength int64
+}
+
+func NewCollectionStats() *CollectionStats {
+	return &CollectionStats{docLength: make(map[uint64]int32, 1000)}
+}
+
+// AddDoc 记录一篇新文档，重复添加同一个IntId时以最后一次为准
+func (stats *CollectionStats) AddDoc(intId uint64, length int32) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if old, exists := stats.docLength[intId]; exists {
+		stats.totalLength -= int64(old)
+	}
+	stats.docLength[intId] = length
+	stats.totalLength += int64(length)
+}
+
+// RemoveDoc 删除一篇文档的统计信息，文档不存在时什么也不做
+func (stats *CollectionStats) RemoveDoc(intId uint64) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if old, exists := stats.docLength[intId]; exists {
+		stats.totalLength -= int64(old)
+		delete(stats.docLength, intId)
+	}
+}
+
+// DocCount 索引中的文档总数
+func (stats *CollectionStats) DocCount() int {
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	return len(stats.docLength)
+}
+
+// AvgDocLength 平均文档长度
+func (stats *CollectionStats) AvgDocLength() float64 {
+	stats.mu.RLock()
+	defer stats.mu.RUnlock()
+	if len(stats.docLength) == 0 {
+		return 0
+	}
+	return float64(stats.totalLength) / float64(len(stats.docLength))
+}
+
+// DocLength BM25用的文档长度：keyword的个数，n-gram不算，否则按n-gram建了索引的文档在别的词上得分会偏低
+func DocLength(keywords []*types.Keyword) int32 {
+	var length int32
+	for _, keyword := range keywords {
+		if _, _, ok := types.ParseNgramField(keyword.Field); !ok {
+			length++
+		}
+	}
+	return length
+}
+
+// IDF 逆文档频率，df是包含该词的文档数，docCount是文档总数。加1保证结果非负
+func IDF(df int, docCount int) float64 {
+	return math.Log(1 + (float64(docCount)-float64(df)+0.5)/(float64(df)+0.5))
+}
+
+// BM25TermWeight 单个词在单篇文档上的BM25词频部分，乘以IDF即为该词的得分
+func BM25TermWeight(tf int32, docLength int32, avgDocLength float64) float64 {
+	if tf <= 0 {
+		return 0
+	}
+	norm := 1.0
+	if avgDocLength > 0 {
+		norm = 1 - BM25_B + BM25_B*float64(docLength)/avgDocLength
+	}
+	return float64(tf) * (BM25_K1 + 1) / (float64(tf) + BM25_K1*norm)
+}
+
+// scoringTerms 参与打分的词：查询树叶子上的keyword，再加上前缀、通配符、模糊、子串查询在词典里展开出的词。
+// 展开出的词与普通keyword一样按BM25打分，文档包含其中几个就累加几个，boost是展开它的节点的boost
+func scoringTerms(q *types.TermQuery, values *DocValues, dict *TermDict) []types.BoostedKey {
+	return q.LeafTermsWith(func(q *types.TermQuery) []string {
+		switch {
+		case q.Prefix != nil:
+			return dict.PrefixTerms(q.Prefix.Field, q.Prefix.Prefix, int(q.Prefix.MaxExpansions))
+		case q.Wildcard != nil:
+			return dict.WildcardTerms(q.Wildcard.Field, q.Wildcard.Pattern, int(q.Wildcard.MaxExpansions))
+		case q.Fuzzy != nil:
+			fuzzy := q.Fuzzy
+			return dict.FuzzyTerms(fuzzy.Field, fuzzy.Word, int(fuzzy.MaxEdits), int(fuzzy.PrefixLength), int(fuzzy.MaxExpansions))
+		case q.Substring != nil:
+			keys, _ := substringTerms(q.Substring, values, dict)
+			return keys
+		}
+		return nil
+	})
+}
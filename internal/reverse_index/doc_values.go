@@ -0,0 +1,255 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DocValues 列式存储的数值属性和keyword：每个属性一列，按IntId下标存放(IntId从1开始递增分配，数组足够紧凑)
+// 范围过滤、排序、聚合时按IntId直接取值，不需要读正排、反序列化文档，所以可以在遍历倒排链的同时完成
+type DocValues struct {
+	mu       sync.RWMutex
+	ints     map[string]*column[int64]
+	floats   map[string]*column[float64]
+	keywords map[string]*keywordColumn // Keyword.Field -> 这个field上每篇文档的词
+	texts    map[string]*textColumn    // 按n-gram建了索引的field -> 每篇文档的原文，子串查询时校验
+}
+
+// column 一个属性的一列值，present记录哪些IntId有这个属性
+type column[T int64 | float64] struct {
+	values  []T
+	present []uint64 // 位集，第IntId位为1表示有值
+}
+
+func (c *column[T]) set(intId uint64, v T) {
+	for uint64(len(c.values)) <= intId {
+		c.values = append(c.values, 0)
+	}
+	for uint64(len(c.present)) <= intId/64 {
+		c.present = append(c.present, 0)
+	}
+	c.values[intId] = v
+	c.present[intId/64] |= 1 << (intId % 64)
+}
+
+func (c *column[T]) unset(intId uint64) {
+	if intId/64 < uint64(len(c.present)) {
+		c.present[intId/64] &^= 1 << (intId % 64)
+	}
+}
+
+func (c *column[T]) get(intId uint64) (T, bool) {
+	if intId/64 >= uint64(len(c.present)) || c.present[intId/64]&(1<<(intId%64)) == 0 {
+		return 0, false
+	}
+	return c.values[intId], true
+}
+
+// keywordColumn 一个field上的词。每个词编一个序号(ord)，文档只存自己的词的序号，聚合时按序号计数，不用比较字符串
+// 词删光了也不回收序号，词表的大小以该field的词汇量为上限
+type keywordColumn struct {
+	words []string          // ord -> word
+	ords  map[string]uint32 // word -> ord
+	docs  [][]uint32        // IntId -> 文档在该field上的词的ord，去重
+}
+
+func newKeywordColumn() *keywordColumn {
+	return &keywordColumn{ords: make(map[string]uint32, 64)}
+}
+
+func (c *keywordColumn) set(intId uint64, words []string) {
+	for uint64(len(c.docs)) <= intId {
+		c.docs = append(c.docs, nil)
+	}
+	ords := make([]uint32, 0, len(words))
+	for _, word := range words {
+		ord, exists := c.ords[word]
+		if !exists {
+			ord = uint32(len(c.words))
+			c.words = append(c.words, word)
+			c.ords[word] = ord
+		}
+		if !slices.Contains(ords, ord) {
+			ords = append(ords, ord)
+		}
+	}
+	c.docs[intId] = ords
+}
+
+func (c *keywordColumn) unset(intId uint64) {
+	if intId < uint64(len(c.docs)) {
+		c.docs[intId] = nil
+	}
+}
+
+func (c *keywordColumn) get(intId uint64) []uint32 {
+	if intId < uint64(len(c.docs)) {
+		return c.docs[intId]
+	}
+	return nil
+}
+
+// textColumn 一个field上每篇文档的原文，以及建索引时n-gram的长度
+type textColumn struct {
+	n     int
+	texts map[uint64]string // IntId -> 原文。只有按n-gram建索引的field才存，用map不用数组，没有这个field的文档不占空间
+}
+
+func NewDocValues() *DocValues {
+	return &DocValues{
+		ints:     make(map[string]*column[int64], 8),
+		floats:   make(map[string]*column[float64], 8),
+		keywords: make(map[string]*keywordColumn, 8),
+		texts:    make(map[string]*textColumn, 4),
+	}
+}
+
+// Add 写入文档的数值属性、keyword，以及按n-gram建了索引的field的原文
+func (dv *DocValues) Add(doc types.Document) {
+	words := make(map[string][]string, 4) // field -> words
+	grams := make(map[string]int, 1)      // 按n-gram建了索引的field -> n
+	for _, keyword := range doc.Keywords {
+		if field, n, ok := types.ParseNgramField(keyword.Field); ok {
+			grams[field] = n // gram不是有意义的词，不用聚合
+		} else if keyword.Word != "" {
+			words[keyword.Field] = append(words[keyword.Field], keyword.Word)
+		}
+	}
+	if len(doc.IntFeatures) == 0 && len(doc.FloatFeatures) == 0 && len(words) == 0 && len(grams) == 0 {
+		return
+	}
+	dv.mu.Lock()
+	defer dv.mu.Unlock()
+	for field, v := range doc.IntFeatures {
+		col, exists := dv.ints[field]
+		if !exists {
+			col = new(column[int64])
+			dv.ints[field] = col
+		}
+		col.set(doc.IntId, v)
+	}
+	for field, v := range doc.FloatFeatures {
+		col, exists := dv.floats[field]
+		if !exists {
+			col = new(column[float64])
+			dv.floats[field] = col
+		}
+		col.set(doc.IntId, v)
+	}
+	for field, words := range words {
+		col, exists := dv.keywords[field]
+		if !exists {
+			col = newKeywordColumn()
+			dv.keywords[field] = col
+		}
+		col.set(doc.IntId, words)
+	}
+	for field, n := range grams {
+		col, exists := dv.texts[field]
+		if !exists {
+			col = &textColumn{texts: make(map[uint64]string, 64)}
+			dv.texts[field] = col
+		}
+		col.n = n // 改了n要重建索引，以最后写入的为准
+		col.texts[doc.IntId] = doc.TextFields[field]
+	}
+}
+
+// Remove 删除文档的所有数值属性和keyword，可以重复调用
+func (dv *DocValues) Remove(intId uint64) {
+	dv.mu.Lock()
+	defer dv.mu.Unlock()
+	for _, col := range dv.ints {
+		col.unset(intId)
+	}
+	for _, col := range dv.floats {
+		col.unset(intId)
+	}
+	for _, col := range dv.keywords {
+		col.unset(intId)
+	}
+	for _, col := range dv.texts {
+		delete(col.texts, intId)
+	}
+}
+
+// NgramSize field建索引时n-gram的长度，没有按n-gram建索引时返回0
+func (dv *DocValues) NgramSize(field string) int {
+	dv.mu.RLock()
+	defer dv.mu.RUnlock()
+	if col, exists := dv.texts[field]; exists {
+		return col.n
+	}
+	return 0
+}
+
+// ContainsText 文档在field上的原文是否包含text
+func (dv *DocValues) ContainsText(field string, intId uint64, text string) bool {
+	dv.mu.RLock()
+	defer dv.mu.RUnlock()
+	if col, exists := dv.texts[field]; exists {
+		if stored, ok := col.texts[intId]; ok {
+			return strings.Contains(stored, text)
+		}
+	}
+	return false
+}
+
+// IntValue 文档的int64属性，没有时返回false
+func (dv *DocValues) IntValue(field string, intId uint64) (int64, bool) {
+	dv.mu.RLock()
+	defer dv.mu.RUnlock()
+	if col, exists := dv.ints[field]; exists {
+		return col.get(intId)
+	}
+	return 0, false
+}
+
+// FloatValue 文档的float64属性，没有时返回false
+func (dv *DocValues) FloatValue(field string, intId uint64) (float64, bool) {
+	dv.mu.RLock()
+	defer dv.mu.RUnlock()
+	if col, exists := dv.floats[field]; exists {
+		return col.get(intId)
+	}
+	return 0, false
+}
+
+// Match 文档是否满足所有范围条件，没有某个属性的文档不满足该属性上的条件
+func (dv *DocValues) Match(intId uint64, ranges *types.RangeFilter) bool {
+	if ranges.Empty() {
+		return true
+	}
+	dv.mu.RLock()
+	defer dv.mu.RUnlock()
+	for _, r := range ranges.Ints {
+		col, exists := dv.ints[r.Field]
+		if !exists {
+			return false
+		}
+		if v, ok := col.get(intId); !ok || !r.Contains(v) {
+			return false
+		}
+	}
+	for _, r := range ranges.Floats {
+		col, exists := dv.floats[r.Field]
+		if !exists {
+			return false
+		}
+		if v, ok := col.get(intId); !ok || !r.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFloat 属性是否是float64类型。两种类型都有时按int64处理，都没有时也按int64处理(所有文档都没有值)
+func (dv *DocValues) isFloat(field string) bool {
+	dv.mu.RLock()
+	defer dv.mu.RUnlock()
+	_, isInt := dv.ints[field]
+	_, isFloat := dv.floats[field]
+	return isFloat && !isInt
+}
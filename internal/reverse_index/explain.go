@@ -0,0 +1,122 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+)
+
+// termInfo 文档在一个key上的倒排信息
+type termInfo struct {
+	termFreq  int32
+	docLength int32
+	positions []int32
+	weight    float64 // 建索引时keyword的权重
+}
+
+// explainer 对单篇文档逐个节点求值查询树，命中的语义与search一致，得分与SearchTopK一致。两种倒排索引共用，只是取倒排信息的方式不同
+type explainer struct {
+	intId        uint64
+	values       *DocValues                        // 子串查询用原文校验
+	lookup       func(key string) (termInfo, bool) // 文档在key上的倒排信息，不包含key时返回false
+	docFreq      func(key string) int
+	dict         *TermDict
+	docCount     int
+	avgDocLength float64
+}
+
+// weight 文档在key上的BM25得分(乘上了建索引时的权重)，不包含key时为0
+func (e *explainer) weight(key string) float64 {
+	info, ok := e.lookup(key)
+	if !ok {
+		return 0
+	}
+	return IDF(e.docFreq(key), e.docCount) * BM25TermWeight(info.termFreq, info.docLength, e.avgDocLength) * info.weight
+}
+
+// explain scored为false时不计算得分(不打分的检索、MustNot下的节点)。boost是所有祖先节点boost的乘积，
+// 节点的得分是它在整个查询的得分里贡献的部分
+func (e *explainer) explain(q *types.TermQuery, scored bool, boost float64) *types.QueryExplanation {
+	node := &types.QueryExplanation{Query: q.ToQueryString()}
+	leaf := true
+	if key := q.Key(); key != "" {
+		if _, ok := e.lookup(key); ok {
+			node.MatchedKeywords = []string{key}
+		}
+		node.Matched = len(node.MatchedKeywords) > 0
+	} else if q.Phrase != nil {
+		keys := q.Phrase.Keys()
+		positions := make([][]int32, len(keys))
+		for i, key := range keys {
+			if info, ok := e.lookup(key); ok {
+				node.MatchedKeywords = append(node.MatchedKeywords, key)
+				positions[i] = info.positions
+			}
+		}
+		node.Matched = len(keys) > 0 && len(node.MatchedKeywords) == len(keys) && MatchPhrase(positions, q.Phrase.Slop)
+	} else if q.Prefix != nil {
+		node.Matched = e.explainTerms(node, e.dict.PrefixTerms(q.Prefix.Field, q.Prefix.Prefix, int(q.Prefix.MaxExpansions)))
+	} else if q.Wildcard != nil {
+		node.Matched = e.explainTerms(node, e.dict.WildcardTerms(q.Wildcard.Field, q.Wildcard.Pattern, int(q.Wildcard.MaxExpansions)))
+	} else if fuzzy := q.Fuzzy; fuzzy != nil {
+		node.Matched = e.explainTerms(node, e.dict.FuzzyTerms(fuzzy.Field, fuzzy.Word, int(fuzzy.MaxEdits), int(fuzzy.PrefixLength), int(fuzzy.MaxExpansions)))
+	} else if sub := q.Substring; sub != nil {
+		// MatchedKeywords列出文档包含的gram，是否命中以原文为准
+		keys, prefix := substringTerms(sub, e.values, e.dict)
+		e.explainTerms(node, keys)
+		node.Matched = len(keys) > 0 && (prefix || len(node.MatchedKeywords) == len(keys)) && e.values.ContainsText(sub.Field, e.intId, sub.Text)
+	} else {
+		leaf = false
+	}
+
+	// 叶子节点上的Must、Should不参与求值，与search一致；Must和Should同时存在时Should不影响是否命中，但其中的keyword参与打分
+	if !leaf {
+		childBoost := boost * q.EffectiveBoost()
+		for _, child := range q.Must {
+			node.Must = append(node.Must, e.explain(child, scored, childBoost))
+		}
+		for _, child := range q.Should {
+			node.Should = append(node.Should, e.explain(child, scored, childBoost))
+		}
+		if len(q.Must) > 0 {
+			node.Matched = true
+			for _, child := range node.Must {
+				node.Matched = node.Matched && child.Matched
+			}
+		} else if len(q.Should) > 0 {
+			if minMatch, err := q.MinShouldMatch(); err == nil {
+				node.MinimumShouldMatch = int32(minMatch)
+				matched := 0
+				for _, child := range node.Should {
+					if child.Matched {
+						matched++
+					}
+				}
+				node.Matched = matched >= minMatch
+			}
+		}
+	}
+
+	for _, child := range q.MustNot {
+		explained := e.explain(child, false, 1)
+		node.MustNot = append(node.MustNot, explained)
+		if explained.Matched {
+			node.Matched = false
+		}
+	}
+
+	if scored {
+		for _, term := range scoringTerms(q, e.values, e.dict) {
+			node.Score += e.weight(term.Key) * term.Boost * boost
+		}
+	}
+	return node
+}
+
+// explainTerms 展开成多个词的查询，文档包含其中任何一个就命中
+func (e *explainer) explainTerms(node *types.QueryExplanation, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := e.lookup(key); ok {
+			node.MatchedKeywords = append(node.MatchedKeywords, key)
+		}
+	}
+	return len(node.MatchedKeywords) > 0
+}
@@ -0,0 +1,103 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"cmp"
+	"slices"
+)
+
+// facetCounter 在遍历命中文档的同时做聚合统计，词从DocValues里按IntId取，不读正排。
+// 方法都可以在nil上调用，没有请求聚合时调用方不需要判断
+type facetCounter struct {
+	request *types.FacetRequest
+	values  *DocValues
+	columns []*keywordColumn // 与request.Terms一一对应，field上没有任何词时为nil
+	terms   [][]int64        // 每个TermsFacet按ord计数
+	bits    []int64          // 与request.Bits一一对应
+}
+
+// newFacetCounter request为空时返回nil
+func newFacetCounter(request *types.FacetRequest, values *DocValues) *facetCounter {
+	if request.Empty() {
+		return nil
+	}
+	c := &facetCounter{
+		request: request,
+		values:  values,
+		columns: make([]*keywordColumn, len(request.Terms)),
+		terms:   make([][]int64, len(request.Terms)),
+		bits:    make([]int64, len(request.Bits)),
+	}
+	values.mu.RLock()
+	defer values.mu.RUnlock()
+	for i, facet := range request.Terms {
+		if col, exists := values.keywords[facet.Field]; exists {
+			c.columns[i] = col
+			c.terms[i] = make([]int64, len(col.words))
+		}
+	}
+	return c
+}
+
+// add 统计一个命中的文档，bits是文档的BitsFeature
+func (c *facetCounter) add(intId uint64, bits uint64) {
+	if c == nil {
+		return
+	}
+	for i, flag := range c.request.Bits {
+		if bits&flag == flag {
+			c.bits[i]++
+		}
+	}
+	if len(c.columns) == 0 {
+		return
+	}
+	c.values.mu.RLock()
+	defer c.values.mu.RUnlock()
+	for i, col := range c.columns {
+		if col == nil {
+			continue
+		}
+		for _, ord := range col.get(intId) {
+			for int(ord) >= len(c.terms[i]) { // 统计期间有新词写入
+				c.terms[i] = append(c.terms[i], 0)
+			}
+			c.terms[i][ord]++
+		}
+	}
+}
+
+// result 统计结果，每个field取文档数最多的几个词
+func (c *facetCounter) result() *types.FacetResult {
+	if c == nil {
+		return nil
+	}
+	result := &types.FacetResult{
+		Terms: make([]*types.TermsFacetResult, 0, len(c.request.Terms)),
+		Bits:  make([]*types.BitCount, 0, len(c.request.Bits)),
+	}
+	c.values.mu.RLock()
+	defer c.values.mu.RUnlock()
+	for i, facet := range c.request.Terms {
+		counts := make([]*types.TermCount, 0, 16)
+		for ord, count := range c.terms[i] {
+			if count > 0 {
+				counts = append(counts, &types.TermCount{Word: c.columns[i].words[ord], Count: count})
+			}
+		}
+		slices.SortFunc(counts, func(a, b *types.TermCount) int {
+			if a.Count != b.Count {
+				return cmp.Compare(b.Count, a.Count)
+			}
+			return cmp.Compare(a.Word, b.Word)
+		})
+		if len(counts) > facet.Limit() {
+			counts = counts[:facet.Limit()]
+		}
+		result.Terms = append(result.Terms, &types.TermsFacetResult{Field: facet.Field, Terms: counts})
+	}
+	for i, flag := range c.request.Bits {
+		result.Bits = append(result.Bits, &types.BitCount{Flag: flag, Count: c.bits[i]})
+	}
+	return result
+}
@@ -0,0 +1,138 @@
+package reverse_index
+
+import (
+	"RADIC/util"
+	"log/slog"
+	"slices"
+	"sort"
+)
+
+const MAX_EDITS = 2 // 模糊查询允许的最大编辑距离，距离越大展开的词越多，且越不像用户想要的词
+
+// LevenshteinAutomaton 接受与word编辑距离不超过maxEdits的字符串的自动机，按rune计算距离，所以中文一个字算一个字符
+// 状态是动态规划表的一行：row[i]表示已读入的字符串与word[:i]的编辑距离
+type LevenshteinAutomaton struct {
+	word     []rune
+	maxEdits int
+}
+
+func NewLevenshteinAutomaton(word string, maxEdits int) *LevenshteinAutomaton {
+	return &LevenshteinAutomaton{word: []rune(word), maxEdits: maxEdits}
+}
+
+// Start 初始状态：空串与word[:i]的距离是i
+func (a *LevenshteinAutomaton) Start() []int {
+	row := make([]int, len(a.word)+1)
+	for i := range row {
+		row[i] = i
+	}
+	return row
+}
+
+// Step 读入一个字符r，返回新状态
+func (a *LevenshteinAutomaton) Step(row []int, r rune) []int {
+	next := make([]int, len(row))
+	next[0] = row[0] + 1
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if a.word[i-1] == r {
+			cost = 0
+		}
+		next[i] = min(row[i-1]+cost, row[i]+1, next[i-1]+1) // 替换、插入、删除
+	}
+	return next
+}
+
+// IsMatch 当前读入的字符串与word的距离不超过maxEdits
+func (a *LevenshteinAutomaton) IsMatch(row []int) bool {
+	return row[len(row)-1] <= a.maxEdits
+}
+
+// CanMatch 再往后读字符还有没有可能匹配：行里的最小值只会增不会减
+func (a *LevenshteinAutomaton) CanMatch(row []int) bool {
+	return slices.Min(row) <= a.maxEdits
+}
+
+// Distance 状态对应的编辑距离
+func (a *LevenshteinAutomaton) Distance(row []int) int {
+	return row[len(row)-1]
+}
+
+// fuzzyCandidate 模糊匹配到的词和它与查询词的编辑距离
+type fuzzyCandidate struct {
+	key      string
+	distance int
+}
+
+// FuzzyTerms 返回field下与word编辑距离不超过maxEdits的词(编码后的key)，前prefixLength个字符必须完全一致
+// 超过maxExpansions个时，优先保留编辑距离小的词
+func (dict *TermDict) FuzzyTerms(field string, word string, maxEdits int, prefixLength int, maxExpansions int) []string {
+	candidates := dict.fuzzyCandidates(field, word, maxEdits, prefixLength)
+	limit := expansionLimit(maxExpansions)
+	if len(candidates) > limit {
+		slog.Warn("fuzzy query expands too many terms, truncated",
+			slog.String("field", field), slog.String("word", word), slog.Int("limit", limit))
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].distance < candidates[j].distance
+		})
+		candidates = candidates[:limit]
+	}
+	keys := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		keys = append(keys, c.key)
+	}
+	return keys
+}
+
+// fuzzyCandidates 用Levenshtein自动机遍历前缀树，返回field下与word编辑距离不超过maxEdits的所有词，按字典序
+func (dict *TermDict) fuzzyCandidates(field string, word string, maxEdits int, prefixLength int) []fuzzyCandidate {
+	maxEdits = max(0, min(maxEdits, MAX_EDITS))
+	runes := []rune(word)
+	prefixLength = max(0, min(prefixLength, len(runes)))
+	prefix := string(runes[:prefixLength])
+
+	automaton := NewLevenshteinAutomaton(word, maxEdits)
+	state := automaton.Start()
+	for _, r := range runes[:prefixLength] {
+		state = automaton.Step(state, r)
+	}
+
+	candidates := make([]fuzzyCandidate, 0, 16)
+	dict.mu.RLock()
+	if trie, exists := dict.fields[field]; exists {
+		util.WalkTrie(trie, prefix, state,
+			func(row []int, r rune) ([]int, bool) {
+				next := automaton.Step(row, r)
+				return next, automaton.CanMatch(next)
+			},
+			automaton.IsMatch,
+			func(w string, row []int) bool {
+				candidates = append(candidates, fuzzyCandidate{joinKey(field, w), automaton.Distance(row)})
+				return true
+			})
+	}
+	dict.mu.RUnlock()
+	return candidates
+}
+
+// similarTerms 拼写纠错的候选词：field下与word编辑距离在1~maxEdits之间的词，按文档频率从高到低取前k个，
+// 文档频率相同时编辑距离小的在前。与fuzzyCandidates一样先从词典取出来，再查文档频率
+func similarTerms(dict *TermDict, docFreq func(key string) int, field string, word string, maxEdits int, k int) []TermStat {
+	candidates := dict.fuzzyCandidates(field, word, maxEdits, 0)
+	terms := make([]TermStat, 0, len(candidates))
+	for _, c := range candidates {
+		df := docFreq(c.key)
+		if c.distance == 0 || df == 0 {
+			continue // 原词不是纠错的候选，文档都删掉了的词也不是
+		}
+		_, w := splitKey(c.key)
+		terms = append(terms, TermStat{Word: w, DocFreq: df, Score: float64(df), Distance: c.distance})
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		if terms[i].DocFreq != terms[j].DocFreq {
+			return terms[i].DocFreq > terms[j].DocFreq
+		}
+		return terms[i].Distance < terms[j].Distance
+	})
+	return terms[:min(k, len(terms))]
+}
@@ -0,0 +1,223 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Page 分页参数：从After之后(After为nil时从第一个)开始，跳过Offset个，最多取Limit个，Limit<=0表示不限制
+// 深翻页时Offset要把前面的文档都走一遍，用上一页返回的游标(search-after)翻页代价与页码无关
+type Page struct {
+	Offset int
+	Limit  int
+	After  *Cursor
+	SortBy []*types.SortBy // 为空时打分按得分降序，不打分按IntId升序
+}
+
+// Cursor search-after分页的游标，即上一页最后一个文档的排序键，IntId是最后的排序依据
+type Cursor struct {
+	Scored bool
+	IntId  uint64
+	Sort   []SortValue
+}
+
+// Hits 一页检索结果
+type Hits struct {
+	Docs    []ScoredDoc          // 不打分时Score为0
+	Total   int                  // 通过过滤的命中文档总数，与分页无关
+	Next    *Cursor              // 下一页的游标，没有下一页时为nil
+	Facets  *types.FacetResult   // 对所有命中文档的聚合统计，没有请求聚合时为nil
+	Profile *types.SearchProfile // 各阶段的耗时，没有请求Profile时为nil
+}
+
+// Check 游标必须是同样的打分方式、同样的排序条件下生成的
+func (c *Cursor) Check(sortBy []*types.SortBy, scored bool) error {
+	if c == nil {
+		return nil
+	}
+	if c.Scored != scored {
+		return fmt.Errorf("cursor does not match the scoring of the request")
+	}
+	n := len(sortBy)
+	if n == 0 && scored {
+		n = 1 // 默认按得分排序
+	}
+	if len(c.Sort) != n {
+		return fmt.Errorf("cursor does not match the sort of the request")
+	}
+	return nil
+}
+
+// 游标中排序值的类型
+const (
+	SORT_VALUE_MISSING byte = iota
+	SORT_VALUE_INT
+	SORT_VALUE_FLOAT
+)
+
+// Encode 把游标编码成不透明的字符串，返回给客户端
+func (c *Cursor) Encode() string {
+	buf := make([]byte, 0, 2+binary.MaxVarintLen64+len(c.Sort)*(1+8))
+	if c.Scored {
+		buf = append(buf, 1)
+	} else {
+		buf = append(buf, 0)
+	}
+	buf = binary.AppendUvarint(buf, c.IntId)
+	buf = binary.AppendUvarint(buf, uint64(len(c.Sort)))
+	for _, v := range c.Sort {
+		switch {
+		case v.Missing:
+			buf = append(buf, SORT_VALUE_MISSING)
+		case v.IsFloat:
+			buf = append(buf, SORT_VALUE_FLOAT)
+			buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(v.Float))
+		default:
+			buf = append(buf, SORT_VALUE_INT)
+			buf = binary.AppendVarint(buf, v.Int)
+		}
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// DecodeCursor 解析Encode生成的游标，空字符串返回nil
+func DecodeCursor(s string) (*Cursor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(buf) == 0 || buf[0] > 1 {
+		return nil, errInvalidCursor
+	}
+	cursor := &Cursor{Scored: buf[0] == 1}
+	buf = buf[1:]
+	intId, n := binary.Uvarint(buf)
+	if n <= 0 {
+		return nil, errInvalidCursor
+	}
+	cursor.IntId = intId
+	buf = buf[n:]
+	count, n := binary.Uvarint(buf)
+	if n <= 0 || count > uint64(len(buf)) { // 每个排序值至少占1个字节
+		return nil, errInvalidCursor
+	}
+	buf = buf[n:]
+	cursor.Sort = make([]SortValue, 0, count)
+	for i := uint64(0); i < count; i++ {
+		if len(buf) == 0 {
+			return nil, errInvalidCursor
+		}
+		tag := buf[0]
+		buf = buf[1:]
+		switch tag {
+		case SORT_VALUE_MISSING:
+			cursor.Sort = append(cursor.Sort, SortValue{Missing: true})
+		case SORT_VALUE_INT:
+			v, n := binary.Varint(buf)
+			if n <= 0 {
+				return nil, errInvalidCursor
+			}
+			cursor.Sort = append(cursor.Sort, SortValue{Int: v})
+			buf = buf[n:]
+		case SORT_VALUE_FLOAT:
+			if len(buf) < 8 {
+				return nil, errInvalidCursor
+			}
+			cursor.Sort = append(cursor.Sort, SortValue{IsFloat: true, Float: math.Float64frombits(binary.BigEndian.Uint64(buf))})
+			buf = buf[8:]
+		default:
+			return nil, errInvalidCursor
+		}
+	}
+	if len(buf) != 0 {
+		return nil, errInvalidCursor
+	}
+	return cursor, nil
+}
+
+// pageCollector 按排序依次喂入命中的文档，收集其中的一页
+type pageCollector struct {
+	page    Page
+	order   *order
+	scored  bool
+	skipped int
+	hits    Hits
+}
+
+func newPageCollector(page Page, order *order, scored bool) *pageCollector {
+	c := &pageCollector{page: page, order: order, scored: scored}
+	if page.Limit > 0 {
+		c.hits.Docs = make([]ScoredDoc, 0, min(page.Limit, 1024))
+	}
+	return c
+}
+
+// add 喂入一个文档，返回false表示这一页已经收满且确定还有下一页，后面的文档不用再喂了
+func (c *pageCollector) add(doc ScoredDoc) bool {
+	if c.page.After != nil && !c.order.before(c.order.docOf(c.page.After), doc) {
+		return true
+	}
+	if c.skipped < c.page.Offset {
+		c.skipped++
+		return true
+	}
+	if c.page.Limit > 0 && len(c.hits.Docs) == c.page.Limit {
+		c.hits.Next = c.order.cursorOf(c.hits.Docs[len(c.hits.Docs)-1], c.scored)
+		return false
+	}
+	c.hits.Docs = append(c.hits.Docs, doc)
+	return true
+}
+
+// pageHeap 文档不按排序到达时(打分、按属性排序)，用有界堆取一页：
+// 游标之前的文档不入堆，堆里只需要保留Offset+Limit+1个文档(多1个用来判断有没有下一页)，不用对所有命中的文档排序
+type pageHeap struct {
+	page   Page
+	order  *order
+	scored bool
+	topK   *TopK
+	after  ScoredDoc // 游标所在位置的文档
+	total  int
+}
+
+// newPageHeap total是候选文档数
+func newPageHeap(page Page, order *order, scored bool, total int) *pageHeap {
+	size := total
+	if page.Limit > 0 {
+		size = min(page.Offset+page.Limit+1, total)
+	}
+	h := &pageHeap{page: page, order: order, scored: scored, topK: NewTopK(size, order.before), total: total}
+	if page.After != nil {
+		h.after = order.docOf(page.After)
+	}
+	return h
+}
+
+// push 放入一个文档，doc.Score需要先算好
+func (h *pageHeap) push(doc ScoredDoc) {
+	h.order.fill(&doc)
+	if h.page.After != nil && !h.order.before(h.after, doc) {
+		return
+	}
+	h.topK.Push(doc)
+}
+
+// hits 从堆里取出一页
+func (h *pageHeap) hits() Hits {
+	page := h.page
+	page.After = nil // 游标之前的文档在入堆前已经排除了
+	collector := newPageCollector(page, h.order, h.scored)
+	for _, doc := range h.topK.Sorted() {
+		if !collector.add(doc) {
+			break
+		}
+	}
+	collector.hits.Total = h.total
+	return collector.hits
+}
@@ -0,0 +1,88 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"github.com/huandu/skiplist"
+)
+
+// searchPhrase 短语查询：先对短语里的所有词求交集，再用每个词在文档中的位置校验词序和间隔
+func (indexer SkipListReverseIndex) searchPhrase(phrase *types.PhraseQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, prof *queryProfiler) *skiplist.SkipList {
+	keys := phrase.Keys()
+	if len(keys) == 0 {
+		return nil
+	}
+	lists := make([]*skiplist.SkipList, 0, len(keys))
+	for _, keyword := range keys {
+		value, exists := indexer.table.Get(keyword)
+		if !exists {
+			return nil // 有一个词不存在，短语肯定不命中
+		}
+		lists = append(lists, value.(*skiplist.SkipList))
+		prof.posting(value.(*skiplist.SkipList).Len())
+	}
+
+	// 候选文档：包含全部词且通过特征过滤和范围过滤
+	musts := make([]*types.TermQuery, 0, len(keys))
+	for _, keyword := range keys {
+		musts = append(musts, &types.TermQuery{Keyword: keyword})
+	}
+	candidates := indexer.search(&types.TermQuery{Must: musts}, onFlag, offFlag, orFlags, ranges, prof.detached())
+	if candidates == nil || candidates.Len() == 0 {
+		return nil
+	}
+
+	result := skiplist.New(skiplist.Uint64)
+	positions := make([][]int32, len(lists))
+	for node := candidates.Front(); node != nil; node = node.Next() {
+		intId := node.Key().(uint64)
+		for i, list := range lists {
+			positions[i] = nil
+			if elem := list.Get(intId); elem != nil {
+				positions[i] = elem.Value.(SkipListValue).Positions
+			}
+		}
+		if MatchPhrase(positions, phrase.Slop) {
+			result.Set(intId, node.Value)
+		}
+	}
+	return result
+}
+
+// MatchPhrase 判断各个词的位置能否按顺序组成一个短语，positions[i]是第i个词的位置(升序)
+// 要求 p0 < p1 < ... < pn-1，且间隔合计 (pn-1 - p0) - (n-1) 不超过slop。slop为0即精确短语
+// 有任何一个词没有位置信息时无法校验，视为不命中
+func MatchPhrase(positions [][]int32, slop int32) bool {
+	if len(positions) == 0 {
+		return false
+	}
+	for _, pos := range positions {
+		if len(pos) == 0 {
+			return false
+		}
+	}
+	n := int32(len(positions))
+	// 枚举第一个词的位置，后面每个词贪心地取大于前一个词的最小位置，这样得到的结尾位置最小
+	cursors := make([]int, len(positions))
+	for _, start := range positions[0] {
+		prev := start
+		matched := true
+		for i := 1; i < len(positions); i++ {
+			pos := positions[i]
+			for cursors[i] < len(pos) && pos[cursors[i]] <= prev {
+				cursors[i]++ // start递增，所以游标只需要向后移动
+			}
+			if cursors[i] == len(pos) {
+				return false // 后面的词已经没有更靠后的位置了，更大的start也不可能命中
+			}
+			prev = pos[cursors[i]]
+			if prev-start-(int32(i)) > slop {
+				matched = false
+				break
+			}
+		}
+		if matched && prev-start-(n-1) <= slop {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"time"
+)
+
+// queryProfiler 记录查询树上一个节点的求值开销。方法都可以在nil上调用，不做Profile时传nil，调用方不需要判断
+type queryProfiler struct {
+	node   *types.QueryProfile
+	start  time.Time
+	filter *int64 // 整棵查询树在叶子上做特征过滤和范围过滤的耗时，所有节点共用
+}
+
+// newQueryProfiler 查询树根节点的profiler，enabled为false时返回nil
+func newQueryProfiler(q *types.TermQuery, enabled bool) *queryProfiler {
+	if !enabled {
+		return nil
+	}
+	return &queryProfiler{node: &types.QueryProfile{Query: q.ToQueryString()}, start: time.Now(), filter: new(int64)}
+}
+
+// child 与p共用过滤耗时的子节点profiler
+func (p *queryProfiler) child(q *types.TermQuery) *queryProfiler {
+	return &queryProfiler{node: &types.QueryProfile{Query: q.ToQueryString()}, start: time.Now(), filter: p.filter}
+}
+
+// must、should、mustNot 子节点的profiler，创建时开始计时
+func (p *queryProfiler) must(q *types.TermQuery) *queryProfiler {
+	if p == nil {
+		return nil
+	}
+	child := p.child(q)
+	p.node.Must = append(p.node.Must, child.node)
+	return child
+}
+
+func (p *queryProfiler) should(q *types.TermQuery) *queryProfiler {
+	if p == nil {
+		return nil
+	}
+	child := p.child(q)
+	p.node.Should = append(p.node.Should, child.node)
+	return child
+}
+
+func (p *queryProfiler) mustNot(q *types.TermQuery) *queryProfiler {
+	if p == nil {
+		return nil
+	}
+	child := p.child(q)
+	p.node.MustNot = append(p.node.MustNot, child.node)
+	return child
+}
+
+// posting 叶子节点读取了一条长度为n的倒排链
+func (p *queryProfiler) posting(n int) {
+	if p != nil {
+		p.node.PostingSize += int64(n)
+	}
+}
+
+// detached 不挂在查询树上、只累计过滤耗时的profiler，用于短语的候选、展开出的词这类内部的求值
+func (p *queryProfiler) detached() *queryProfiler {
+	if p == nil {
+		return nil
+	}
+	return &queryProfiler{node: &types.QueryProfile{}, start: time.Now(), filter: p.filter}
+}
+
+// filterStart、filterDone 叶子节点过滤一篇文档前后调用，累计过滤耗时。nil上不计时
+func (p *queryProfiler) filterStart() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+func (p *queryProfiler) filterDone(start time.Time) {
+	if p != nil {
+		*p.filter += time.Since(start).Nanoseconds()
+	}
+}
+
+// done 节点求值结束
+func (p *queryProfiler) done(resultCount int) {
+	if p != nil {
+		p.node.Nanos = time.Since(p.start).Nanoseconds()
+		p.node.ResultCount = int64(resultCount)
+	}
+}
+
+// profile 查询树的开销，nil上返回nil
+func (p *queryProfiler) profile() *types.QueryProfile {
+	if p == nil {
+		return nil
+	}
+	return p.node
+}
+
+// searchProfiler 倒排索引内一次检索的各阶段开销
+type searchProfiler struct {
+	profile *types.SearchProfile
+	start   time.Time
+}
+
+func newSearchProfiler(enabled bool) *searchProfiler {
+	if !enabled {
+		return nil
+	}
+	return &searchProfiler{profile: &types.SearchProfile{}, start: time.Now()}
+}
+
+// matched 求完命中集合，此后的耗时算在RankNanos里
+func (p *searchProfiler) matched(query *queryProfiler, matchedDocs int) {
+	if p == nil {
+		return
+	}
+	p.profile.Query = query.profile()
+	if query != nil {
+		p.profile.FilterNanos += *query.filter // 跳表在叶子上过滤的耗时
+	}
+	p.profile.MatchNanos = time.Since(p.start).Nanoseconds()
+	p.profile.MatchedDocs = int64(matchedDocs)
+	p.start = time.Now()
+}
+
+// filtered 位图索引最后统一过滤的耗时
+func (p *searchProfiler) filtered(start time.Time) {
+	if p != nil {
+		p.profile.FilterNanos = time.Since(start).Nanoseconds()
+	}
+}
+
+// done 打分、排序、分页、聚合结束，返回整个开销
+func (p *searchProfiler) done() *types.SearchProfile {
+	if p == nil {
+		return nil
+	}
+	p.profile.RankNanos = time.Since(p.start).Nanoseconds()
+	return p.profile
+}
@@ -0,0 +1,75 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"cmp"
+	"math"
+	"slices"
+)
+
+// estimateCost 预估查询命中的文档数(不考虑过滤)，用于安排Must子查询的求值顺序，不需要精确。
+// 前缀、通配符、模糊、子串查询要遍历词典才能知道展开出哪些词，预估时不展开，都当作最贵的放到最后
+func estimateCost(q *types.TermQuery, docFreq func(key string) int) int {
+	if key := q.Key(); key != "" {
+		return docFreq(key)
+	} else if q.Phrase != nil {
+		keys := q.Phrase.Keys()
+		if len(keys) == 0 {
+			return 0
+		}
+		cost := math.MaxInt
+		for _, key := range keys {
+			cost = min(cost, docFreq(key))
+		}
+		return cost
+	} else if q.Prefix != nil || q.Wildcard != nil || q.Fuzzy != nil || q.Substring != nil {
+		return math.MaxInt
+	} else if len(q.Must) > 0 {
+		cost := math.MaxInt
+		for _, child := range q.Must {
+			cost = min(cost, estimateCost(child, docFreq))
+		}
+		return cost
+	} else if len(q.Should) > 0 {
+		// 至少命中m个子句的文档，一定命中预估最小的n-m+1个子句中的某一个
+		minMatch, err := q.MinShouldMatch()
+		if err != nil || minMatch > len(q.Should) {
+			return 0
+		}
+		costs := make([]int, 0, len(q.Should))
+		for _, child := range q.Should {
+			costs = append(costs, estimateCost(child, docFreq))
+		}
+		slices.Sort(costs)
+		cost := 0
+		for _, c := range costs[:len(costs)-minMatch+1] {
+			cost = addCost(cost, c)
+		}
+		return cost
+	}
+	return math.MaxInt
+}
+
+// addCost 不溢出的加法
+func addCost(a, b int) int {
+	if a > math.MaxInt-b {
+		return math.MaxInt
+	}
+	return a + b
+}
+
+// planMust Must子查询的求值顺序：预估命中数少的先求，预估相同的保持原来的顺序
+func planMust(children []*types.TermQuery, docFreq func(key string) int) []*types.TermQuery {
+	if len(children) <= 1 {
+		return children
+	}
+	costs := make(map[*types.TermQuery]int, len(children))
+	for _, child := range children {
+		costs[child] = estimateCost(child, docFreq)
+	}
+	plan := slices.Clone(children)
+	slices.SortStableFunc(plan, func(a, b *types.TermQuery) int {
+		return cmp.Compare(costs[a], costs[b])
+	})
+	return plan
+}
@@ -0,0 +1,266 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"container/list"
+	"crypto/sha256"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	DEFAULT_CACHE_ENTRIES = 1024    // 结果缓存最多缓存的查询数
+	DEFAULT_CACHE_DOCS    = 1 << 20 // 结果缓存里所有结果的文档数之和的上限，超过单个上限的结果不缓存
+)
+
+// CacheStats 结果缓存的统计
+type CacheStats struct {
+	Hits          int64
+	Misses        int64
+	Evictions     int64 // 因为容量不够被淘汰的条目数
+	Invalidations int64 // 因为写入文档被删掉的条目数
+	Entries       int   // 当前缓存的条目数
+	Docs          int   // 当前缓存的文档数
+}
+
+// cacheKey 查询和过滤条件的规范化编码的哈希
+type cacheKey [sha256.Size]byte
+
+type cacheEntry[V any] struct {
+	key   cacheKey
+	value V
+	size  int      // 结果的文档数
+	deps  []string // 结果依赖的key，这些key上的倒排链变了结果就可能变
+}
+
+// ResultCache 热门查询的匹配结果(过滤后、分页前的文档集合)的LRU缓存，按条目数和文档数限制大小。
+// 每个条目记录依赖的key，写入、删除文档时只淘汰依赖了该文档的key的条目，其他查询的缓存不受影响。
+// 打分、排序、分页、聚合都在缓存的结果上现算，所以文档总数、平均长度等统计量的变化不会让缓存的结果过时
+type ResultCache[V any] struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxDocs    int
+	docs       int
+	lru        *list.List // 元素是*cacheEntry[V]，队头是最近使用的
+	entries    map[cacheKey]*list.Element
+	byDep      map[string]map[cacheKey]struct{} // 依赖的key -> 依赖它的条目
+	epoch      uint64                           // 每次写入加1，用来识别检索期间发生过的写入
+
+	hits, misses, evictions, invalidations atomic.Int64
+}
+
+// NewResultCache maxEntries或maxDocs<=0时返回nil，即不缓存。nil上可以调用所有方法
+func NewResultCache[V any](maxEntries int, maxDocs int) *ResultCache[V] {
+	if maxEntries <= 0 || maxDocs <= 0 {
+		return nil
+	}
+	return &ResultCache[V]{
+		maxEntries: maxEntries,
+		maxDocs:    maxDocs,
+		lru:        list.New(),
+		entries:    make(map[cacheKey]*list.Element, maxEntries),
+		byDep:      make(map[string]map[cacheKey]struct{}, maxEntries),
+	}
+}
+
+// Get 命中时返回缓存的结果。没命中时返回的epoch要传给Put
+func (c *ResultCache[V]) Get(key cacheKey) (value V, epoch uint64, ok bool) {
+	if c == nil {
+		return value, 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, exists := c.entries[key]; exists {
+		c.lru.MoveToFront(elem)
+		c.hits.Add(1)
+		return elem.Value.(*cacheEntry[V]).value, c.epoch, true
+	}
+	c.misses.Add(1)
+	return value, c.epoch, false
+}
+
+// Put 缓存检索结果。epoch是检索开始前Get返回的，之后发生过写入的话结果可能是按旧数据算的，不缓存
+func (c *ResultCache[V]) Put(key cacheKey, deps []string, value V, size int, epoch uint64) {
+	if c == nil || size > c.maxDocs {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if epoch != c.epoch {
+		return
+	}
+	if elem, exists := c.entries[key]; exists {
+		c.remove(elem)
+	}
+	entry := &cacheEntry[V]{key: key, value: value, size: size, deps: deps}
+	c.entries[key] = c.lru.PushFront(entry)
+	c.docs += size
+	for _, dep := range deps {
+		keys, exists := c.byDep[dep]
+		if !exists {
+			keys = make(map[cacheKey]struct{}, 1)
+			c.byDep[dep] = keys
+		}
+		keys[key] = struct{}{}
+	}
+	for c.lru.Len() > c.maxEntries || c.docs > c.maxDocs {
+		c.remove(c.lru.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// Invalidate 文档在keys上的倒排链发生了变化(写入或删除)，淘汰依赖这些key的条目
+func (c *ResultCache[V]) Invalidate(keys ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.epoch++
+	for _, key := range keys {
+		field, _ := splitKey(key)
+		deps := []string{key, fieldDep(field)}
+		if source, _, ok := types.ParseNgramField(field); ok {
+			deps = append(deps, substringDep(source))
+		}
+		for _, dep := range deps {
+			for cached := range c.byDep[dep] {
+				c.remove(c.entries[cached])
+				c.invalidations.Add(1)
+			}
+		}
+	}
+}
+
+// remove 删除一个条目，调用方需持有锁
+func (c *ResultCache[V]) remove(elem *list.Element) {
+	entry := elem.Value.(*cacheEntry[V])
+	c.lru.Remove(elem)
+	delete(c.entries, entry.key)
+	c.docs -= entry.size
+	for _, dep := range entry.deps {
+		if keys, exists := c.byDep[dep]; exists {
+			delete(keys, entry.key)
+			if len(keys) == 0 {
+				delete(c.byDep, dep)
+			}
+		}
+	}
+}
+
+// Stats 缓存的统计
+func (c *ResultCache[V]) Stats() CacheStats {
+	if c == nil {
+		return CacheStats{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{
+		Hits:          c.hits.Load(),
+		Misses:        c.misses.Load(),
+		Evictions:     c.evictions.Load(),
+		Invalidations: c.invalidations.Load(),
+		Entries:       c.lru.Len(),
+		Docs:          c.docs,
+	}
+}
+
+// fieldDep 前缀、通配符、模糊查询展开出哪些词取决于词典，field上任何词的倒排链变了都要淘汰
+func fieldDep(field string) string {
+	return field + "\002"
+}
+
+// substringDep 子串查询用到哪些gram、校验用的原文，取决于field上所有的gram
+func substringDep(field string) string {
+	return field + "\003"
+}
+
+// queryCacheKey 查询和过滤条件的规范化哈希：Must、Should、MustNot的子查询顺序和orFlags的顺序不影响结果，编码前先排序。
+// 同时返回查询依赖的key
+func queryCacheKey(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter) (cacheKey, []string) {
+	var sb strings.Builder
+	deps := make([]string, 0, 4)
+	writeCanonicalQuery(&sb, query, &deps)
+
+	flags := make([]uint64, 0, len(orFlags))
+	for _, flag := range orFlags {
+		if flag > 0 { // 与FilterByBits一致，为0的orFlag不起作用
+			flags = append(flags, flag)
+		}
+	}
+	slices.Sort(flags)
+	flags = slices.Compact(flags)
+	sb.WriteString("|on:" + strconv.FormatUint(onFlag, 16) + "|off:" + strconv.FormatUint(offFlag, 16) + "|or:")
+	for _, flag := range flags {
+		sb.WriteString(strconv.FormatUint(flag, 16) + ",")
+	}
+
+	if !ranges.Empty() {
+		conditions := make([]string, 0, len(ranges.Ints)+len(ranges.Floats))
+		for _, r := range ranges.Ints {
+			conditions = append(conditions, "i"+r.String())
+		}
+		for _, r := range ranges.Floats {
+			conditions = append(conditions, "f"+r.String())
+		}
+		slices.Sort(conditions)
+		sb.WriteString("|ranges:" + strings.Join(conditions, ","))
+	}
+
+	slices.Sort(deps)
+	return sha256.Sum256([]byte(sb.String())), slices.Compact(deps)
+}
+
+// writeCanonicalQuery 查询树的规范化编码，字符串都加引号，不会与分隔符混淆。Boost只影响打分，不参与编码
+func writeCanonicalQuery(sb *strings.Builder, q *types.TermQuery, deps *[]string) {
+	if q == nil {
+		sb.WriteString("nil")
+		return
+	}
+	if key := q.Key(); key != "" {
+		sb.WriteString("k" + strconv.Quote(key))
+		*deps = append(*deps, key)
+	} else if q.Phrase != nil {
+		sb.WriteString("p" + strconv.Itoa(int(q.Phrase.Slop)))
+		for _, key := range q.Phrase.Keys() {
+			sb.WriteString(strconv.Quote(key))
+			*deps = append(*deps, key)
+		}
+	} else if q.Prefix != nil {
+		sb.WriteString("x" + strconv.Quote(q.Prefix.Field) + strconv.Quote(q.Prefix.Prefix) + strconv.Itoa(int(q.Prefix.MaxExpansions)))
+		*deps = append(*deps, fieldDep(q.Prefix.Field))
+	} else if q.Wildcard != nil {
+		sb.WriteString("w" + strconv.Quote(q.Wildcard.Field) + strconv.Quote(q.Wildcard.Pattern) + strconv.Itoa(int(q.Wildcard.MaxExpansions)))
+		*deps = append(*deps, fieldDep(q.Wildcard.Field))
+	} else if fuzzy := q.Fuzzy; fuzzy != nil {
+		sb.WriteString("z" + strconv.Quote(fuzzy.Field) + strconv.Quote(fuzzy.Word) +
+			strconv.Itoa(int(fuzzy.MaxEdits)) + "," + strconv.Itoa(int(fuzzy.PrefixLength)) + "," + strconv.Itoa(int(fuzzy.MaxExpansions)))
+		*deps = append(*deps, fieldDep(fuzzy.Field))
+	} else if q.Substring != nil {
+		sb.WriteString("s" + strconv.Quote(q.Substring.Field) + strconv.Quote(q.Substring.Text))
+		*deps = append(*deps, substringDep(q.Substring.Field))
+	}
+	writeCanonicalChildren(sb, "must", q.Must, deps)
+	writeCanonicalChildren(sb, "should", q.Should, deps)
+	writeCanonicalChildren(sb, "not", q.MustNot, deps)
+	if q.MinimumShouldMatch != "" {
+		sb.WriteString("msm" + strconv.Quote(q.MinimumShouldMatch))
+	}
+}
+
+func writeCanonicalChildren(sb *strings.Builder, name string, children []*types.TermQuery, deps *[]string) {
+	if len(children) == 0 {
+		return
+	}
+	encoded := make([]string, 0, len(children))
+	for _, child := range children {
+		var childSb strings.Builder
+		writeCanonicalQuery(&childSb, child, deps)
+		encoded = append(encoded, childSb.String())
+	}
+	slices.Sort(encoded)
+	sb.WriteString(name + "(" + strings.Join(encoded, ";") + ")")
+}
@@ -4,10 +4,55 @@ import (
 	"RADIC/types"
 )
 
+// 倒排索引的几种实现
+const (
+	SKIPLIST = iota // 跳表，实现简单，适合中小规模的索引
+	BITMAP          // 压缩位图，内存占用小，长倒排链的交并差快得多
+)
+
 // 统一接口，方便倒排索引的数据结构重构
 
 type IReverseIndexer interface {
 	Add(doc types.Document)
-	Delete(IntId uint64, keyword *types.Keyword)
-	Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64) []string
+	Delete(IntId uint64, keyword *types.Keyword)                                                                                                                             // 只删keyword上的倒排，文档的统计信息和数值属性不变
+	DeleteDoc(IntId uint64, keywords []*types.Keyword)                                                                                                                       // 删除整篇文档：每个keyword上的倒排，以及统计信息和数值属性。没有keyword的文档也会删干净
+	Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, facets *types.FacetRequest, profile bool, page Page) Hits     // 不打分，按IntId升序分页
+	SearchTopK(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, facets *types.FacetRequest, profile bool, page Page) Hits // 按相关性从高到低分页
+	Explain(intId uint64, query *types.TermQuery, scored bool) *types.QueryExplanation                                                                                       // 解释一篇文档为什么命中或没命中query
+	CacheStats() CacheStats                                                                                                                                                  // 结果缓存的命中率等统计
+	TopTerms(field string, prefix string, k int, score func(word string, docFreq int) float64) []TermStat                                                                    // 补全：field下以prefix开头的词，按score(为nil时按文档频率)取前k个
+	SimilarTerms(field string, word string, maxEdits int, k int) []TermStat                                                                                                  // 拼写纠错：field下与word编辑距离不超过maxEdits的其他词，按文档频率取前k个
+	DocFreq(key string) int                                                                                                                                                  // 包含key的文档数
+}
+
+// GetReverseIndex 按类型创建倒排索引，DocNumEstimate是预估的doc数量
+func GetReverseIndex(indexType int, DocNumEstimate int) IReverseIndexer {
+	switch indexType {
+	case BITMAP:
+		return NewBitmapReverseIndex(DocNumEstimate)
+	default:
+		return NewSkipListReverseIndex(DocNumEstimate)
+	}
+}
+
+// FilterByBits 倒排索引的特征过滤，各种倒排索引的实现共用
+func FilterByBits(bit uint64, onFlag uint64, offFlag uint64, orFlags []uint64) bool {
+	// bit: 文档自身的属性	需要对应的条件写入xxFlag中，不同的Flag对应不同的要求
+
+	// onFlag:所有bit必须全部命中
+	if bit&onFlag != onFlag {
+		return false
+	}
+	// offFlag:所有bit必须全部不命中
+	if bit&offFlag != 0 {
+		return false
+	}
+	// 多个orFlags必须全部命中
+	for _, orFlag := range orFlags {
+		if orFlag > 0 && bit&orFlag == 0 {
+			// 单个orFlag只有一个bit命中即可
+			return false
+		}
+	}
+	return true
 }
@@ -5,20 +5,30 @@ import (
 	"RADIC/util"
 	"github.com/huandu/skiplist"
 	farmhash "github.com/leemcloughlin/gofarmhash"
+	"log/slog"
 	"runtime"
+	"slices"
 	"sync"
 )
 
 // SkipListReverseIndex 倒排索引整体上是map， map的value是一个List
 type SkipListReverseIndex struct {
-	table *util.ConcurrentHashMap // 分段map，并发安全
-	locks []sync.RWMutex          // 修改倒排索引时，相同的key需要去竞争同一把锁
+	table  *util.ConcurrentHashMap          // 分段map，并发安全
+	locks  []sync.RWMutex                   // 修改倒排索引时，相同的key需要去竞争同一把锁
+	stats  *CollectionStats                 // 集合统计信息，BM25打分时使用
+	values *DocValues                       // 数值属性，范围过滤时使用
+	dict   *TermDict                        // 有序词典，前缀、通配符查询时枚举词
+	cache  *ResultCache[*skiplist.SkipList] // 查询 -> 过滤后的命中文档
 }
 
 // SkipListValue 将Id和BitsFeature封装到一起，因为在跳表中key对应的是document的IntId，value是业务侧的Id和BitsFeature
 type SkipListValue struct {
 	Id          string
 	BitsFeature uint64
+	TermFreq    int32   // 该keyword在文档中出现的次数
+	DocLength   int32   // 文档的长度，即文档keyword的总数(不算n-gram)
+	Positions   []int32 // 该keyword在文档中出现的位置(升序)，建索引时没提供位置则为空
+	Weight      float32 // 建索引时keyword的权重，默认为1，出现多次时取最大的
 }
 
 // NewSkipListReverseIndex 初始化倒排索引，DocNumEstimate是预估的doc数量
@@ -26,16 +36,41 @@ func NewSkipListReverseIndex(DocNumEstimate int) *SkipListReverseIndex {
 	indexer := new(SkipListReverseIndex)
 	indexer.table = util.NewConcurrentHashMap(runtime.NumCPU(), DocNumEstimate)
 	indexer.locks = make([]sync.RWMutex, 1000)
+	indexer.stats = NewCollectionStats()
+	indexer.values = NewDocValues()
+	indexer.dict = NewTermDict()
+	indexer.cache = NewResultCache[*skiplist.SkipList](DEFAULT_CACHE_ENTRIES, DEFAULT_CACHE_DOCS)
 	return indexer
 }
 
 // Add 将文档增加到倒排索引中
 func (indexer *SkipListReverseIndex) Add(doc types.Document) {
+	// 同一个keyword可能在文档中出现多次，先统计词频和位置，每个key只写一次跳表
+	termFreq := make(map[string]int32, len(doc.Keywords))
+	positions := make(map[string][]int32, len(doc.Keywords))
+	weights := make(map[string]float64, len(doc.Keywords))
 	for _, keyword := range doc.Keywords {
-		key := keyword.ToString()
+		if key := keyword.ToString(); key != "" {
+			if len(keyword.Positions) > 0 {
+				termFreq[key] += int32(len(keyword.Positions)) // 带位置的keyword，每个位置算出现一次
+				positions[key] = append(positions[key], keyword.Positions...)
+			} else {
+				termFreq[key]++
+			}
+			weights[key] = max(weights[key], keyword.EffectiveWeight())
+		}
+	}
+	docLength := DocLength(doc.Keywords)
+	indexer.stats.AddDoc(doc.IntId, docLength)
+	indexer.values.Add(doc)
+
+	for key, tf := range termFreq {
+		pos := positions[key]
+		slices.Sort(pos)
+		pos = slices.Compact(pos)
 		lock := indexer.getLock(key)
 		lock.Lock()
-		sklValue := SkipListValue{doc.Id, doc.BitsFeature}
+		sklValue := SkipListValue{doc.Id, doc.BitsFeature, tf, docLength, pos, float32(weights[key])}
 		if value, exists := indexer.table.Get(key); exists {
 			list := value.(*skiplist.SkipList)
 			list.Set(doc.IntId, sklValue)
@@ -44,8 +79,15 @@ func (indexer *SkipListReverseIndex) Add(doc types.Document) {
 			list.Set(doc.IntId, sklValue)
 			indexer.table.Set(key, list)
 		}
+		indexer.dict.Add(key) // key可能是之前被删空过的，每次都要确保在词典里
 		lock.Unlock()
 	}
+	// 写完再淘汰缓存：之前缓存的旧结果被删掉，与这次写入有重叠的检索结果不会被放进缓存
+	keys := make([]string, 0, len(termFreq))
+	for key := range termFreq {
+		keys = append(keys, key)
+	}
+	indexer.cache.Invalidate(keys...)
 }
 
 // Delete 根据IntId删除key上的对应的doc
@@ -56,8 +98,74 @@ func (indexer *SkipListReverseIndex) Delete(IntId uint64, keyword *types.Keyword
 	if value, exists := indexer.table.Get(key); exists {
 		list := value.(*skiplist.SkipList)
 		list.Remove(IntId)
+		if list.Len() == 0 {
+			indexer.dict.Remove(key) // 倒排链删空了，词典里也不应该再能枚举到它
+		}
 	}
 	lock.Unlock()
+	indexer.cache.Invalidate(key)
+}
+
+// DeleteDoc 删除整篇文档。统计信息和数值属性在Add时总会写入，不能依赖逐个keyword的Delete来扣除
+func (indexer *SkipListReverseIndex) DeleteDoc(IntId uint64, keywords []*types.Keyword) {
+	for _, keyword := range keywords {
+		indexer.Delete(IntId, keyword)
+	}
+	indexer.stats.RemoveDoc(IntId)
+	indexer.values.Remove(IntId)
+}
+
+// Explain 解释IntId对应的文档为什么命中或没命中query，scored为true时给出每个节点的得分
+func (indexer SkipListReverseIndex) Explain(intId uint64, query *types.TermQuery, scored bool) *types.QueryExplanation {
+	e := &explainer{
+		intId:        intId,
+		values:       indexer.values,
+		docFreq:      indexer.DocFreq,
+		dict:         indexer.dict,
+		docCount:     indexer.stats.DocCount(),
+		avgDocLength: indexer.stats.AvgDocLength(),
+		lookup: func(key string) (termInfo, bool) {
+			value, exists := indexer.table.Get(key)
+			if !exists {
+				return termInfo{}, false
+			}
+			elem := value.(*skiplist.SkipList).Get(intId)
+			if elem == nil {
+				return termInfo{}, false
+			}
+			skv, _ := elem.Value.(SkipListValue)
+			return termInfo{termFreq: skv.TermFreq, docLength: skv.DocLength, positions: skv.Positions, weight: float64(skv.Weight)}, true
+		},
+	}
+	return e.explain(query, scored, 1)
+}
+
+// SetCacheSize 调整结果缓存的容量，任一参数<=0时不缓存。需在写入文档之前调用
+func (indexer *SkipListReverseIndex) SetCacheSize(maxEntries int, maxDocs int) {
+	indexer.cache = NewResultCache[*skiplist.SkipList](maxEntries, maxDocs)
+}
+
+// CacheStats 结果缓存的统计
+func (indexer SkipListReverseIndex) CacheStats() CacheStats {
+	return indexer.cache.Stats()
+}
+
+// TopTerms 按score从高到低返回field下以prefix开头的前k个词，score为nil时按文档频率
+func (indexer SkipListReverseIndex) TopTerms(field string, prefix string, k int, score func(word string, docFreq int) float64) []TermStat {
+	return topTerms(indexer.dict, indexer.DocFreq, field, prefix, k, score)
+}
+
+// SimilarTerms 拼写纠错的候选词，按文档频率从高到低取前k个
+func (indexer SkipListReverseIndex) SimilarTerms(field string, word string, maxEdits int, k int) []TermStat {
+	return similarTerms(indexer.dict, indexer.DocFreq, field, word, maxEdits, k)
+}
+
+// DocFreq 包含key的文档数，即key对应跳表的长度
+func (indexer SkipListReverseIndex) DocFreq(key string) int {
+	if value, exists := indexer.table.Get(key); exists {
+		return value.(*skiplist.SkipList).Len()
+	}
+	return 0
 }
 
 // getLock 通过哈希方式，将key分成多组，每组key争夺一个lock去写，相当于每个key都有一把锁，但是没办法开辟那么多锁，因为不知道会有多少个key
@@ -66,72 +174,49 @@ func (indexer *SkipListReverseIndex) getLock(key string) *sync.RWMutex {
 	return &indexer.locks[n%len(indexer.locks)]
 }
 
-// IntersectionOfSkipList 求多个跳表的交集 利用了跳表有序的特性，使用多路归并（Multi-way Merge）的思想。
+// IntersectionOfSkipList 求多个跳表的交集，逐个遍历最短的跳表，在其他跳表上用FindNext跳跃查找(leapfrog)。
+// 每个跳表记住上次查找到的位置，下一次从这个位置往后找，长跳表上跳过的部分不需要一个个访问。结果的value取自最短的跳表
 func IntersectionOfSkipList(lists ...*skiplist.SkipList) *skiplist.SkipList {
-
-	// 初始化检查
 	if len(lists) == 0 {
 		return nil
 	}
 	if len(lists) == 1 {
 		return lists[0]
 	}
-
-	result := skiplist.New(skiplist.Uint64)        // 新建一个结果跳表
-	iters := make([]*skiplist.Element, len(lists)) // 指向每一个跳表的单个元素的指针 切片
-
-	// 遍历每一个跳表，如果有空的，则直接return，同时给iters初始化到每个跳表的首元素地址
-	for i, list := range lists {
+	for _, list := range lists {
 		if list == nil || list.Len() == 0 {
-			// 只要有一个跳表是空的，则结果交集为空
-			return nil
+			return nil // 只要有一个跳表是空的，交集就为空
 		}
-		iters[i] = list.Front()
 	}
 
-	for {
-		maxList := make(map[int]struct{}, len(iters)) // 存放每个跳表的当前最大值的key
-		var maxValue uint64 = 0                       // 当前最大值
-		// 以上的变量：每一轮都会重新初始化
+	sorted := slices.Clone(lists)
+	slices.SortStableFunc(sorted, func(a, b *skiplist.SkipList) int { return a.Len() - b.Len() })
+	base, others := sorted[0], sorted[1:]
+	iters := make([]*skiplist.Element, len(others)) // 每个长跳表上次查找到的位置，nil表示从头找
 
-		// 遍历每个跳表的当前访问元素
-		for i, node := range iters {
-			if node.Key().(uint64) > maxValue {
-				// 如果当前跳表的key值 > 当前最大值，更新最大值并用maxList存储元素key
-				maxValue = node.Key().(uint64)
-				maxList = map[int]struct{}{i: {}}
-			} else if node.Key().(uint64) == maxValue {
-				// 如果当前跳表的key值 == 当前最大值，填充当前跳表对应的maxList
-				maxList[i] = struct{}{}
+	result := skiplist.New(skiplist.Uint64)
+	node := base.Front()
+	for node != nil {
+		key := node.Key().(uint64)
+		next := key // 所有跳表上不小于key的最小值
+		for i, list := range others {
+			iters[i] = list.FindNext(iters[i], key)
+			if iters[i] == nil {
+				return result // 有一个跳表走完了，后面不会再有交集
 			}
-		}
-		// 循环结束后，如果当前指向的都是相等的最大值，则maxList满，说明找到了相交元素
-		// 如果maxList不满，说明当前iters切片中指向的各个跳表中的元素还有比maxValue小的
-
-		if len(maxList) == len(iters) {
-			result.Set(iters[0].Key(), iters[0].Value) // 找到相交元素，将kv存入result
-
-			// 让各指针都next
-			for i, node := range iters {
-				iters[i] = node.Next()
-				if iters[i] == nil {
-					return result // 只要有一个跳表走完，说明后面就不会再有交集了
-				}
+			if k := iters[i].Key().(uint64); k > key {
+				next = k
+				break
 			}
+		}
+		if next == key {
+			result.Set(key, node.Value)
+			node = node.Next()
 		} else {
-			// 如果不满足相交条件，应让小于maxValue的跳表指针向后next
-			for i := range iters {
-				for iters[i] != nil && iters[i].Key().(uint64) < maxValue {
-					// 让不等于max的往后next，直到相等或大于
-					iters[i] = iters[i].Next()
-				}
-				if iters[i] == nil {
-					return result // 只要有一个跳表走完，说明后面就不会再有交集了
-				}
-			}
+			node = base.FindNext(node, next) // 最短的跳表也跳到next
 		}
-
 	}
+	return result
 }
 
 // UnionOfSkipList 求多个跳表的并集
@@ -202,76 +287,327 @@ func UnionOfSkipList(lists ...*skiplist.SkipList) *skiplist.SkipList {
 	}
 }
 
-// FilterByBits 倒排索引的特征过滤
-func (indexer SkipListReverseIndex) FilterByBits(bit uint64, onFlag uint64, offFlag uint64, orFlags []uint64) bool {
-	// bit: 文档自身的属性	需要对应的条件写入xxFlag中，不同的Flag对应不同的要求
+// MinMatchOfSkipList 求至少出现在minMatch个跳表中的元素，minMatch<=1时等价于求并集
+// 逻辑：计数的多路归并。与UnionOfSkipList一样每一轮找出最小的Key，同时数一下有几个跳表的指针停在这个Key上，个数够了才加入结果集。
+// 还没走完的跳表少于minMatch个时，后面的元素不可能再满足条件，直接结束
+func MinMatchOfSkipList(minMatch int, lists ...*skiplist.SkipList) *skiplist.SkipList {
+	if minMatch <= 1 {
+		return UnionOfSkipList(lists...)
+	}
+	if len(lists) < minMatch {
+		return nil
+	}
 
-	// onFlag:所有bit必须全部命中
-	if bit&onFlag != onFlag {
-		return false
+	result := skiplist.New(skiplist.Uint64)
+	iters := make([]*skiplist.Element, 0, len(lists))
+	for _, list := range lists {
+		if list != nil && list.Len() > 0 {
+			iters = append(iters, list.Front()) // 空跳表不可能贡献计数，直接丢掉
+		}
 	}
-	// offFlag:所有bit必须全部不命中
-	if bit&offFlag != 0 {
-		return false
+
+	for {
+		alive := 0 // 还没走完的跳表个数
+		var minValue uint64 = 0
+		for _, node := range iters {
+			if node != nil {
+				if val := node.Key().(uint64); alive == 0 || val < minValue {
+					minValue = val
+				}
+				alive++
+			}
+		}
+		if alive < minMatch {
+			return result
+		}
+
+		count := 0
+		var targetValue interface{}
+		for i, node := range iters {
+			if node != nil && node.Key().(uint64) == minValue {
+				if count == 0 {
+					targetValue = node.Value
+				}
+				count++
+				iters[i] = node.Next()
+			}
+		}
+		if count >= minMatch {
+			result.Set(minValue, targetValue)
+		}
+	}
+}
+
+// DifferenceOfSkipList 求差集：base中去掉出现在任意一个excludes跳表中的元素
+// 逻辑：base与每个excludes都是有序的，各维护一个指针，随着base向后走，把excludes的指针推进到不小于当前key的位置即可判断是否命中
+func DifferenceOfSkipList(base *skiplist.SkipList, excludes ...*skiplist.SkipList) *skiplist.SkipList {
+	if base == nil || base.Len() == 0 {
+		return nil
+	}
+	if len(excludes) == 0 {
+		return base
+	}
+
+	result := skiplist.New(skiplist.Uint64)
+	iters := make([]*skiplist.Element, len(excludes))
+	for i, list := range excludes {
+		if list != nil && list.Len() > 0 {
+			iters[i] = list.Front()
+		}
 	}
-	// 多个orFlags必须全部命中
-	for _, orFlag := range orFlags {
-		if orFlag > 0 && bit&orFlag == 0 {
-			// 单个orFlag只有一个bit命中即可
-			return false
+
+	for node := base.Front(); node != nil; node = node.Next() {
+		key := node.Key().(uint64)
+		excluded := false
+		for i := range iters {
+			for iters[i] != nil && iters[i].Key().(uint64) < key {
+				iters[i] = iters[i].Next()
+			}
+			if iters[i] != nil && iters[i].Key().(uint64) == key {
+				excluded = true
+			}
+		}
+		if !excluded {
+			result.Set(key, node.Value)
 		}
 	}
-	return true
+	return result
 }
 
-func (indexer SkipListReverseIndex) search(q *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64) *skiplist.SkipList {
-	if q.Keyword != "" {
-		keyword := q.Keyword
+// FilterByBits 倒排索引的特征过滤
+func (indexer SkipListReverseIndex) FilterByBits(bit uint64, onFlag uint64, offFlag uint64, orFlags []uint64) bool {
+	return FilterByBits(bit, onFlag, offFlag, orFlags)
+}
+
+// search prof不为nil时记录每个节点的耗时、倒排链长度和结果数
+func (indexer SkipListReverseIndex) search(q *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, prof *queryProfiler) *skiplist.SkipList {
+	var result *skiplist.SkipList
+	if keyword := q.Key(); keyword != "" {
 		if value, exists := indexer.table.Get(keyword); exists {
-			result := skiplist.New(skiplist.Uint64)
+			result = skiplist.New(skiplist.Uint64)
 			list := value.(*skiplist.SkipList)
+			prof.posting(list.Len())
 			node := list.Front()
 			for node != nil {
 				intId := node.Key().(uint64)
 				skv, _ := node.Value.(SkipListValue)
 				flag := skv.BitsFeature
-				if intId > 0 && indexer.FilterByBits(flag, onFlag, offFlag, orFlags) {
+				start := prof.filterStart()
+				pass := intId > 0 && indexer.FilterByBits(flag, onFlag, offFlag, orFlags) && indexer.values.Match(intId, ranges)
+				prof.filterDone(start)
+				if pass {
 					result.Set(intId, skv)
 				}
 				node = node.Next()
 			}
-			return result
 		}
+	} else if q.Phrase != nil {
+		result = indexer.searchPhrase(q.Phrase, onFlag, offFlag, orFlags, ranges, prof)
+	} else if q.Prefix != nil {
+		keys := indexer.dict.PrefixTerms(q.Prefix.Field, q.Prefix.Prefix, int(q.Prefix.MaxExpansions))
+		result = indexer.searchTerms(keys, onFlag, offFlag, orFlags, ranges, prof)
+	} else if q.Wildcard != nil {
+		keys := indexer.dict.WildcardTerms(q.Wildcard.Field, q.Wildcard.Pattern, int(q.Wildcard.MaxExpansions))
+		result = indexer.searchTerms(keys, onFlag, offFlag, orFlags, ranges, prof)
+	} else if q.Fuzzy != nil {
+		fuzzy := q.Fuzzy
+		keys := indexer.dict.FuzzyTerms(fuzzy.Field, fuzzy.Word, int(fuzzy.MaxEdits), int(fuzzy.PrefixLength), int(fuzzy.MaxExpansions))
+		result = indexer.searchTerms(keys, onFlag, offFlag, orFlags, ranges, prof)
+	} else if q.Substring != nil {
+		result = indexer.searchSubstring(q.Substring, onFlag, offFlag, orFlags, ranges, prof)
 	} else if len(q.Must) > 0 {
-		results := make([]*skiplist.SkipList, 0, len(q.Must))
-		for _, q := range q.Must {
-			results = append(results, indexer.search(q, onFlag, offFlag, orFlags))
-		}
-		return IntersectionOfSkipList(results...)
+		result = indexer.searchMust(q.Must, onFlag, offFlag, orFlags, ranges, prof)
 	} else if len(q.Should) > 0 {
+		minMatch, err := q.MinShouldMatch()
+		if err != nil {
+			slog.Warn("invalid query", slog.String("error", err.Error()))
+			prof.done(0)
+			return nil
+		}
 		results := make([]*skiplist.SkipList, 0, len(q.Should))
 		for _, q := range q.Should {
-			results = append(results, indexer.search(q, onFlag, offFlag, orFlags))
+			results = append(results, indexer.search(q, onFlag, offFlag, orFlags, ranges, prof.should(q)))
+		}
+		result = MinMatchOfSkipList(minMatch, results...)
+	}
+
+	// 排除MustNot命中的文档。被排除的文档已经在result里通过了特征过滤和范围过滤，所以MustNot不需要再过滤
+	if len(q.MustNot) > 0 && result != nil && result.Len() > 0 {
+		excludes := make([]*skiplist.SkipList, 0, len(q.MustNot))
+		for _, q := range q.MustNot {
+			excludes = append(excludes, indexer.search(q, 0, 0, nil, nil, prof.mustNot(q)))
 		}
-		return UnionOfSkipList(results...)
+		result = DifferenceOfSkipList(result, excludes...)
 	}
 
-	return nil
+	if result != nil {
+		prof.done(result.Len())
+	} else {
+		prof.done(0)
+	}
+	return result
 }
 
-// Search 搜索，返回docId
-func (indexer SkipListReverseIndex) Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64) []string {
-	result := indexer.search(query, onFlag, offFlag, orFlags)
-	if result == nil {
+// searchMust Must子查询求交集。按预估的倒排链长度从短到长求值，交集只会越来越小，
+// 后面的子查询只需要在长倒排链上跳跃查找已有的结果；有一个子查询为空就不再求后面的
+func (indexer SkipListReverseIndex) searchMust(children []*types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, prof *queryProfiler) *skiplist.SkipList {
+	var result *skiplist.SkipList
+	for i, q := range planMust(children, indexer.DocFreq) {
+		child := prof.must(q)
+		if i == 0 {
+			result = indexer.search(q, onFlag, offFlag, orFlags, ranges, child)
+		} else if key := q.Key(); key != "" && len(q.MustNot) == 0 {
+			// result里的文档已经通过了过滤，叶子节点不用把整条倒排链过滤、复制一遍，直接在原始的倒排链上查找。
+			// 带MustNot的叶子节点还要排除文档，走search
+			value, exists := indexer.table.Get(key)
+			if !exists {
+				child.done(0)
+				return nil
+			}
+			list := value.(*skiplist.SkipList)
+			child.posting(list.Len())
+			result = IntersectionOfSkipList(result, list)
+			child.done(result.Len()) // 这种叶子节点的结果数是与之前结果的交集
+		} else {
+			result = IntersectionOfSkipList(result, indexer.search(q, onFlag, offFlag, orFlags, ranges, child))
+		}
+		if result == nil || result.Len() == 0 {
+			return nil
+		}
+	}
+	return result
+}
+
+// searchTerms 多个词的倒排链求并集，用于前缀、通配符、模糊等展开成多个词的查询
+func (indexer SkipListReverseIndex) searchTerms(keys []string, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, prof *queryProfiler) *skiplist.SkipList {
+	if len(keys) == 0 {
 		return nil
 	}
+	results := make([]*skiplist.SkipList, 0, len(keys))
+	for _, key := range keys {
+		if prof != nil {
+			if value, exists := indexer.table.Get(key); exists {
+				prof.posting(value.(*skiplist.SkipList).Len())
+			}
+		}
+		results = append(results, indexer.search(&types.TermQuery{Keyword: key}, onFlag, offFlag, orFlags, ranges, prof.detached()))
+	}
+	return UnionOfSkipList(results...)
+}
+
+// match 求通过特征过滤和范围过滤的命中文档，热门查询直接取缓存。返回的跳表不能修改。
+// sp不为nil时要记录真实的求值开销，不读也不写缓存
+func (indexer SkipListReverseIndex) match(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, sp *searchProfiler) *skiplist.SkipList {
+	if sp != nil {
+		qp := newQueryProfiler(query, true)
+		result := indexer.search(query, onFlag, offFlag, orFlags, ranges, qp)
+		sp.matched(qp, int(qp.profile().ResultCount))
+		return result
+	}
+	key, deps := queryCacheKey(query, onFlag, offFlag, orFlags, ranges)
+	result, epoch, ok := indexer.cache.Get(key)
+	if ok {
+		return result
+	}
+	result = indexer.search(query, onFlag, offFlag, orFlags, ranges, nil)
+	size := 0
+	if result != nil {
+		size = result.Len()
+	}
+	indexer.cache.Put(key, deps, result, size, epoch)
+	return result
+}
+
+// Search 搜索，不打分，按page.SortBy(为空时按IntId升序)返回一页docId
+func (indexer SkipListReverseIndex) Search(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, facets *types.FacetRequest, profile bool, page Page) Hits {
+	sp := newSearchProfiler(profile)
+	result := indexer.match(query, onFlag, offFlag, orFlags, ranges, sp)
+	counter := newFacetCounter(facets, indexer.values)
+	if result == nil || result.Len() == 0 {
+		return Hits{Facets: counter.result(), Profile: sp.done()}
+	}
+
+	order := newOrder(page.SortBy, false, indexer.values)
+	if !order.byIntId() {
+		heap := newPageHeap(page, order, false, result.Len())
+		for node := result.Front(); node != nil; node = node.Next() {
+			skv, _ := node.Value.(SkipListValue)
+			intId := node.Key().(uint64)
+			heap.push(ScoredDoc{Id: skv.Id, IntId: intId})
+			counter.add(intId, skv.BitsFeature)
+		}
+		hits := heap.hits()
+		hits.Facets = counter.result()
+		hits.Profile = sp.done()
+		return hits
+	}
+
+	// 聚合要统计所有命中的文档，不能只走一页
+	if counter != nil {
+		for node := result.Front(); node != nil; node = node.Next() {
+			skv, _ := node.Value.(SkipListValue)
+			counter.add(node.Key().(uint64), skv.BitsFeature)
+		}
+	}
 
-	arr := make([]string, 0, result.Len())
+	collector := newPageCollector(page, order, false)
+	node := result.Front()
+	if page.After != nil {
+		node = result.Find(page.After.IntId + 1) // 跳表按IntId有序，直接定位到游标之后，不用从头走
+	}
+	for ; node != nil; node = node.Next() {
+		skv, _ := node.Value.(SkipListValue)
+		if !collector.add(ScoredDoc{Id: skv.Id, IntId: node.Key().(uint64)}) {
+			break // 凑够一页就不再往后走
+		}
+	}
+	collector.hits.Total = result.Len()
+	collector.hits.Facets = counter.result()
+	collector.hits.Profile = sp.done()
+	return collector.hits
+}
+
+// SearchTopK 搜索，按BM25得分从高到低(或按page.SortBy)返回一页文档。只需要维护前Offset+Limit+1个文档的堆，多出的1个用来判断有没有下一页
+func (indexer SkipListReverseIndex) SearchTopK(query *types.TermQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, facets *types.FacetRequest, profile bool, page Page) Hits {
+	sp := newSearchProfiler(profile)
+	result := indexer.match(query, onFlag, offFlag, orFlags, ranges, sp)
+	counter := newFacetCounter(facets, indexer.values)
+	if result == nil || result.Len() == 0 {
+		return Hits{Facets: counter.result(), Profile: sp.done()}
+	}
+
+	// 查询里每个keyword对应的跳表和idf只需要取一次
+	docCount := indexer.stats.DocCount()
+	avgDocLength := indexer.stats.AvgDocLength()
+	terms := make([]*skiplist.SkipList, 0, 4)
+	idfs := make([]float64, 0, 4) // 乘上了查询里的boost
+	for _, term := range scoringTerms(query, indexer.values, indexer.dict) {
+		if value, exists := indexer.table.Get(term.Key); exists {
+			list := value.(*skiplist.SkipList)
+			terms = append(terms, list)
+			idfs = append(idfs, IDF(list.Len(), docCount)*term.Boost)
+		}
+	}
+
+	heap := newPageHeap(page, newOrder(page.SortBy, true, indexer.values), true, result.Len())
 	node := result.Front()
 	for node != nil {
+		intId := node.Key().(uint64)
 		skv, _ := node.Value.(SkipListValue)
-		arr = append(arr, skv.Id)
+		var score float64
+		for i, list := range terms {
+			// 文档不一定命中查询里的每个keyword(比如Should)，没命中的不贡献得分
+			if elem := list.Get(intId); elem != nil {
+				tv, _ := elem.Value.(SkipListValue)
+				score += idfs[i] * BM25TermWeight(tv.TermFreq, tv.DocLength, avgDocLength) * float64(tv.Weight)
+			}
+		}
+		heap.push(ScoredDoc{Id: skv.Id, IntId: intId, Score: score})
+		counter.add(intId, skv.BitsFeature)
 		node = node.Next()
 	}
-	return arr
+	hits := heap.hits()
+	hits.Facets = counter.result()
+	hits.Profile = sp.done()
+	return hits
 }
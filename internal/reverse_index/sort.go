@@ -0,0 +1,147 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"cmp"
+)
+
+// SortValue 文档在一个排序字段上的值，即排序键的一部分
+type SortValue struct {
+	Missing bool // 文档没有这个属性
+	IsFloat bool // 浮点属性或得分，值在Float里；否则是整数属性，值在Int里
+	Int     int64
+	Float   float64
+}
+
+type sortKind int
+
+const (
+	sortByInt sortKind = iota
+	sortByFloat
+	sortByScore
+)
+
+// order 检索结果的排序方式：依次比较每个SortBy，全部相同时按IntId升序，所以任意两篇文档都能分出先后，游标翻页才不会漏、不会重
+type order struct {
+	sortBy []*types.SortBy
+	kinds  []sortKind
+	fields bool // 是否有按属性排序的条件。只按得分排序时直接比较ScoredDoc.Score，不需要为每个文档分配排序键
+	values *DocValues
+}
+
+// newOrder sortBy为空时，scored为true按得分降序，否则按IntId升序
+func newOrder(sortBy []*types.SortBy, scored bool, values *DocValues) *order {
+	if len(sortBy) == 0 && scored {
+		sortBy = []*types.SortBy{{Field: types.SORT_BY_SCORE, Desc: true}}
+	}
+	o := &order{sortBy: sortBy, kinds: make([]sortKind, len(sortBy)), values: values}
+	for i, s := range sortBy {
+		if s.Field == types.SORT_BY_SCORE {
+			o.kinds[i] = sortByScore
+			continue
+		}
+		o.fields = true
+		if values.isFloat(s.Field) {
+			o.kinds[i] = sortByFloat
+		}
+	}
+	return o
+}
+
+// byIntId 只按IntId排序，即倒排链本身的顺序，不需要堆
+func (o *order) byIntId() bool {
+	return len(o.sortBy) == 0
+}
+
+// fill 填上文档在各个属性上的排序键，得分的位置留空，比较时用doc.Score
+func (o *order) fill(doc *ScoredDoc) {
+	if !o.fields {
+		return
+	}
+	doc.Sort = make([]SortValue, len(o.sortBy))
+	o.values.mu.RLock()
+	defer o.values.mu.RUnlock()
+	for i, s := range o.sortBy {
+		switch o.kinds[i] {
+		case sortByScore:
+		case sortByFloat:
+			v, ok := o.values.floats[s.Field].get(doc.IntId)
+			doc.Sort[i] = SortValue{Missing: !ok, IsFloat: true, Float: v}
+		default:
+			var v int64
+			ok := false
+			if col, exists := o.values.ints[s.Field]; exists {
+				v, ok = col.get(doc.IntId)
+			}
+			doc.Sort[i] = SortValue{Missing: !ok, Int: v}
+		}
+	}
+}
+
+// before a是否排在b前面
+func (o *order) before(a, b ScoredDoc) bool {
+	for i, s := range o.sortBy {
+		if c := compareSortValue(o.valueAt(a, i), o.valueAt(b, i), s.Desc); c != 0 {
+			return c < 0
+		}
+	}
+	return a.IntId < b.IntId
+}
+
+// valueAt 文档排序键的第i个值，越界(比如游标与排序条件对不上)时当作没有值
+func (o *order) valueAt(doc ScoredDoc, i int) SortValue {
+	if o.kinds[i] == sortByScore {
+		return SortValue{IsFloat: true, Float: doc.Score}
+	}
+	if i < len(doc.Sort) {
+		return doc.Sort[i]
+	}
+	return SortValue{Missing: true}
+}
+
+// cursorOf 文档所在位置的游标，得分也要记到排序键里
+func (o *order) cursorOf(doc ScoredDoc, scored bool) *Cursor {
+	cursor := &Cursor{Scored: scored, IntId: doc.IntId}
+	if !o.byIntId() {
+		cursor.Sort = make([]SortValue, len(o.sortBy))
+		for i := range o.sortBy {
+			cursor.Sort[i] = o.valueAt(doc, i)
+		}
+	}
+	return cursor
+}
+
+// docOf 游标所在位置的文档，用于与其他文档比较先后
+func (o *order) docOf(c *Cursor) ScoredDoc {
+	doc := ScoredDoc{IntId: c.IntId, Sort: c.Sort}
+	for i, kind := range o.kinds {
+		if kind == sortByScore && i < len(c.Sort) {
+			doc.Score = c.Sort[i].Float
+		}
+	}
+	return doc
+}
+
+// compareSortValue 没有值的总是排在最后，与升序降序无关
+func compareSortValue(a, b SortValue, desc bool) int {
+	if a.Missing || b.Missing {
+		switch {
+		case a.Missing && b.Missing:
+			return 0
+		case a.Missing:
+			return 1
+		default:
+			return -1
+		}
+	}
+	var c int
+	if a.IsFloat {
+		c = cmp.Compare(a.Float, b.Float)
+	} else {
+		c = cmp.Compare(a.Int, b.Int)
+	}
+	if desc {
+		c = -c
+	}
+	return c
+}
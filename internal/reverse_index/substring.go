@@ -0,0 +1,60 @@
+package reverse_index
+
+import (
+	"RADIC/types"
+	"github.com/huandu/skiplist"
+	"slices"
+)
+
+// substringTerms 子串查询用到的gram(编码后的key)。子串不短于n时返回它的所有n-gram，文档要包含全部gram，再用原文校验；
+// 比n短时返回以它开头的gram(prefix为true)，最多MAX_EXPANSIONS个，包含其中任何一个就一定包含子串，不用校验。
+// field没有按n-gram建索引时返回空
+func substringTerms(sub *types.SubstringQuery, values *DocValues, dict *TermDict) (keys []string, prefix bool) {
+	n := values.NgramSize(sub.Field)
+	if n == 0 || sub.Text == "" {
+		return nil, false
+	}
+	field := types.NgramField(sub.Field, n)
+	runes := []rune(sub.Text)
+	if len(runes) < n {
+		return dict.PrefixTerms(field, sub.Text, MAX_EXPANSIONS), true
+	}
+	keys = make([]string, 0, len(runes)-n+1)
+	for i := 0; i+n <= len(runes); i++ {
+		if key := joinKey(field, string(runes[i:i+n])); !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, false
+}
+
+// searchSubstring 子串查询：先对子串的所有gram求交集，再用原文校验。gram只能保证子串的每一段都出现过，不能保证它们连在一起
+func (indexer SkipListReverseIndex) searchSubstring(sub *types.SubstringQuery, onFlag uint64, offFlag uint64, orFlags []uint64, ranges *types.RangeFilter, prof *queryProfiler) *skiplist.SkipList {
+	keys, prefix := substringTerms(sub, indexer.values, indexer.dict)
+	if prefix {
+		return indexer.searchTerms(keys, onFlag, offFlag, orFlags, ranges, prof)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	musts := make([]*types.TermQuery, 0, len(keys))
+	for _, key := range keys {
+		value, exists := indexer.table.Get(key)
+		if !exists {
+			return nil // 有一个gram不存在，肯定不包含子串
+		}
+		prof.posting(value.(*skiplist.SkipList).Len())
+		musts = append(musts, &types.TermQuery{Keyword: key})
+	}
+	candidates := indexer.search(&types.TermQuery{Must: musts}, onFlag, offFlag, orFlags, ranges, prof.detached())
+	if candidates == nil || candidates.Len() == 0 {
+		return nil
+	}
+	result := skiplist.New(skiplist.Uint64)
+	for node := candidates.Front(); node != nil; node = node.Next() {
+		if intId := node.Key().(uint64); indexer.values.ContainsText(sub.Field, intId, sub.Text) {
+			result.Set(intId, node.Value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,208 @@
+package reverse_index
+
+import (
+	"RADIC/util"
+	"log/slog"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	DEFAULT_MAX_EXPANSIONS = 128  // 前缀、通配符查询没指定MaxExpansions时，最多展开的词数
+	MAX_EXPANSIONS         = 1024 // 一个模式最多展开的词数上限，防止一个查询展开出海量的词
+)
+
+// TermDict 有序词典：每个field一棵前缀树，存放该field下出现过的所有word，用于前缀、通配符等需要枚举词的查询
+// ConcurrentHashMap只能按完整的key查找，词典是它的补充
+type TermDict struct {
+	mu     sync.RWMutex
+	fields map[string]*util.Trie
+}
+
+func NewTermDict() *TermDict {
+	return &TermDict{fields: make(map[string]*util.Trie, 8)}
+}
+
+// splitKey 把Keyword.ToString编码的key拆回field和word
+func splitKey(key string) (field string, word string) {
+	if field, word, found := strings.Cut(key, "\001"); found {
+		return field, word
+	}
+	return "", key
+}
+
+// joinKey 与Keyword.ToString的编码一致
+func joinKey(field string, word string) string {
+	return field + "\001" + word
+}
+
+// Add 把key加入词典
+func (dict *TermDict) Add(key string) {
+	field, word := splitKey(key)
+	dict.mu.Lock()
+	defer dict.mu.Unlock()
+	trie, exists := dict.fields[field]
+	if !exists {
+		trie = util.NewTrie()
+		dict.fields[field] = trie
+	}
+	trie.Insert(word)
+}
+
+// Remove 把key从词典中删除，key对应的倒排链为空时调用
+func (dict *TermDict) Remove(key string) {
+	field, word := splitKey(key)
+	dict.mu.Lock()
+	defer dict.mu.Unlock()
+	if trie, exists := dict.fields[field]; exists {
+		trie.Delete(word)
+		if trie.Len() == 0 {
+			delete(dict.fields, field)
+		}
+	}
+}
+
+// WalkPrefix 按字典序遍历field下以prefix开头的词，fn拿到的是编码后的key，返回false时停止
+func (dict *TermDict) WalkPrefix(field string, prefix string, fn func(key string) bool) {
+	dict.mu.RLock()
+	defer dict.mu.RUnlock()
+	if trie, exists := dict.fields[field]; exists {
+		trie.WalkPrefix(prefix, func(word string) bool {
+			return fn(joinKey(field, word))
+		})
+	}
+}
+
+// PrefixTerms 返回field下以prefix开头的词(编码后的key)，最多maxExpansions个
+func (dict *TermDict) PrefixTerms(field string, prefix string, maxExpansions int) []string {
+	limit := expansionLimit(maxExpansions)
+	keys := make([]string, 0, 16)
+	truncated := false
+	dict.WalkPrefix(field, prefix, func(key string) bool {
+		if len(keys) >= limit {
+			truncated = true
+			return false
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if truncated {
+		slog.Warn("prefix query expands too many terms, truncated",
+			slog.String("field", field), slog.String("prefix", prefix), slog.Int("limit", limit))
+	}
+	return keys
+}
+
+// WildcardTerms 返回field下匹配通配符pattern的词(编码后的key)，最多maxExpansions个
+func (dict *TermDict) WildcardTerms(field string, pattern string, maxExpansions int) []string {
+	limit := expansionLimit(maxExpansions)
+	keys := make([]string, 0, 16)
+	truncated := false
+	// 第一个通配符之前的部分是固定前缀，先用它缩小遍历范围
+	prefix := pattern
+	if i := strings.IndexAny(pattern, "*?"); i >= 0 {
+		prefix = pattern[:i]
+	}
+	dict.WalkPrefix(field, prefix, func(key string) bool {
+		_, word := splitKey(key)
+		if !MatchWildcard(pattern, word) {
+			return true
+		}
+		if len(keys) >= limit {
+			truncated = true
+			return false
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if truncated {
+		slog.Warn("wildcard query expands too many terms, truncated",
+			slog.String("field", field), slog.String("pattern", pattern), slog.Int("limit", limit))
+	}
+	return keys
+}
+
+// expansionLimit 没指定时用默认值，且不能超过上限
+func expansionLimit(maxExpansions int) int {
+	if maxExpansions <= 0 {
+		return DEFAULT_MAX_EXPANSIONS
+	}
+	return min(maxExpansions, MAX_EXPANSIONS)
+}
+
+// MatchWildcard 通配符匹配，*匹配任意个字符(包括0个)，?匹配恰好一个字符，按rune计
+// 贪心+回溯：遇到*时记下位置，后面失配时让这个*多吞一个字符再试
+func MatchWildcard(pattern string, word string) bool {
+	p, w := 0, 0
+	starP, starW := -1, -1
+	for w < len(word) {
+		if p < len(pattern) {
+			pr, pn := utf8.DecodeRuneInString(pattern[p:])
+			wr, wn := utf8.DecodeRuneInString(word[w:])
+			if pr == '*' {
+				starP, starW = p, w
+				p += pn
+				continue
+			}
+			if pr == '?' || pr == wr {
+				p += pn
+				w += wn
+				continue
+			}
+		}
+		if starP < 0 {
+			return false
+		}
+		// 回到上一个*，让它多匹配一个字符
+		_, wn := utf8.DecodeRuneInString(word[starW:])
+		starW += wn
+		p, w = starP+1, starW
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// MAX_SUGGEST_SCAN 补全时一个前缀最多遍历多少个词，前缀很短时不会把整个词典走一遍
+const MAX_SUGGEST_SCAN = 100000
+
+// TermStat 词典里的一个词、包含它的文档数和排序用的得分
+type TermStat struct {
+	Word     string
+	DocFreq  int
+	Score    float64
+	Distance int // 拼写纠错时与原词的编辑距离
+}
+
+// topTerms 按score从高到低返回field下以prefix开头的前k个词，得分相同时按字典序。score为nil时得分就是文档频率。
+// 先把词从词典里取出来再查文档频率，不在持有词典锁的时候去拿倒排索引的锁
+func topTerms(dict *TermDict, docFreq func(key string) int, field string, prefix string, k int, score func(word string, docFreq int) float64) []TermStat {
+	keys := make([]string, 0, 64)
+	dict.WalkPrefix(field, prefix, func(key string) bool {
+		keys = append(keys, key)
+		return len(keys) < MAX_SUGGEST_SCAN
+	})
+	top := NewTopK(k, func(a, b ScoredDoc) bool {
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Id < b.Id
+	})
+	for _, key := range keys {
+		df := docFreq(key)
+		s := float64(df)
+		if score != nil {
+			_, word := splitKey(key)
+			s = score(word, df)
+		}
+		top.Push(ScoredDoc{Id: key, Score: s})
+	}
+	result := make([]TermStat, 0, k)
+	for _, doc := range top.Sorted() {
+		_, word := splitKey(doc.Id)
+		result = append(result, TermStat{Word: word, DocFreq: docFreq(doc.Id), Score: doc.Score})
+	}
+	return result
+}
@@ -0,0 +1,961 @@
+package test
+
+import (
+	"RADIC/internal/reverse_index"
+	"RADIC/types"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/huandu/skiplist"
+)
+
+// reverseIndexer 测试用到的倒排索引方法，两种实现都要通过同样的测试
+type reverseIndexer interface {
+	reverse_index.IReverseIndexer
+	DocFreq(key string) int
+}
+
+func forEachIndexer(t *testing.T, test func(t *testing.T, indexer reverseIndexer)) {
+	t.Run("skiplist", func(t *testing.T) {
+		test(t, reverse_index.NewSkipListReverseIndex(100))
+	})
+	t.Run("bitmap", func(t *testing.T) {
+		test(t, reverse_index.NewBitmapReverseIndex(100))
+	})
+}
+
+func newDoc(intId uint64, id string, words ...string) types.Document {
+	doc := types.Document{Id: id, IntId: intId}
+	for _, word := range words {
+		doc.Keywords = append(doc.Keywords, &types.Keyword{Field: "content", Word: word})
+	}
+	return doc
+}
+
+// ids 一页结果中的docId
+func ids(hits reverse_index.Hits) []string {
+	result := make([]string, 0, len(hits.Docs))
+	for _, doc := range hits.Docs {
+		result = append(result, doc.Id)
+	}
+	return result
+}
+
+func kw(word string) *types.TermQuery {
+	return types.NewTermQuery("content", word)
+}
+
+func TestSearchTopK(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java", "python", "rust", "c"))
+		indexer.Add(newDoc(2, "b", "go", "go", "go", "search"))
+		indexer.Add(newDoc(3, "c", "go", "search"))
+		indexer.Add(newDoc(4, "d", "java"))
+
+		if df := indexer.DocFreq(kw("go").Key()); df != 3 {
+			t.Fatalf("DocFreq(go) = %d, want 3", df)
+		}
+
+		query := kw("go").Or(kw("search"))
+		result := indexer.SearchTopK(query, 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 2}).Docs
+		if len(result) != 2 {
+			t.Fatalf("got %d results, want 2", len(result))
+		}
+		// b和c都命中了go和search，c的文档更短所以得分更高；a只命中一次go且文档最长
+		if result[0].Id != "c" || result[1].Id != "b" {
+			t.Errorf("got order %s,%s, want c,b", result[0].Id, result[1].Id)
+		}
+		if result[0].Score < result[1].Score {
+			t.Errorf("scores not descending: %v", result)
+		}
+
+		all := indexer.SearchTopK(query, 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 10}).Docs
+		if len(all) != 3 || all[2].Id != "a" {
+			t.Errorf("got %v, want a last", all)
+		}
+
+		// 删除文档后，统计信息同步减少
+		indexer.DeleteDoc(2, newDoc(2, "b", "go", "go", "go", "search").Keywords)
+		if df := indexer.DocFreq(kw("go").Key()); df != 2 {
+			t.Errorf("DocFreq(go) after delete = %d, want 2", df)
+		}
+		if result := indexer.SearchTopK(kw("search"), 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 10}).Docs; len(result) != 1 || result[0].Id != "c" {
+			t.Errorf("got %v after delete, want only c", result)
+		}
+	})
+}
+
+func TestSearchLegacyKeyword(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go"))
+		indexer.Add(newDoc(2, "b", "java"))
+		indexer.Add(newDoc(3, "c", "rust"))
+
+		// 旧的客户端自己编码Keyword字符串，结果要和Term一样
+		keyword := types.Keyword{Field: "content", Word: "go"}
+		legacy := &types.TermQuery{Keyword: keyword.ToString()}
+		if got := ids(indexer.Search(legacy.Or(kw("java")), 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("got %v, want [a b]", got)
+		}
+	})
+}
+
+func TestSearchMustNot(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java"))
+		indexer.Add(newDoc(2, "b", "go", "java", "php"))
+		indexer.Add(newDoc(3, "c", "go", "php"))
+		indexer.Add(newDoc(4, "d", "go", "java", "rust"))
+
+		// go AND java AND NOT (php OR rust)
+		query := kw("go").And(kw("java")).Not(kw("php"), kw("rust"))
+		result := ids(indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{}))
+		if len(result) != 1 || result[0] != "a" {
+			t.Errorf("got %v, want [a]", result)
+		}
+
+		// 继续And时MustNot不能丢
+		query = query.And(kw("go"))
+		if result := ids(indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 1 || result[0] != "a" {
+			t.Errorf("got %v after And, want [a]", result)
+		}
+
+		// 只有MustNot的查询不命中任何文档
+		if result := ids(indexer.Search(new(types.TermQuery).Not(kw("php")), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 0 {
+			t.Errorf("got %v, want nothing", result)
+		}
+	})
+}
+
+func TestIntersectionOfSkipList(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for round := 0; round < 100; round++ {
+		lists := make([]*skiplist.SkipList, 1+r.IntN(4))
+		counts := make(map[uint64]int)
+		for i := range lists {
+			lists[i] = skiplist.New(skiplist.Uint64)
+			n, max := r.IntN(200), 1+r.IntN(500) // 长短、疏密各不相同
+			for j := 0; j < n; j++ {
+				key := uint64(r.IntN(max))
+				if lists[i].Get(key) == nil {
+					lists[i].Set(key, nil)
+					counts[key]++
+				}
+			}
+		}
+		var want []uint64
+		for key, count := range counts {
+			if count == len(lists) {
+				want = append(want, key)
+			}
+		}
+		slices.Sort(want)
+		var got []uint64
+		if result := reverse_index.IntersectionOfSkipList(lists...); result != nil {
+			for node := result.Front(); node != nil; node = node.Next() {
+				got = append(got, node.Key().(uint64))
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("round %d: got %v, want %v", round, got, want)
+		}
+	}
+}
+
+func TestSearchMustPlan(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		for i := 1; i <= 50; i++ {
+			words := []string{"common"}
+			if i%2 == 0 {
+				words = append(words, "even")
+			}
+			if i%7 == 0 {
+				words = append(words, "seven")
+			}
+			doc := newDoc(uint64(i), strconv.Itoa(i), words...)
+			doc.BitsFeature = uint64(i % 3 & 1)
+			indexer.Add(doc)
+		}
+		// 子查询的顺序不影响结果，不论短的、长的、空的子查询放在哪里
+		orders := [][]*types.TermQuery{
+			{kw("common"), kw("even"), kw("seven")},
+			{kw("seven"), kw("even"), kw("common")},
+			{kw("common"), kw("even").Or(kw("none")), types.NewPrefixQuery("content", "sev", 0)},
+			{types.AtLeast(2, kw("common"), kw("none"), kw("even")), types.NewWildcardQuery("content", "se?en", 0), kw("common")},
+		}
+		for _, children := range orders {
+			query := &types.TermQuery{Must: children}
+			if got := ids(indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"14", "28", "42"}) {
+				t.Errorf("%s: got %v", query, got)
+			}
+			// 过滤只在第一个求值的子查询上做，后面的子查询直接查原始的倒排链，结果要一样
+			if got := ids(indexer.Search(query, 1, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"28"}) {
+				t.Errorf("%s with flag: got %v", query, got)
+			}
+		}
+		// 后求值的叶子节点自己带了MustNot，也要排除文档
+		common := kw("common")
+		common.MustNot = []*types.TermQuery{kw("even")}
+		if got := ids(indexer.Search(&types.TermQuery{Must: []*types.TermQuery{kw("seven"), common}}, 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"7", "21", "35", "49"}) {
+			t.Errorf("must not on a later leaf: got %v", got)
+		}
+		for _, children := range [][]*types.TermQuery{
+			{kw("common"), kw("even"), kw("none")},
+			{kw("none"), kw("common")},
+			{kw("common"), kw("even").And(kw("none"))},
+		} {
+			if got := ids(indexer.Search(&types.TermQuery{Must: children}, 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(got) != 0 {
+				t.Errorf("empty child: got %v", got)
+			}
+		}
+	})
+}
+
+func TestSearchMinimumShouldMatch(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java", "rust"))
+		indexer.Add(newDoc(2, "b", "go", "java"))
+		indexer.Add(newDoc(3, "c", "go"))
+		indexer.Add(newDoc(4, "d", "java", "rust", "c"))
+
+		tags := []*types.TermQuery{kw("go"), kw("java"), kw("rust"), kw("c")}
+		tests := []struct {
+			minimumShouldMatch string
+			want               []string
+		}{
+			{"", []string{"a", "b", "c", "d"}},
+			{"2", []string{"a", "b", "d"}},
+			{"3", []string{"a", "d"}},
+			{"4", nil},
+			{"5", nil},
+			{"50%", []string{"a", "b", "d"}},
+			{"10%", []string{"a", "b", "c", "d"}}, // 向下取整为0，至少为1
+			{"x", nil},                            // 不合法时不命中任何文档
+		}
+		for _, test := range tests {
+			query := &types.TermQuery{Should: tags, MinimumShouldMatch: test.minimumShouldMatch}
+			if got := ids(indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, test.want) {
+				t.Errorf("MinimumShouldMatch=%q: got %v, want %v", test.minimumShouldMatch, got, test.want)
+			}
+		}
+
+		// 和MustNot一起使用
+		query := types.AtLeast(2, tags...).Not(kw("c"))
+		if got := ids(indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("got %v, want [a b]", got)
+		}
+	})
+}
+
+func TestSearchRange(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		newVideo := func(intId uint64, id string, view int64, score float64, bits uint64) types.Document {
+			doc := newDoc(intId, id, "go")
+			doc.BitsFeature = bits
+			doc.IntFeatures = map[string]int64{"view": view, "post_time": 1700000000 + int64(intId)*86400}
+			doc.FloatFeatures = map[string]float64{"score": score}
+			return doc
+		}
+		indexer.Add(newVideo(1, "a", 500, 9.5, 1))
+		indexer.Add(newVideo(2, "b", 10000, 7.0, 1))
+		indexer.Add(newVideo(3, "c", 20000, 8.5, 0))
+		indexer.Add(newVideo(4, "d", 30000, 6.0, 1))
+		indexer.Add(newDoc(5, "e", "go")) // 没有数值属性
+
+		tests := []struct {
+			name   string
+			ranges *types.RangeFilter
+			onFlag uint64
+			want   []string
+		}{
+			{"none", nil, 0, []string{"a", "b", "c", "d", "e"}},
+			{"gt", &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gt(10000)}}, 0, []string{"c", "d"}},
+			{"gte", &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gte(10000)}}, 0, []string{"b", "c", "d"}},
+			{"between", &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gt(500).Lt(30000)}}, 0, []string{"b", "c"}},
+			{"last days", &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("post_time").Gte(1700000000 + 3*86400)}}, 0, []string{"c", "d"}},
+			{"float", &types.RangeFilter{Floats: []*types.FloatRange{types.NewFloatRange("score").Gte(8.5)}}, 0, []string{"a", "c"}},
+			{"and", &types.RangeFilter{
+				Ints:   []*types.IntRange{types.NewIntRange("view").Gte(10000)},
+				Floats: []*types.FloatRange{types.NewFloatRange("score").Lte(8.5)},
+			}, 0, []string{"b", "c", "d"}},
+			{"with bits", &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gte(10000)}}, 1, []string{"b", "d"}},
+			{"unknown field", &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("like").Gte(0)}}, 0, nil},
+		}
+		for _, test := range tests {
+			if got := ids(indexer.Search(kw("go"), test.onFlag, 0, nil, test.ranges, nil, false, reverse_index.Page{})); !slices.Equal(got, test.want) {
+				t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			}
+		}
+
+		// 范围过滤也作用于打分检索，作用于短语、Should等各种节点
+		ranges := &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gt(10000)}}
+		if got := indexer.SearchTopK(kw("go").Or(kw("java")), 0, 0, nil, ranges, nil, false, reverse_index.Page{Limit: 10}).Docs; len(got) != 2 {
+			t.Errorf("SearchTopK got %v, want c and d", got)
+		}
+
+		// 删除后属性不再命中
+		indexer.DeleteDoc(3, []*types.Keyword{{Field: "content", Word: "go"}})
+		indexer.Add(newDoc(3, "c", "go"))
+		if got := ids(indexer.Search(kw("go"), 0, 0, nil, ranges, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"d"}) {
+			t.Errorf("after delete got %v, want [d]", got)
+		}
+	})
+}
+
+func TestSearchPage(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		// 25篇文档，偶数文档的BitsFeature为1；go出现的次数为IntId%4+1，打分时有大量同分的文档
+		for i := 1; i <= 25; i++ {
+			words := []string{"x"}
+			for j := 0; j <= i%4; j++ {
+				words = append(words, "go")
+			}
+			doc := newDoc(uint64(i), strconv.Itoa(i), words...)
+			doc.BitsFeature = uint64(1 - i%2)
+			indexer.Add(doc)
+		}
+		all := ids(indexer.Search(kw("go"), 0, 0, nil, nil, nil, false, reverse_index.Page{}))
+		scoredAll := ids(indexer.SearchTopK(kw("go"), 0, 0, nil, nil, nil, false, reverse_index.Page{}))
+		if len(all) != 25 || len(scoredAll) != 25 {
+			t.Fatalf("got %d and %d docs, want 25", len(all), len(scoredAll))
+		}
+
+		hits := indexer.Search(kw("go"), 0, 0, nil, nil, nil, false, reverse_index.Page{Offset: 10, Limit: 10})
+		if !slices.Equal(ids(hits), all[10:20]) || hits.Total != 25 || hits.Next == nil {
+			t.Errorf("offset page got %v total %d", ids(hits), hits.Total)
+		}
+		hits = indexer.Search(kw("go"), 1, 0, nil, nil, nil, false, reverse_index.Page{Limit: 5})
+		if !slices.Equal(ids(hits), []string{"2", "4", "6", "8", "10"}) || hits.Total != 12 {
+			t.Errorf("filtered page got %v total %d, want total 12", ids(hits), hits.Total)
+		}
+
+		// 用游标把所有页走一遍，拼起来要和不分页的结果一致，游标经过编码解码后依然有效
+		for _, scored := range []bool{false, true} {
+			search, want := indexer.Search, all
+			if scored {
+				search, want = indexer.SearchTopK, scoredAll
+			}
+			var got []string
+			page := reverse_index.Page{Limit: 7}
+			for pages := 0; ; pages++ {
+				hits := search(kw("go"), 0, 0, nil, nil, nil, false, page)
+				if hits.Total != 25 || pages > 4 {
+					t.Fatalf("scored=%v: total %d after %d pages", scored, hits.Total, pages)
+				}
+				got = append(got, ids(hits)...)
+				if hits.Next == nil {
+					break
+				}
+				cursor, err := reverse_index.DecodeCursor(hits.Next.Encode())
+				if err != nil {
+					t.Fatal(err)
+				}
+				page.After = cursor
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("scored=%v: pages got %v, want %v", scored, got, want)
+			}
+		}
+
+		if _, err := reverse_index.DecodeCursor("not a cursor"); err == nil {
+			t.Error("DecodeCursor accepted an invalid cursor")
+		}
+	})
+}
+
+func TestSearchSort(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		newItem := func(intId uint64, id string, price int64, rating float64, words ...string) types.Document {
+			doc := newDoc(intId, id, words...)
+			doc.IntFeatures = map[string]int64{"price": price}
+			doc.FloatFeatures = map[string]float64{"rating": rating}
+			return doc
+		}
+		indexer.Add(newItem(1, "a", 300, 4.5, "go"))
+		indexer.Add(newItem(2, "b", 100, 4.8, "go", "go", "go"))
+		indexer.Add(newItem(3, "c", 200, 4.5, "go", "go"))
+		indexer.Add(newDoc(4, "d", "go")) // 没有price和rating
+		indexer.Add(newItem(5, "e", 100, 3.9, "go", "go"))
+		indexer.Add(newItem(6, "f", 500, 4.8, "go"))
+
+		tests := []struct {
+			name   string
+			sortBy []*types.SortBy
+			want   []string
+		}{
+			{"asc", []*types.SortBy{types.NewSortBy("price", false)}, []string{"b", "e", "c", "a", "f", "d"}},
+			{"desc", []*types.SortBy{types.NewSortBy("price", true)}, []string{"f", "a", "c", "b", "e", "d"}},
+			{"float", []*types.SortBy{types.NewSortBy("rating", true)}, []string{"b", "f", "a", "c", "e", "d"}},
+			{"tie breaker", []*types.SortBy{types.NewSortBy("rating", true), types.NewSortBy("price", true)}, []string{"f", "b", "a", "c", "e", "d"}},
+			{"unknown field", []*types.SortBy{types.NewSortBy("like", true)}, []string{"a", "b", "c", "d", "e", "f"}},
+		}
+		for _, test := range tests {
+			if got := ids(indexer.Search(kw("go"), 0, 0, nil, nil, nil, false, reverse_index.Page{SortBy: test.sortBy})); !slices.Equal(got, test.want) {
+				t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+			}
+			// 堆只保留一页，结果要与全部排序后取前几个一致
+			if got := ids(indexer.Search(kw("go"), 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 3, SortBy: test.sortBy})); !slices.Equal(got, test.want[:3]) {
+				t.Errorf("%s: top 3 got %v, want %v", test.name, got, test.want[:3])
+			}
+		}
+
+		// 先按价格升序，同价格的按得分降序：b的go比e多，得分更高
+		sortBy := []*types.SortBy{types.NewSortBy("price", false), types.NewSortBy(types.SORT_BY_SCORE, true)}
+		hits := indexer.SearchTopK(kw("go"), 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 2, SortBy: sortBy})
+		if got := ids(hits); !slices.Equal(got, []string{"b", "e"}) || hits.Docs[0].Score <= hits.Docs[1].Score {
+			t.Errorf("sort by price and score got %v", hits.Docs)
+		}
+
+		// 按属性排序时用游标翻页，没有值的文档也能翻到
+		want := tests[1].want
+		var got []string
+		page := reverse_index.Page{Limit: 2, SortBy: tests[1].sortBy}
+		for pages := 0; pages <= 3; pages++ {
+			hits := indexer.Search(kw("go"), 0, 0, nil, nil, nil, false, page)
+			got = append(got, ids(hits)...)
+			if hits.Next == nil {
+				break
+			}
+			cursor, err := reverse_index.DecodeCursor(hits.Next.Encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+			page.After = cursor
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("sorted pages got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestSearchFacets(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		const VIP = 1 << 0
+		const HD = 1 << 1
+		newVideo := func(intId uint64, id string, bits uint64, tags ...string) types.Document {
+			doc := newDoc(intId, id, "go")
+			doc.BitsFeature = bits
+			for _, tag := range tags {
+				doc.Keywords = append(doc.Keywords, &types.Keyword{Field: "tag", Word: tag})
+			}
+			return doc
+		}
+		indexer.Add(newVideo(1, "a", VIP, "go", "tutorial"))
+		indexer.Add(newVideo(2, "b", VIP|HD, "java", "tutorial", "tutorial")) // 同一个词在一篇文档里只计一次
+		indexer.Add(newVideo(3, "c", 0, "go"))
+		indexer.Add(newVideo(4, "d", HD, "rust"))
+		indexer.Add(newVideo(5, "e", VIP, "go", "java"))
+		indexer.Add(newDoc(6, "f", "java")) // 不命中go
+
+		facets := &types.FacetRequest{
+			Terms: []*types.TermsFacet{types.NewTermsFacet("tag", 2), types.NewTermsFacet("author", 0)},
+			Bits:  []uint64{VIP, HD, VIP | HD},
+		}
+		wantTags := []*types.TermCount{{Word: "go", Count: 3}, {Word: "java", Count: 2}}
+		wantBits := []int64{3, 2, 1}
+		check := func(name string, hits reverse_index.Hits) {
+			if hits.Facets == nil || len(hits.Facets.Terms) != 2 || len(hits.Facets.Bits) != 3 {
+				t.Fatalf("%s: facets %v", name, hits.Facets)
+			}
+			tags := hits.Facets.Terms[0].Terms
+			if len(tags) != len(wantTags) {
+				t.Fatalf("%s: tags %v, want %v", name, tags, wantTags)
+			}
+			for i, tag := range tags {
+				if tag.Word != wantTags[i].Word || tag.Count != wantTags[i].Count {
+					t.Errorf("%s: tags %v, want %v", name, tags, wantTags)
+				}
+			}
+			if len(hits.Facets.Terms[1].Terms) != 0 {
+				t.Errorf("%s: unknown field got %v", name, hits.Facets.Terms[1].Terms)
+			}
+			for i, bit := range hits.Facets.Bits {
+				if bit.Count != wantBits[i] {
+					t.Errorf("%s: bit %d count %d, want %d", name, bit.Flag, bit.Count, wantBits[i])
+				}
+			}
+		}
+		// 聚合的是所有命中的文档，与分页、排序无关
+		check("search", indexer.Search(kw("go"), 0, 0, nil, nil, facets, false, reverse_index.Page{Limit: 1}))
+		check("top k", indexer.SearchTopK(kw("go"), 0, 0, nil, nil, facets, false, reverse_index.Page{Limit: 1}))
+		check("sort", indexer.Search(kw("go"), 0, 0, nil, nil, facets, false, reverse_index.Page{Limit: 1, SortBy: []*types.SortBy{types.NewSortBy("like", true)}}))
+
+		// 过滤之后再聚合：a、b、e三篇，三个词都是2篇，同样多的按字典序
+		hits := indexer.Search(kw("go"), VIP, 0, nil, nil, facets, false, reverse_index.Page{})
+		if tags := hits.Facets.Terms[0].Terms; len(tags) != 2 || tags[0].Word != "go" || tags[0].Count != 2 || tags[1].Word != "java" {
+			t.Errorf("filtered tags %v", tags)
+		}
+		// 删除的文档不再计数
+		indexer.DeleteDoc(1, newVideo(1, "a", VIP, "go", "tutorial").Keywords)
+		hits = indexer.Search(types.NewTermQuery("tag", "tutorial"), 0, 0, nil, nil, facets, false, reverse_index.Page{})
+		if tags := hits.Facets.Terms[0].Terms; len(hits.Docs) != 1 || len(tags) != 2 || tags[0].Word != "java" {
+			t.Errorf("after delete tags %v", tags)
+		}
+		if hits := indexer.Search(kw("php"), 0, 0, nil, nil, facets, false, reverse_index.Page{}); hits.Facets == nil || hits.Facets.Bits[0].Count != 0 {
+			t.Errorf("no hits facets %v", hits.Facets)
+		}
+	})
+}
+
+func TestSearchCache(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java"))
+		indexer.Add(newDoc(2, "b", "go"))
+		indexer.Add(newDoc(3, "c", "rust"))
+		search := func(query *types.TermQuery) []string {
+			return ids(indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{}))
+		}
+		expect := func(name string, got []string, want []string, hits int64, misses int64) {
+			t.Helper()
+			stats := indexer.CacheStats()
+			if !slices.Equal(got, want) || stats.Hits != hits || stats.Misses != misses {
+				t.Errorf("%s: got %v hits %d misses %d, want %v hits %d misses %d", name, got, stats.Hits, stats.Misses, want, hits, misses)
+			}
+		}
+
+		expect("first", search(kw("go").Or(kw("rust"))), []string{"a", "b", "c"}, 0, 1)
+		expect("same query", search(kw("go").Or(kw("rust"))), []string{"a", "b", "c"}, 1, 1)
+		expect("reordered", search(kw("rust").Or(kw("go"))), []string{"a", "b", "c"}, 2, 1) // 子查询的顺序不影响缓存的key
+		// 分页、排序、打分都在缓存的结果上算，不影响缓存的key
+		if got := ids(indexer.SearchTopK(kw("go").Or(kw("rust")), 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 1})); len(got) != 1 {
+			t.Errorf("top k got %v", got)
+		}
+		expect("top k", nil, nil, 3, 1)
+		other := ids(indexer.Search(kw("go").Or(kw("rust")), 1, 0, nil, nil, nil, false, reverse_index.Page{}))
+		expect("other flags", other, nil, 3, 2)
+
+		// 写入不相关的词不淘汰，写入相关的词只淘汰依赖它的查询
+		expect("java", search(kw("java")), []string{"a"}, 3, 3)
+		indexer.Add(newDoc(4, "d", "php"))
+		expect("unrelated write", search(kw("go").Or(kw("rust"))), []string{"a", "b", "c"}, 4, 3)
+		indexer.Add(newDoc(5, "e", "rust"))
+		expect("related write", search(kw("go").Or(kw("rust"))), []string{"a", "b", "c", "e"}, 4, 4)
+		expect("untouched", search(kw("java")), []string{"a"}, 5, 4)
+		indexer.Delete(1, &types.Keyword{Field: "content", Word: "java"})
+		expect("delete", search(kw("java")), nil, 5, 5)
+
+		// 前缀查询依赖整个field
+		expect("prefix", search(types.NewPrefixQuery("content", "ja", 0)), nil, 5, 6)
+		indexer.Add(newDoc(6, "f", "javascript"))
+		expect("prefix after write", search(types.NewPrefixQuery("content", "ja", 0)), []string{"f"}, 5, 7)
+		if stats := indexer.CacheStats(); stats.Invalidations == 0 || stats.Entries == 0 {
+			t.Errorf("stats %+v", stats)
+		}
+	})
+}
+
+func TestExplain(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java", "rust"))
+		indexer.Add(newDoc(2, "b", "go", "go", "php"))
+		indexer.Add(newDoc(3, "c", "java"))
+		indexer.Add(newDoc(4, "d", "golang"))
+
+		query := kw("go").And(types.AtLeast(1, kw("java"), kw("php"))).Not(kw("rust"))
+		explained := indexer.Explain(1, query, true)
+		if explained.Matched || len(explained.Must) != 2 || !explained.Must[0].Matched || len(explained.MustNot) != 1 || !explained.MustNot[0].Matched {
+			t.Errorf("a should be excluded by MustNot: %v", explained)
+		}
+		explained = indexer.Explain(2, query, true)
+		should := explained.Must[1]
+		if !explained.Matched || should.MinimumShouldMatch != 1 || should.Should[0].Matched || !should.Should[1].Matched {
+			t.Errorf("b should match via php: %v", explained)
+		}
+		if !slices.Equal(explained.Must[0].MatchedKeywords, []string{types.NewTermQuery("content", "go").Key()}) {
+			t.Errorf("matched keywords %v", explained.Must[0].MatchedKeywords)
+		}
+		// 得分与SearchTopK一致，MustNot下的节点不打分
+		hits := indexer.SearchTopK(query, 0, 0, nil, nil, nil, false, reverse_index.Page{})
+		if len(hits.Docs) != 1 || hits.Docs[0].Score != explained.Score || explained.Score != explained.Must[0].Score+should.Score {
+			t.Errorf("score %v, explained %v", hits.Docs, explained.Score)
+		}
+		if explained.MustNot[0].Score != 0 || indexer.Explain(2, query, false).Score != 0 {
+			t.Error("unscored nodes got a score")
+		}
+
+		// 展开成多个词的查询列出文档实际命中的词
+		explained = indexer.Explain(4, types.NewPrefixQuery("content", "go", 0), false)
+		if !explained.Matched || len(explained.MatchedKeywords) != 1 || explained.MatchedKeywords[0] != types.NewTermQuery("content", "golang").Key() {
+			t.Errorf("prefix explained %v", explained)
+		}
+		if explained := indexer.Explain(3, query, false); explained.Matched || explained.Must[0].Matched {
+			t.Errorf("c should not match: %v", explained)
+		}
+	})
+}
+
+func TestSearchBoost(t *testing.T) {
+	field := func(field string, word string, weight float32) *types.Keyword {
+		return &types.Keyword{Field: field, Word: word, Weight: weight}
+	}
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(types.Document{Id: "a", IntId: 1, Keywords: []*types.Keyword{field("title", "go", 0), field("tag", "x", 0)}})
+		indexer.Add(types.Document{Id: "b", IntId: 2, Keywords: []*types.Keyword{field("tag", "go", 0), field("tag", "y", 0)}})
+		indexer.Add(types.Document{Id: "c", IntId: 3, Keywords: []*types.Keyword{field("tag", "rust", 2), field("tag", "z", 0)}})
+		indexer.Add(types.Document{Id: "d", IntId: 4, Keywords: []*types.Keyword{field("tag", "rust", 0), field("tag", "w", 0)}})
+
+		// 两个词的idf、词频、文档长度都一样，得分相同时按IntId排序
+		plain := types.NewTermQuery("title", "go").Or(types.NewTermQuery("tag", "go"))
+		if got := ids(indexer.SearchTopK(plain, 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"a", "b"}) {
+			t.Errorf("without boost got %v", got)
+		}
+		boosted := types.NewTermQuery("title", "go").Or(types.NewTermQuery("tag", "go").WithBoost(2))
+		hits := indexer.SearchTopK(boosted, 0, 0, nil, nil, nil, false, reverse_index.Page{})
+		if got := ids(hits); !slices.Equal(got, []string{"b", "a"}) || hits.Docs[0].Score != 2*hits.Docs[1].Score {
+			t.Errorf("with boost got %v", hits.Docs)
+		}
+		if explained := indexer.Explain(2, boosted, true); explained.Score != hits.Docs[0].Score || explained.Should[1].Score != explained.Score {
+			t.Errorf("explained score %v, want %v", explained.Score, hits.Docs[0].Score)
+		}
+
+		// 建索引时的权重
+		hits = indexer.SearchTopK(types.NewTermQuery("tag", "rust"), 0, 0, nil, nil, nil, false, reverse_index.Page{})
+		if got := ids(hits); !slices.Equal(got, []string{"c", "d"}) || hits.Docs[0].Score != 2*hits.Docs[1].Score {
+			t.Errorf("with keyword weight got %v", hits.Docs)
+		}
+		if explained := indexer.Explain(3, types.NewTermQuery("tag", "rust"), true); explained.Score != hits.Docs[0].Score {
+			t.Errorf("explained score %v, want %v", explained.Score, hits.Docs[0].Score)
+		}
+
+		// 展开成多个词的查询按展开出的词打分，boost同样生效
+		for _, query := range []*types.TermQuery{
+			types.NewPrefixQuery("tag", "ru", 0),
+			types.NewWildcardQuery("tag", "r?st", 0),
+			types.NewFuzzyQuery("tag", "rast", 1, 0),
+		} {
+			hits := indexer.SearchTopK(query, 0, 0, nil, nil, nil, false, reverse_index.Page{})
+			want := indexer.SearchTopK(types.NewTermQuery("tag", "rust"), 0, 0, nil, nil, nil, false, reverse_index.Page{})
+			if !slices.Equal(ids(hits), []string{"c", "d"}) || hits.Docs[0].Score != want.Docs[0].Score || hits.Docs[1].Score != want.Docs[1].Score {
+				t.Errorf("%s got %v, want %v", query.ToQueryString(), hits.Docs, want.Docs)
+			}
+			boosted := indexer.SearchTopK(query.WithBoost(2), 0, 0, nil, nil, nil, false, reverse_index.Page{})
+			if len(boosted.Docs) != 2 || boosted.Docs[0].Score != 2*want.Docs[0].Score {
+				t.Errorf("boosted %s got %v", query.ToQueryString(), boosted.Docs)
+			}
+			if explained := indexer.Explain(3, query, true); explained.Score != boosted.Docs[0].Score {
+				t.Errorf("explained %s score %v, want %v", query.ToQueryString(), explained.Score, boosted.Docs[0].Score)
+			}
+		}
+	})
+}
+
+func TestSearchProfile(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java", "rust"))
+		indexer.Add(newDoc(2, "b", "go", "go", "php"))
+		indexer.Add(newDoc(3, "c", "java"))
+		indexer.Add(newDoc(4, "d", "golang"))
+
+		query := kw("go").And(types.AtLeast(1, kw("java"), kw("php"))).Not(kw("rust"))
+		if hits := indexer.Search(query, 0, 0, nil, nil, nil, false, reverse_index.Page{}); hits.Profile != nil {
+			t.Errorf("profile without asking: %v", hits.Profile)
+		}
+
+		before := indexer.CacheStats()
+		for _, scored := range []bool{false, true} {
+			search := indexer.Search
+			if scored {
+				search = indexer.SearchTopK
+			}
+			hits := search(query, 0, 0, nil, nil, nil, true, reverse_index.Page{})
+			profile := hits.Profile
+			if profile == nil || profile.MatchedDocs != 1 || len(hits.Docs) != 1 {
+				t.Fatalf("scored=%v: profile %v, hits %v", scored, profile, hits.Docs)
+			}
+			root := profile.Query
+			if root.Query != query.ToQueryString() || root.ResultCount != 1 || root.Nanos <= 0 || len(root.Must) != 2 || len(root.MustNot) != 1 {
+				t.Fatalf("scored=%v: root %v", scored, root)
+			}
+			// go的倒排链最短，先求值
+			if got := root.Must[0]; got.Query != kw("go").ToQueryString() || got.PostingSize != 2 || got.ResultCount != 2 {
+				t.Errorf("scored=%v: first must %v", scored, got)
+			}
+			if got := root.Must[1]; len(got.Should) != 2 || got.ResultCount != 3 || got.Should[1].PostingSize != 1 {
+				t.Errorf("scored=%v: should %v", scored, got)
+			}
+			if got := root.MustNot[0]; got.PostingSize != 1 || got.ResultCount != 1 {
+				t.Errorf("scored=%v: must not %v", scored, got)
+			}
+			// 两种实现都要单独统计过滤的耗时，跳表在叶子上过滤
+			if profile.FilterNanos <= 0 || profile.FilterNanos > profile.MatchNanos {
+				t.Errorf("scored=%v: filter nanos %d, match nanos %d", scored, profile.FilterNanos, profile.MatchNanos)
+			}
+		}
+		if after := indexer.CacheStats(); after.Hits != before.Hits || after.Misses != before.Misses {
+			t.Errorf("profiled search used the cache: %+v -> %+v", before, after)
+		}
+
+		// 前缀查询的倒排链长度是展开出的所有词之和
+		hits := indexer.Search(types.NewPrefixQuery("content", "go", 0), 0, 0, nil, nil, nil, true, reverse_index.Page{})
+		if got := hits.Profile.Query; got.PostingSize != 3 || got.ResultCount != 3 {
+			t.Errorf("prefix profile %v", got)
+		}
+	})
+}
+
+func TestSearchPhrase(t *testing.T) {
+	// 按空格切词，每个词记录自己的位置
+	newPositionalDoc := func(intId uint64, id string, words ...string) types.Document {
+		doc := types.Document{Id: id, IntId: intId}
+		for i, word := range words {
+			doc.Keywords = append(doc.Keywords, &types.Keyword{Field: "content", Word: word, Positions: []int32{int32(i)}})
+		}
+		return doc
+	}
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newPositionalDoc(1, "a", "分布式", "搜索", "引擎"))
+		indexer.Add(newPositionalDoc(2, "b", "搜索", "分布式", "系统"))
+		indexer.Add(newPositionalDoc(3, "c", "分布式", "全文", "搜索"))
+		indexer.Add(newPositionalDoc(4, "d", "分布式", "搜索", "分布式", "搜索"))
+
+		words := []*types.Keyword{{Field: "content", Word: "分布式"}, {Field: "content", Word: "搜索"}}
+		if result := ids(indexer.Search(types.NewPhraseQuery(0, words...), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 2 || result[0] != "a" || result[1] != "d" {
+			t.Errorf("exact phrase got %v, want [a d]", result)
+		}
+		if result := ids(indexer.Search(types.NewPhraseQuery(1, words...), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 3 || result[2] != "d" {
+			t.Errorf("slop 1 got %v, want [a c d]", result)
+		}
+		legacy := &types.TermQuery{Phrase: &types.PhraseQuery{Keywords: []string{words[0].ToString(), words[1].ToString()}}}
+		if result := ids(indexer.Search(legacy, 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(result, []string{"a", "d"}) {
+			t.Errorf("legacy phrase got %v, want [a d]", result)
+		}
+
+		// 重复的keyword合并后词频等于位置个数
+		if result := indexer.SearchTopK(types.NewPhraseQuery(0, words...), 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 10}).Docs; len(result) != 2 || result[0].Id != "d" {
+			t.Errorf("scored phrase got %v, want d first", result)
+		}
+
+		positions := [][]int32{{0, 5}, {3, 7}, {8}}
+		if !reverse_index.MatchPhrase(positions, 1) || reverse_index.MatchPhrase(positions, 0) {
+			t.Errorf("MatchPhrase(%v) wrong", positions)
+		}
+	})
+}
+
+func TestSearchPrefixAndWildcard(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "golang"))
+		indexer.Add(newDoc(2, "b", "gopher"))
+		indexer.Add(newDoc(3, "c", "go-lang"))
+		indexer.Add(newDoc(4, "d", "java"))
+
+		if result := ids(indexer.Search(types.NewPrefixQuery("content", "go", 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 3 {
+			t.Errorf("prefix go got %v, want [a b c]", result)
+		}
+		if result := ids(indexer.Search(types.NewWildcardQuery("content", "go*lang", 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 2 || result[0] != "a" || result[1] != "c" {
+			t.Errorf("wildcard go*lang got %v, want [a c]", result)
+		}
+		if result := ids(indexer.Search(types.NewWildcardQuery("content", "?ava", 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 1 || result[0] != "d" {
+			t.Errorf("wildcard ?ava got %v, want [d]", result)
+		}
+		// 展开的词数受MaxExpansions限制，按字典序取前2个：go-lang、golang
+		if result := ids(indexer.Search(types.NewPrefixQuery("content", "go", 2), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 2 || result[0] != "a" || result[1] != "c" {
+			t.Errorf("prefix go with 2 expansions got %v, want [a c]", result)
+		}
+
+		// 倒排链删空后，词典里也枚举不到
+		indexer.Delete(2, &types.Keyword{Field: "content", Word: "gopher"})
+		if result := ids(indexer.Search(types.NewPrefixQuery("content", "gop", 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 0 {
+			t.Errorf("prefix gop after delete got %v", result)
+		}
+
+		for pattern, want := range map[string]bool{"*": true, "分*式": true, "分?式": true, "分??式": false, "*布*": true, "分布式?": false} {
+			if got := reverse_index.MatchWildcard(pattern, "分布式"); got != want {
+				t.Errorf("MatchWildcard(%s, 分布式) = %v, want %v", pattern, got, want)
+			}
+		}
+	})
+}
+
+func TestSearchFuzzy(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "golang"))
+		indexer.Add(newDoc(2, "b", "golan"))
+		indexer.Add(newDoc(3, "c", "gulang"))
+		indexer.Add(newDoc(4, "d", "搜索引擎"))
+		indexer.Add(newDoc(5, "e", "java"))
+
+		// golnag与golang、golan的距离都是2，与gulang的距离是3
+		if result := ids(indexer.Search(types.NewFuzzyQuery("content", "golnag", 2, 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 2 || result[0] != "a" || result[1] != "b" {
+			t.Errorf("fuzzy golnag~2 got %v, want [a b]", result)
+		}
+		if result := ids(indexer.Search(types.NewFuzzyQuery("content", "golang", 1, 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 3 {
+			t.Errorf("fuzzy golang~1 got %v, want [a b c]", result)
+		}
+		// 前缀必须一致，gulang被排除
+		if result := ids(indexer.Search(types.NewFuzzyQuery("content", "golang", 1, 2), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 2 || result[1] != "b" {
+			t.Errorf("fuzzy golang~1 with prefix 2 got %v, want [a b]", result)
+		}
+		// 中文按字符计算距离：搜素引擎与搜索引擎只差一个字
+		if result := ids(indexer.Search(types.NewFuzzyQuery("content", "搜素引擎", 1, 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 1 || result[0] != "d" {
+			t.Errorf("fuzzy 搜素引擎~1 got %v, want [d]", result)
+		}
+		if result := ids(indexer.Search(types.NewFuzzyQuery("content", "搜素引擎", 0, 0), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 0 {
+			t.Errorf("fuzzy 搜素引擎~0 got %v, want nothing", result)
+		}
+	})
+}
+
+func TestSearchSubstring(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		textDoc := func(intId uint64, id string, text string) types.Document {
+			return types.Document{Id: id, IntId: intId, TextFields: map[string]string{"content": text}, Keywords: types.NgramKeywords("content", text, 2)}
+		}
+		search := func(text string) []string {
+			return ids(indexer.Search(types.NewSubstringQuery("content", text), 0, 0, nil, nil, nil, false, reverse_index.Page{}))
+		}
+		indexer.Add(textDoc(1, "a", "分布式搜索引擎"))
+		indexer.Add(textDoc(2, "b", "搜索引擎与分布式"))
+		indexer.Add(textDoc(3, "c", "式搜分布式")) // 包含分布式搜的所有bigram，但不包含分布式搜
+
+		if result := search("分布式搜"); !slices.Equal(result, []string{"a"}) {
+			t.Errorf("substring 分布式搜 got %v, want [a]", result)
+		}
+		if result := search("分布式"); !slices.Equal(result, []string{"a", "b", "c"}) {
+			t.Errorf("substring 分布式 got %v, want [a b c]", result)
+		}
+		// 比n短的子串用前缀查gram，末尾的字也能查到
+		if result := search("擎"); !slices.Equal(result, []string{"a", "b"}) {
+			t.Errorf("substring 擎 got %v, want [a b]", result)
+		}
+		if result := ids(indexer.Search(types.NewSubstringQuery("title", "分布"), 0, 0, nil, nil, nil, false, reverse_index.Page{})); len(result) != 0 {
+			t.Errorf("substring on field without ngrams got %v", result)
+		}
+
+		explanation := indexer.Explain(3, types.NewSubstringQuery("content", "分布式搜"), false)
+		if explanation.Matched || len(explanation.MatchedKeywords) != 3 {
+			t.Errorf("explain c got %v", explanation)
+		}
+		if !indexer.Explain(1, types.NewSubstringQuery("content", "分布式搜"), false).Matched {
+			t.Error("explain a should match")
+		}
+
+		// 写入新文档后不能再用缓存的结果
+		indexer.Add(textDoc(4, "d", "分布式搜"))
+		if result := search("分布式搜"); !slices.Equal(result, []string{"a", "d"}) {
+			t.Errorf("substring 分布式搜 after add got %v, want [a d]", result)
+		}
+		for _, keyword := range types.NgramKeywords("content", "分布式搜索引擎", 2) {
+			indexer.Delete(1, keyword)
+		}
+		if result := search("分布式搜"); !slices.Equal(result, []string{"d"}) {
+			t.Errorf("substring 分布式搜 after delete got %v, want [d]", result)
+		}
+	})
+}
+
+func TestTopTerms(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "golang", "gopher"))
+		indexer.Add(newDoc(2, "b", "golang", "google"))
+		indexer.Add(newDoc(3, "c", "golang", "google", "java"))
+		indexer.Add(newDoc(4, "d", "gopher"))
+
+		words := func(terms []reverse_index.TermStat) []string {
+			result := make([]string, 0, len(terms))
+			for _, term := range terms {
+				result = append(result, term.Word)
+			}
+			return result
+		}
+		// 按文档频率排序，相同时按字典序
+		terms := indexer.TopTerms("content", "go", 10, nil)
+		if got := words(terms); !slices.Equal(got, []string{"golang", "google", "gopher"}) || terms[0].DocFreq != 3 {
+			t.Errorf("TopTerms go got %v", terms)
+		}
+		if got := words(indexer.TopTerms("content", "go", 1, nil)); !slices.Equal(got, []string{"golang"}) {
+			t.Errorf("TopTerms go with k=1 got %v", got)
+		}
+		boosted := indexer.TopTerms("content", "go", 2, func(word string, docFreq int) float64 {
+			if word == "gopher" {
+				return 100
+			}
+			return float64(docFreq)
+		})
+		if got := words(boosted); !slices.Equal(got, []string{"gopher", "golang"}) {
+			t.Errorf("TopTerms with score got %v", got)
+		}
+		if got := indexer.TopTerms("title", "go", 10, nil); len(got) != 0 {
+			t.Errorf("TopTerms on other field got %v", got)
+		}
+	})
+}
+
+func TestSimilarTerms(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "golang", "golem"))
+		indexer.Add(newDoc(2, "b", "golang", "gulang"))
+		indexer.Add(newDoc(3, "c", "golang"))
+		indexer.Add(newDoc(4, "d", "goland", "gulang"))
+
+		// 按文档频率排序，相同时编辑距离小的在前，不包含原词
+		terms := indexer.SimilarTerms("content", "gulang", 2, 10)
+		got := make([]string, 0, len(terms))
+		for _, term := range terms {
+			got = append(got, term.Word)
+		}
+		if !slices.Equal(got, []string{"golang", "goland"}) || terms[0].DocFreq != 3 || terms[0].Distance != 1 || terms[1].Distance != 2 {
+			t.Errorf("SimilarTerms gulang got %v", terms)
+		}
+		if got := indexer.SimilarTerms("content", "golanj", 1, 10); len(got) != 2 || got[0].Word != "golang" {
+			t.Errorf("SimilarTerms golanj got %v", got)
+		}
+		if got := indexer.SimilarTerms("content", "golanj", 1, 1); len(got) != 1 {
+			t.Errorf("SimilarTerms with k=1 got %v", got)
+		}
+
+		indexer.Delete(4, &types.Keyword{Field: "content", Word: "goland"})
+		for _, term := range indexer.SimilarTerms("content", "golanj", 1, 10) {
+			if term.Word == "goland" {
+				t.Errorf("deleted term should not be a candidate: %v", term)
+			}
+		}
+	})
+}
+
+func TestDeleteDoc(t *testing.T) {
+	forEachIndexer(t, func(t *testing.T, indexer reverseIndexer) {
+		indexer.Add(newDoc(1, "a", "go", "java"))
+		indexer.Add(newDoc(2, "b", "java"))
+		score := func() float64 {
+			hits := indexer.SearchTopK(types.NewTermQuery("content", "go"), 0, 0, nil, nil, nil, false, reverse_index.Page{Limit: 10})
+			if len(hits.Docs) != 1 {
+				t.Fatalf("search go got %v", hits.Docs)
+			}
+			return hits.Docs[0].Score
+		}
+		want := score()
+
+		// 没有keyword的文档(比如原文全是停用词)也计入了文档总数，删除后要扣掉，反复添加删除不能让统计信息越来越偏
+		for i := uint64(3); i < 13; i++ {
+			doc := newDoc(i, "empty")
+			doc.IntFeatures = map[string]int64{"view": 1}
+			indexer.Add(doc)
+			indexer.DeleteDoc(i, doc.Keywords)
+		}
+		if got := score(); got != want {
+			t.Errorf("score after deleting docs without keywords got %v, want %v", got, want)
+		}
+
+		// 只删一个keyword时文档还在，统计信息和数值属性都不能少
+		doc := newDoc(20, "c", "rust", "c")
+		doc.IntFeatures = map[string]int64{"view": 5}
+		indexer.Add(doc)
+		want = score()
+		indexer.Delete(20, doc.Keywords[1])
+		if got := score(); got != want {
+			t.Errorf("score after deleting a keyword of another doc got %v, want %v", got, want)
+		}
+		ranges := &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gte(5)}}
+		if got := ids(indexer.Search(types.NewTermQuery("content", "rust"), 0, 0, nil, ranges, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"c"}) {
+			t.Errorf("range search after deleting a keyword got %v", got)
+		}
+
+		indexer.DeleteDoc(2, newDoc(2, "b", "java").Keywords)
+		if indexer.DocFreq("content\001java") != 1 {
+			t.Errorf("DocFreq of java got %d", indexer.DocFreq("content\001java"))
+		}
+	})
+}
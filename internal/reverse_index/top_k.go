@@ -0,0 +1,66 @@
+package reverse_index
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// ScoredDoc 带得分的检索结果
+type ScoredDoc struct {
+	Id    string // 业务侧的文档Id
+	IntId uint64 // 倒排索引上的文档Id
+	Score float64
+	Sort  []SortValue // 在各个排序属性上的值，没有按属性排序时为空
+}
+
+// scoredDocHeap 堆顶是当前TopK里排在最后的文档。before(a, b)表示a排在b前面
+type scoredDocHeap struct {
+	docs   []ScoredDoc
+	before func(a, b ScoredDoc) bool
+}
+
+func (h scoredDocHeap) Len() int           { return len(h.docs) }
+func (h scoredDocHeap) Less(i, j int) bool { return h.before(h.docs[j], h.docs[i]) }
+func (h scoredDocHeap) Swap(i, j int)      { h.docs[i], h.docs[j] = h.docs[j], h.docs[i] }
+func (h *scoredDocHeap) Push(x any)        { h.docs = append(h.docs, x.(ScoredDoc)) }
+func (h *scoredDocHeap) Pop() any {
+	old := h.docs
+	n := len(old)
+	x := old[n-1]
+	h.docs = old[:n-1]
+	return x
+}
+
+// TopK 维护排在最前面的k个文档，内存占用O(k)，每次Push是O(logk)
+type TopK struct {
+	k    int
+	heap scoredDocHeap
+}
+
+// NewTopK before(a, b)表示a排在b前面，必须是全序(相等时再比IntId)，否则同分文档的取舍不确定
+func NewTopK(k int, before func(a, b ScoredDoc) bool) *TopK {
+	return &TopK{k: k, heap: scoredDocHeap{docs: make([]ScoredDoc, 0, k), before: before}}
+}
+
+// Push 尝试放入一个文档，堆满时只有排在堆顶前面的文档才能挤掉堆顶
+func (t *TopK) Push(doc ScoredDoc) {
+	if t.k <= 0 {
+		return
+	}
+	if len(t.heap.docs) < t.k {
+		heap.Push(&t.heap, doc)
+	} else if t.heap.before(doc, t.heap.docs[0]) {
+		t.heap.docs[0] = doc
+		heap.Fix(&t.heap, 0)
+	}
+}
+
+// Sorted 按排序返回堆中的文档
+func (t *TopK) Sorted() []ScoredDoc {
+	result := make([]ScoredDoc, len(t.heap.docs))
+	copy(result, t.heap.docs)
+	sort.Slice(result, func(i, j int) bool {
+		return t.heap.before(result[i], result[j])
+	})
+	return result
+}
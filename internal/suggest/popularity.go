@@ -0,0 +1,89 @@
+package suggest
+
+import (
+	"RADIC/types"
+	"bufio"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// 搜索框的自动补全：候选词来自倒排索引的词典，按文档频率排序，再用检索日志里统计的热度加权
+
+// Score 补全的排序得分。文档频率和热度都取对数，避免极高频的词压倒一切；weight为0时只看文档频率。
+// 各个分片按同样的公式打分，合并时用加总后的文档频率重新计算
+func Score(docFreq int64, popularity int64, weight float64) float64 {
+	return math.Log1p(float64(docFreq)) + weight*math.Log1p(float64(popularity))
+}
+
+// Popularity 每个词被检索的次数，并发安全。nil上可以调用所有方法，即所有词的热度都为0
+type Popularity struct {
+	mu     sync.RWMutex
+	counts map[string]int64 // Keyword.ToString编码的key -> 次数
+}
+
+func NewPopularity() *Popularity {
+	return &Popularity{counts: make(map[string]int64, 1024)}
+}
+
+// Add field下的word被检索了n次
+func (p *Popularity) Add(field string, word string, n int64) {
+	if p == nil || word == "" || n <= 0 {
+		return
+	}
+	key := (&types.Keyword{Field: field, Word: word}).ToString()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.counts[key] += n
+}
+
+// RecordQuery 查询里参与打分的每个keyword都记n次。MustNot下的词不是用户想找的，不记
+func (p *Popularity) RecordQuery(q *types.TermQuery, n int64) {
+	if p == nil || q == nil || n <= 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, term := range q.LeafTerms() {
+		p.counts[term.Key] += n
+	}
+}
+
+// Count field下的word被检索的次数
+func (p *Popularity) Count(field string, word string) int64 {
+	if p == nil {
+		return 0
+	}
+	key := (&types.Keyword{Field: field, Word: word}).ToString()
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.counts[key]
+}
+
+// LogEntry 检索日志里的一行
+type LogEntry struct {
+	Query string
+	Count int64
+}
+
+// ReadSearchLog 读检索日志，每行一个查询字符串(语法见types.ParseQuery)。已经按查询聚合过的日志可以在行首写上次数和制表符，
+// 如 "12\ttitle:go"。空行和#开头的行跳过
+func ReadSearchLog(reader io.Reader, fn func(entry LogEntry)) error {
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entry := LogEntry{Query: line, Count: 1}
+		if count, query, found := strings.Cut(line, "\t"); found {
+			if n, err := strconv.ParseInt(count, 10, 64); err == nil && n > 0 {
+				entry = LogEntry{Query: strings.TrimSpace(query), Count: n}
+			}
+		}
+		fn(entry)
+	}
+	return scanner.Err()
+}
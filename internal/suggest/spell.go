@@ -0,0 +1,165 @@
+package suggest
+
+import (
+	"RADIC/types"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// 零命中时的拼写纠错("您是不是要找")：查询里在整个集群都不存在的词，换成词典里与它编辑距离小、文档频率高的词
+
+const (
+	MAX_SPELL_CANDIDATES  = 5 // 每个拼错的词最多考虑几个候选
+	MAX_SPELL_SUGGESTIONS = 3 // 最多给出几个纠正后的查询
+)
+
+// SpellMaxEdits 纠错时允许的最大编辑距离：只有一个字符的词不纠正，不超过4个字符的词最多改1个字符，更长的最多改2个
+func SpellMaxEdits(word string) int {
+	switch n := utf8.RuneCountInString(word); {
+	case n <= 1:
+		return 0
+	case n <= 4:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Candidate 一个拼错的词的纠正候选
+type Candidate struct {
+	Word     string
+	DocFreq  int64
+	Distance int // 与原词的编辑距离
+}
+
+// Correction 拼错的词(Keyword.ToString编码的key)和它的候选，候选按文档频率从高到低
+type Correction struct {
+	Key        string
+	Candidates []Candidate
+}
+
+// Corrected 纠正后的查询
+type Corrected struct {
+	Query *types.TermQuery
+	Score float64 // 所选候选文档频率的对数之和
+}
+
+// Correct 用候选替换查询里拼错的词，返回最多n个纠正后的查询，按所选候选文档频率的对数之和从高到低。
+// 没有候选的词保持原样，所有词都没有候选时返回空。不修改q
+func Correct(q *types.TermQuery, corrections []Correction, n int) []Corrected {
+	fixable := make([]Correction, 0, len(corrections))
+	for _, c := range corrections {
+		if len(c.Candidates) > 0 {
+			fixable = append(fixable, c)
+		}
+	}
+	if q == nil || len(fixable) == 0 || n <= 0 {
+		return nil
+	}
+	score := func(choice []int) float64 {
+		s := 0.0
+		for i, j := range choice {
+			s += math.Log1p(float64(fixable[i].Candidates[j].DocFreq))
+		}
+		return s
+	}
+
+	// 一个组合是每个词各选第几个候选。从都选第一个开始，每次取出得分最高的组合，再把它的每个词分别换成下一个候选放回去
+	start := make([]int, len(fixable))
+	frontier := [][]int{start}
+	visited := map[string]bool{fmt.Sprint(start): true}
+	result := make([]Corrected, 0, n)
+	for len(result) < n && len(frontier) > 0 {
+		best := 0
+		for i := range frontier {
+			if score(frontier[i]) > score(frontier[best]) {
+				best = i
+			}
+		}
+		choice := frontier[best]
+		frontier = slices.Delete(frontier, best, best+1)
+
+		replace := make(map[string]string, len(fixable))
+		for i, j := range choice {
+			replace[fixable[i].Key] = fixable[i].Candidates[j].Word
+		}
+		result = append(result, Corrected{Query: Replace(q, replace), Score: score(choice)})
+
+		for i := range choice {
+			if choice[i]+1 < len(fixable[i].Candidates) {
+				next := slices.Clone(choice)
+				next[i]++
+				if key := fmt.Sprint(next); !visited[key] {
+					visited[key] = true
+					frontier = append(frontier, next)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Replace 返回把查询里的词换掉之后的查询树，不修改q。replace是Keyword.ToString编码的key -> 新的词(field不变)。
+// 与LeafTerms一致，只换参与打分的词，MustNot下的不换
+func Replace(q *types.TermQuery, replace map[string]string) *types.TermQuery {
+	if q == nil {
+		return nil
+	}
+	if key := q.Key(); key != "" {
+		word, exists := replace[key]
+		if !exists {
+			return q
+		}
+		field, _, _ := strings.Cut(key, "\001")
+		replaced := *q // 查询树可能被调用方复用，改写时复制节点
+		replaced.Term, replaced.Keyword = &types.Keyword{Field: field, Word: word}, ""
+		return &replaced
+	}
+	if q.Phrase != nil {
+		keys := q.Phrase.Keys()
+		if !slices.ContainsFunc(keys, func(key string) bool { _, exists := replace[key]; return exists }) {
+			return q
+		}
+		terms := make([]*types.Keyword, 0, len(keys))
+		for _, key := range keys {
+			field, word, _ := strings.Cut(key, "\001")
+			if w, exists := replace[key]; exists {
+				word = w
+			}
+			terms = append(terms, &types.Keyword{Field: field, Word: word})
+		}
+		replaced := *q
+		replaced.Phrase = &types.PhraseQuery{Terms: terms, Slop: q.Phrase.Slop}
+		return &replaced
+	}
+
+	must, mustChanged := replaceChildren(q.Must, replace)
+	should, shouldChanged := replaceChildren(q.Should, replace)
+	if !mustChanged && !shouldChanged {
+		return q
+	}
+	replaced := *q
+	replaced.Must, replaced.Should = must, should
+	return &replaced
+}
+
+// replaceChildren 没有子节点被改写时返回原切片和false
+func replaceChildren(children []*types.TermQuery, replace map[string]string) ([]*types.TermQuery, bool) {
+	var result []*types.TermQuery
+	for i, child := range children {
+		replaced := Replace(child, replace)
+		if replaced != child && result == nil {
+			result = slices.Clone(children)
+		}
+		if result != nil {
+			result[i] = replaced
+		}
+	}
+	if result == nil {
+		return children, false
+	}
+	return result, true
+}
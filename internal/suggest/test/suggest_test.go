@@ -0,0 +1,100 @@
+package test
+
+import (
+	"RADIC/internal/suggest"
+	"RADIC/types"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPopularity(t *testing.T) {
+	p := suggest.NewPopularity()
+	q, err := types.ParseQuery("title:go AND (title:java OR tag:go) -title:php")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.RecordQuery(q, 2)
+	p.Add("title", "go", 1)
+	if p.Count("title", "go") != 3 || p.Count("tag", "go") != 2 || p.Count("title", "php") != 0 {
+		t.Errorf("counts: go=%d tag:go=%d php=%d", p.Count("title", "go"), p.Count("tag", "go"), p.Count("title", "php"))
+	}
+	var none *suggest.Popularity
+	none.Add("title", "go", 1)
+	if none.Count("title", "go") != 0 {
+		t.Error("nil popularity should count nothing")
+	}
+
+	if suggest.Score(10, 1000, 0) != suggest.Score(10, 0, 0) {
+		t.Error("popularity should not matter with weight 0")
+	}
+	if suggest.Score(10, 1000, 1) <= suggest.Score(100, 0, 1) {
+		t.Error("popular term should rank first with weight 1")
+	}
+}
+
+func TestReadSearchLog(t *testing.T) {
+	log := "# 检索日志\ntitle:go\n\n12\ttitle:java\nx\ttitle:rust\n"
+	var entries []suggest.LogEntry
+	err := suggest.ReadSearchLog(strings.NewReader(log), func(entry suggest.LogEntry) {
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []suggest.LogEntry{{Query: "title:go", Count: 1}, {Query: "title:java", Count: 12}, {Query: "x\ttitle:rust", Count: 1}}
+	if len(entries) != len(want) {
+		t.Fatalf("got %v, want %v", entries, want)
+	}
+	for i := range want {
+		if entries[i] != want[i] {
+			t.Errorf("entry %d got %v, want %v", i, entries[i], want[i])
+		}
+	}
+}
+
+func TestCorrect(t *testing.T) {
+	q, err := types.ParseQuery(`title:golnag AND (tag:jvaa OR tag:go) -title:phq`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := q.ToQueryString()
+	corrections := []suggest.Correction{
+		{Key: "title\001golnag", Candidates: []suggest.Candidate{{Word: "golang", DocFreq: 100, Distance: 2}, {Word: "gonag", DocFreq: 10, Distance: 1}}},
+		{Key: "tag\001jvaa", Candidates: []suggest.Candidate{{Word: "java", DocFreq: 50, Distance: 2}, {Word: "jaa", DocFreq: 2, Distance: 1}}},
+		{Key: "title\001phq", Candidates: []suggest.Candidate{{Word: "php", DocFreq: 10, Distance: 1}}}, // MustNot下的词不换
+		{Key: "title\001zzz"}, // 没有候选
+	}
+	corrected := suggest.Correct(q, corrections, 3)
+	got := make([]string, 0, len(corrected))
+	for _, c := range corrected {
+		got = append(got, c.Query.ToQueryString())
+	}
+	want := []string{
+		`title:golang AND (tag:java OR tag:go) -title:phq`,
+		`title:gonag AND (tag:java OR tag:go) -title:phq`,
+		`title:golang AND (tag:jaa OR tag:go) -title:phq`,
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Correct got %q, want %q", got, want)
+	}
+	if corrected[0].Score <= corrected[1].Score || corrected[1].Score <= corrected[2].Score {
+		t.Errorf("suggestions should be sorted by score: %v", corrected)
+	}
+	if q.ToQueryString() != original {
+		t.Errorf("Correct modified the original query: %s", q.ToQueryString())
+	}
+
+	phrase := types.NewPhraseQuery(1, &types.Keyword{Field: "title", Word: "serch"}, &types.Keyword{Field: "title", Word: "engine"})
+	replaced := suggest.Replace(phrase, map[string]string{"title\001serch": "search"})
+	if replaced.Phrase.Keys()[0] != "title\001search" || replaced.Phrase.Slop != 1 || phrase.Phrase.Terms[0].Word != "serch" {
+		t.Errorf("Replace phrase got %s", replaced.ToQueryString())
+	}
+	if suggest.Correct(q, corrections[3:], 3) != nil {
+		t.Error("no suggestion without candidates")
+	}
+
+	if suggest.SpellMaxEdits("a") != 0 || suggest.SpellMaxEdits("搜索") != 1 || suggest.SpellMaxEdits("golang") != 2 {
+		t.Error("SpellMaxEdits")
+	}
+}
@@ -0,0 +1,244 @@
+package synonym
+
+import (
+	"RADIC/types"
+	"bufio"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 查询时的同义词扩展：把查询树里的叶子keyword改写成Should组，建索引时不需要做任何处理，改了同义词也不用重建索引
+//
+// 同义词文件每行一条规则，#之后是注释：
+//
+//	golang, go              // 等价词：查其中任何一个都扩展成整组
+//	k8s => kubernetes       // 单向：左边的词替换成右边的词，要保留原词就把它也写在右边，如 k8s => k8s, kubernetes
+//	ny, new york            // 多词同义词：用空格分隔的多个词扩展成短语查询(要求建索引时带了位置)
+//
+// 同一个词出现在多条规则里时，扩展出的词取并集。词区分大小写，与建索引时的word完全一致才会扩展
+
+// rules 解析后的同义词规则，加载后不再修改，重新加载时整体替换
+type rules struct {
+	expansions map[string][][]string // 词(多词用空格连接) -> 扩展成的词，每个元素是一个词或一个短语的各个词
+}
+
+// Dict 从本地文件加载的同义词词典，可以在运行时重新加载。nil上可以调用所有方法，即不做扩展
+type Dict struct {
+	path    string
+	rules   atomic.Pointer[rules]
+	modTime atomic.Int64 // 上次加载时文件的修改时间，Watch用来判断文件有没有变
+	stop    chan struct{}
+	once    sync.Once
+}
+
+// LoadDict 从path加载同义词词典，文件不存在或有语法错误时返回error
+func LoadDict(path string) (*Dict, error) {
+	dict := &Dict{path: path, stop: make(chan struct{})}
+	if err := dict.Reload(); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+// Reload 重新加载同义词文件。失败时继续使用之前的规则，正在进行的检索不受影响
+func (dict *Dict) Reload() error {
+	if dict == nil {
+		return nil
+	}
+	f, err := os.Open(dict.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	r, err := parseRules(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", dict.path, err)
+	}
+	dict.rules.Store(r)
+	dict.modTime.Store(info.ModTime().UnixNano())
+	slog.Info("load synonyms", slog.String("path", dict.path), slog.Int("words", len(r.expansions)))
+	return nil
+}
+
+// Watch 每隔interval检查一次文件的修改时间，变了就重新加载，直到调用Close
+func (dict *Dict) Watch(interval time.Duration) {
+	if dict == nil || interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-dict.stop:
+				return
+			case <-ticker.C:
+				info, err := os.Stat(dict.path)
+				if err != nil || info.ModTime().UnixNano() == dict.modTime.Load() {
+					continue
+				}
+				if err := dict.Reload(); err != nil {
+					slog.Warn("reload synonyms failed", slog.Any("err", err))
+				}
+			}
+		}
+	}()
+}
+
+// Close 停止Watch
+func (dict *Dict) Close() {
+	if dict != nil {
+		dict.once.Do(func() { close(dict.stop) })
+	}
+}
+
+// parseRules 解析同义词文件，出错时返回第几行
+func parseRules(reader io.Reader) (*rules, error) {
+	r := &rules{expansions: make(map[string][][]string, 64)}
+	scanner := bufio.NewScanner(reader)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var from, to [][]string
+		var err error
+		if left, right, found := strings.Cut(line, "=>"); found {
+			if from, err = parseWords(left); err == nil {
+				to, err = parseWords(right)
+			}
+		} else {
+			from, err = parseWords(line)
+			to = from
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+		for _, words := range from {
+			key := strings.Join(words, " ")
+			for _, synonym := range to {
+				if !slices.ContainsFunc(r.expansions[key], func(s []string) bool { return slices.Equal(s, synonym) }) {
+					r.expansions[key] = append(r.expansions[key], synonym)
+				}
+			}
+		}
+	}
+	return r, scanner.Err()
+}
+
+// parseWords 解析逗号分隔的一组词，每个词可以是空格分隔的多个词
+func parseWords(text string) ([][]string, error) {
+	items := strings.Split(text, ",")
+	result := make([][]string, 0, len(items))
+	for _, item := range items {
+		words := strings.Fields(item)
+		if len(words) == 0 {
+			return nil, fmt.Errorf("empty synonym in %q", strings.TrimSpace(text))
+		}
+		result = append(result, words)
+	}
+	return result, nil
+}
+
+// Expand 返回同义词扩展后的查询树，不修改q。没有需要扩展的词时原样返回q
+func (dict *Dict) Expand(q *types.TermQuery) *types.TermQuery {
+	if dict == nil || q == nil {
+		return q
+	}
+	r := dict.rules.Load()
+	if r == nil || len(r.expansions) == 0 {
+		return q
+	}
+	return r.expand(q)
+}
+
+func (r *rules) expand(q *types.TermQuery) *types.TermQuery {
+	field, words := leafWords(q)
+	if synonyms, exists := r.expansions[strings.Join(words, " ")]; exists && len(words) > 0 {
+		// 叶子节点上的Must、Should不参与求值，扩展后也不需要保留
+		should := make([]*types.TermQuery, 0, len(synonyms))
+		for _, synonym := range synonyms {
+			should = append(should, newQuery(field, synonym))
+		}
+		mustNot, _ := r.expandChildren(q.MustNot)
+		return &types.TermQuery{Should: should, MustNot: mustNot, Boost: q.Boost}
+	}
+
+	must, mustChanged := r.expandChildren(q.Must)
+	should, shouldChanged := r.expandChildren(q.Should)
+	mustNot, mustNotChanged := r.expandChildren(q.MustNot)
+	if !mustChanged && !shouldChanged && !mustNotChanged {
+		return q
+	}
+	expanded := *q // 查询树可能被调用方复用，改写时复制节点
+	expanded.Must, expanded.Should, expanded.MustNot = must, should, mustNot
+	return &expanded
+}
+
+// expandChildren 没有子节点被改写时返回原切片和false
+func (r *rules) expandChildren(children []*types.TermQuery) ([]*types.TermQuery, bool) {
+	var result []*types.TermQuery
+	for i, child := range children {
+		expanded := r.expand(child)
+		if expanded != child && result == nil {
+			result = slices.Clone(children)
+		}
+		if result != nil {
+			result[i] = expanded
+		}
+	}
+	if result == nil {
+		return children, false
+	}
+	return result, true
+}
+
+// leafWords 可以扩展的叶子节点的field和词：单个keyword，或者同一个field下的精确短语
+func leafWords(q *types.TermQuery) (string, []string) {
+	if key := q.Key(); key != "" {
+		field, word, found := strings.Cut(key, "\001")
+		if !found {
+			return "", nil
+		}
+		return field, []string{word}
+	}
+	if q.Phrase == nil || q.Phrase.Slop != 0 {
+		return "", nil
+	}
+	var field string
+	keys := q.Phrase.Keys()
+	words := make([]string, 0, len(keys))
+	for i, key := range keys {
+		f, word, found := strings.Cut(key, "\001")
+		if !found || (i > 0 && f != field) {
+			return "", nil
+		}
+		field = f
+		words = append(words, word)
+	}
+	return field, words
+}
+
+// newQuery 单个词是keyword查询，多个词是精确短语查询
+func newQuery(field string, words []string) *types.TermQuery {
+	if len(words) == 1 {
+		return types.NewTermQuery(field, words[0])
+	}
+	keywords := make([]*types.Keyword, 0, len(words))
+	for _, word := range words {
+		keywords = append(keywords, &types.Keyword{Field: field, Word: word})
+	}
+	return types.NewPhraseQuery(0, keywords...)
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"RADIC/internal/reverse_index"
+	"RADIC/internal/synonym"
+	"RADIC/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func loadDict(t *testing.T, content string) *synonym.Dict {
+	path := filepath.Join(t.TempDir(), "synonyms.txt")
+	writeFile(t, path, content)
+	dict, err := synonym.LoadDict(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dict
+}
+
+func tag(word string) *types.TermQuery {
+	return types.NewTermQuery("tag", word)
+}
+
+func TestExpand(t *testing.T) {
+	dict := loadDict(t, `
+# 编程语言
+golang, go
+k8s => kubernetes   # 单向替换
+ny, new york
+`)
+	tests := []struct {
+		query *types.TermQuery
+		want  string
+	}{
+		{tag("golang"), "tag:golang OR tag:go"},
+		{tag("go"), "tag:golang OR tag:go"},
+		{tag("k8s"), "tag:kubernetes"},
+		{tag("kubernetes"), "tag:kubernetes"},
+		{tag("ny"), "tag:ny OR tag:[new york]"},
+		{types.NewPhraseQuery(0, &types.Keyword{Field: "tag", Word: "new"}, &types.Keyword{Field: "tag", Word: "york"}), "tag:ny OR tag:[new york]"},
+		{tag("java").And(tag("golang").WithBoost(2)).Not(tag("k8s")), "tag:java AND (tag:golang OR tag:go)^2 -(tag:kubernetes)"},
+		{types.AtLeast(2, tag("go"), tag("java"), tag("rust")), "((tag:golang OR tag:go) OR tag:java OR tag:rust)~2"},
+		{types.NewPrefixQuery("tag", "go", 0), "tag:go*"},
+	}
+	for _, test := range tests {
+		before := test.query.String()
+		if got := dict.Expand(test.query).ToQueryString(); got != test.want {
+			t.Errorf("Expand(%s) = %s, want %s", test.query.ToQueryString(), got, test.want)
+		}
+		if test.query.String() != before {
+			t.Errorf("Expand modified the query: %s", test.query.String())
+		}
+	}
+
+	unchanged := tag("java").And(tag("rust"))
+	if dict.Expand(unchanged) != unchanged {
+		t.Error("query without synonyms should be returned as is")
+	}
+	var none *synonym.Dict
+	if none.Expand(unchanged) != unchanged {
+		t.Error("nil dict should not expand")
+	}
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synonyms.txt")
+	writeFile(t, path, "golang, go\n")
+	dict, err := synonym.LoadDict(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dict.Close()
+
+	// 语法错误时继续使用之前的同义词
+	writeFile(t, path, "golang, go\nk8s =>\n")
+	if err := dict.Reload(); err == nil {
+		t.Error("reload with syntax error should fail")
+	}
+	if got := dict.Expand(tag("golang")).ToQueryString(); got != "tag:golang OR tag:go" {
+		t.Errorf("after failed reload got %s", got)
+	}
+	if _, err := synonym.LoadDict(path); err == nil {
+		t.Error("load with syntax error should fail")
+	}
+
+	// 文件变了Watch会自动重新加载
+	dict.Watch(10 * time.Millisecond)
+	writeFile(t, path, "k8s, kubernetes\n")
+	os.Chtimes(path, time.Now(), time.Now().Add(time.Second)) // 有的文件系统时间精度很低，确保修改时间变了
+	deadline := time.Now().Add(2 * time.Second)
+	for dict.Expand(tag("k8s")).ToQueryString() != "tag:k8s OR tag:kubernetes" {
+		if time.Now().After(deadline) {
+			t.Fatal("synonyms were not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := dict.Expand(tag("golang")).ToQueryString(); got != "tag:golang" {
+		t.Errorf("old synonyms still used: %s", got)
+	}
+}
+
+func TestSearchWithSynonyms(t *testing.T) {
+	dict := loadDict(t, "golang, go\n")
+	indexer := reverse_index.NewSkipListReverseIndex(10)
+	indexer.Add(types.Document{Id: "a", IntId: 1, Keywords: []*types.Keyword{{Field: "tag", Word: "go"}}})
+	indexer.Add(types.Document{Id: "b", IntId: 2, Keywords: []*types.Keyword{{Field: "tag", Word: "golang"}}})
+	indexer.Add(types.Document{Id: "c", IntId: 3, Keywords: []*types.Keyword{{Field: "tag", Word: "java"}}})
+
+	ids := func(hits reverse_index.Hits) []string {
+		result := make([]string, 0, len(hits.Docs))
+		for _, doc := range hits.Docs {
+			result = append(result, doc.Id)
+		}
+		return result
+	}
+	if got := ids(indexer.Search(tag("golang"), 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("without synonyms got %v", got)
+	}
+	if got := ids(indexer.Search(dict.Expand(tag("golang")), 0, 0, nil, nil, nil, false, reverse_index.Page{})); !slices.Equal(got, []string{"a", "b"}) {
+		t.Errorf("with synonyms got %v", got)
+	}
+}
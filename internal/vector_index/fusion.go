@@ -0,0 +1,39 @@
+package vector_index
+
+import (
+	"RADIC/internal/reverse_index"
+	"cmp"
+	"slices"
+)
+
+const (
+	RRF_K = 60 // 倒数排名融合的平滑常数，越大排名靠前和靠后的文档得分差得越少
+)
+
+// FuseRRF 倒数排名融合(Reciprocal Rank Fusion)：文档的得分是它在每一路结果里的1/(RRF_K+排名)之和，排名从1开始，
+// 没出现在某一路里的不计分。只看排名不看原始得分，BM25和余弦相似度不在一个量纲上也能合并。
+// 按得分从高到低，相同时按IntId
+func FuseRRF(lists ...[]reverse_index.ScoredDoc) []reverse_index.ScoredDoc {
+	fused := make(map[uint64]*reverse_index.ScoredDoc, 256)
+	for _, list := range lists {
+		for rank, doc := range list {
+			score := 1 / float64(RRF_K+rank+1)
+			if d, exists := fused[doc.IntId]; exists {
+				d.Score += score
+			} else {
+				fused[doc.IntId] = &reverse_index.ScoredDoc{Id: doc.Id, IntId: doc.IntId, Score: score}
+			}
+		}
+	}
+	docs := make([]reverse_index.ScoredDoc, 0, len(fused))
+	for _, doc := range fused {
+		docs = append(docs, *doc)
+	}
+	slices.SortFunc(docs, func(a, b reverse_index.ScoredDoc) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.IntId, b.IntId)
+	})
+	return docs
+}
@@ -0,0 +1,367 @@
+package vector_index
+
+import (
+	"RADIC/internal/reverse_index"
+	"RADIC/types"
+	"cmp"
+	"container/heap"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// 向量检索(语义检索)：HNSW(Hierarchical Navigable Small World)近似最近邻索引，纯内存、只用CPU。
+// 向量入库时归一化，相似度是余弦相似度(即归一化之后的内积)，距离是1-相似度
+
+const (
+	HNSW_M                 = 16   // 每个节点在每一层最多的邻居数，第0层是2倍
+	HNSW_EF_CONSTRUCTION   = 200  // 建索引时候选队列的长度，越大图的质量越好、建得越慢
+	HNSW_EF_SEARCH         = 64   // 检索时候选队列的默认长度，不会小于k
+	EXACT_SEARCH_THRESHOLD = 2048 // 限定了候选文档且不超过这么多时，直接逐个计算相似度，比在图上找更快也更准
+	REBUILD_MIN_DELETED    = 1024 // 删除的节点超过这么多、且超过一半时重建
+	MAX_LEVEL              = 16
+)
+
+// Filter 与倒排索引相同的过滤条件，只有通过过滤的文档才会出现在结果里。nil表示不过滤
+type Filter struct {
+	OnFlag  uint64
+	OffFlag uint64
+	OrFlags []uint64
+	Ranges  *types.RangeFilter
+	IntIds  map[uint64]struct{} // 不为nil时只在这些文档里找，比如命中了TermQuery的文档
+}
+
+func (f *Filter) accept(n *node) bool {
+	if f == nil {
+		return true
+	}
+	if f.IntIds != nil {
+		if _, exists := f.IntIds[n.intId]; !exists {
+			return false
+		}
+	}
+	return reverse_index.FilterByBits(n.bits, f.OnFlag, f.OffFlag, f.OrFlags) && f.Ranges.Match(n.ints, n.floats)
+}
+
+// node 图上的一个文档，带着过滤要用的属性
+type node struct {
+	id      string
+	intId   uint64
+	bits    uint64
+	ints    map[string]int64
+	floats  map[string]float64
+	vector  []float32  // 归一化之后的向量
+	friends [][]uint64 // 每一层的邻居
+	deleted bool       // 删除只打标记，节点继续留在图上给检索引路
+}
+
+// HNSW 并发安全的近似最近邻索引，所有向量的维度必须相同，维度由第一个加入的向量决定
+type HNSW struct {
+	mu       sync.RWMutex
+	dim      int
+	nodes    map[uint64]*node // IntId -> 节点
+	entry    uint64           // 最高层的入口节点
+	maxLevel int
+	deleted  int
+	rng      *rand.Rand
+}
+
+func NewHNSW(DocNumEstimate int) *HNSW {
+	return &HNSW{nodes: make(map[uint64]*node, DocNumEstimate), rng: rand.New(rand.NewPCG(1, 2))}
+}
+
+// Len 没被删除的文档数
+func (index *HNSW) Len() int {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return len(index.nodes) - index.deleted
+}
+
+// Check 检查向量能不能加入索引：不能有NaN、Inf，不能全是0，维度要与已有的向量一致。空向量表示文档没有向量，是合法的
+func (index *HNSW) Check(vector []float32) error {
+	if len(vector) == 0 {
+		return nil
+	}
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	return index.check(vector)
+}
+
+func (index *HNSW) check(vector []float32) error {
+	if index.dim > 0 && len(vector) != index.dim {
+		return fmt.Errorf("vector has %d dimensions, want %d", len(vector), index.dim)
+	}
+	return (&types.VectorQuery{Vector: vector}).Validate()
+}
+
+// Add 把文档的向量加入索引，文档没有向量时什么也不做。检查和插入在同一把锁里，并发加入不同维度的向量时只有一个能成功
+func (index *HNSW) Add(doc types.Document) error {
+	if len(doc.Vector) == 0 {
+		return nil
+	}
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	if err := index.check(doc.Vector); err != nil {
+		return fmt.Errorf("document %s: %w", doc.Id, err)
+	}
+	if _, exists := index.nodes[doc.IntId]; exists {
+		return nil
+	}
+	index.dim = len(doc.Vector)
+	index.insert(&node{
+		id:     doc.Id,
+		intId:  doc.IntId,
+		bits:   doc.BitsFeature,
+		ints:   doc.IntFeatures,
+		floats: doc.FloatFeatures,
+		vector: normalize(doc.Vector),
+	})
+	return nil
+}
+
+// Delete 删除文档的向量。删掉的节点超过一半时用剩下的节点重建，否则图上无效的路标太多，检索变慢
+func (index *HNSW) Delete(intId uint64) {
+	index.mu.Lock()
+	defer index.mu.Unlock()
+	n, exists := index.nodes[intId]
+	if !exists || n.deleted {
+		return
+	}
+	n.deleted = true
+	index.deleted++
+	if index.deleted == len(index.nodes) {
+		index.reset()
+	} else if index.deleted > REBUILD_MIN_DELETED && index.deleted*2 > len(index.nodes) {
+		index.rebuild()
+	}
+}
+
+// Search 返回与vector余弦相似度最高、且通过filter的k个文档，按相似度从高到低，Score是相似度。
+// ef是候选队列的长度，为0时用默认值
+func (index *HNSW) Search(vector []float32, k int, ef int, filter *Filter) ([]reverse_index.ScoredDoc, error) {
+	index.mu.RLock()
+	defer index.mu.RUnlock()
+	if k <= 0 || len(index.nodes) == 0 {
+		return nil, nil
+	}
+	if err := index.check(vector); err != nil {
+		return nil, err
+	}
+	q := normalize(vector)
+	accept := func(n *node) bool { return !n.deleted && filter.accept(n) }
+
+	var found []candidate
+	if filter != nil && filter.IntIds != nil && len(filter.IntIds) <= EXACT_SEARCH_THRESHOLD {
+		found = make([]candidate, 0, len(filter.IntIds))
+		for intId := range filter.IntIds {
+			if n, exists := index.nodes[intId]; exists && accept(n) {
+				found = append(found, candidate{intId, distance(q, n.vector)})
+			}
+		}
+	} else {
+		if ef <= 0 {
+			ef = HNSW_EF_SEARCH
+		}
+		ep := []candidate{{index.entry, distance(q, index.nodes[index.entry].vector)}}
+		for level := index.maxLevel; level > 0; level-- {
+			ep = closest(index.searchLayer(q, ep, 1, level, nil), 1)
+		}
+		found = index.searchLayer(q, ep, max(ef, k), 0, accept)
+	}
+
+	found = closest(found, k)
+	docs := make([]reverse_index.ScoredDoc, 0, len(found))
+	for _, c := range found {
+		docs = append(docs, reverse_index.ScoredDoc{Id: index.nodes[c.intId].id, IntId: c.intId, Score: 1 - float64(c.dist)})
+	}
+	return docs, nil
+}
+
+// insert 从最高层往下逐层贪心地找离新节点最近的节点，在新节点所在的每一层上连到最近的几个节点
+func (index *HNSW) insert(n *node) {
+	level := index.randomLevel()
+	n.friends = make([][]uint64, level+1)
+	if len(index.nodes) == 0 {
+		index.nodes[n.intId] = n
+		index.entry, index.maxLevel = n.intId, level
+		return
+	}
+	index.nodes[n.intId] = n
+
+	live := func(n *node) bool { return !n.deleted }
+	ep := []candidate{{index.entry, distance(n.vector, index.nodes[index.entry].vector)}}
+	for l := index.maxLevel; l > level; l-- {
+		ep = closest(index.searchLayer(n.vector, ep, 1, l, nil), 1)
+	}
+	for l := min(level, index.maxLevel); l >= 0; l-- {
+		candidates := index.searchLayer(n.vector, ep, HNSW_EF_CONSTRUCTION, l, live)
+		for _, c := range closest(slices.Clone(candidates), maxFriends(l)) {
+			n.friends[l] = append(n.friends[l], c.intId)
+			friend := index.nodes[c.intId]
+			friend.friends[l] = append(friend.friends[l], n.intId)
+			if len(friend.friends[l]) > maxFriends(l) {
+				index.shrink(friend, l)
+			}
+		}
+		if len(candidates) > 0 {
+			ep = candidates
+		}
+	}
+	if level > index.maxLevel {
+		index.entry, index.maxLevel = n.intId, level
+	}
+}
+
+// shrink 邻居超过上限时只保留最近的
+func (index *HNSW) shrink(n *node, level int) {
+	candidates := make([]candidate, 0, len(n.friends[level]))
+	for _, intId := range n.friends[level] {
+		candidates = append(candidates, candidate{intId, distance(n.vector, index.nodes[intId].vector)})
+	}
+	n.friends[level] = n.friends[level][:0]
+	for _, c := range closest(candidates, maxFriends(level)) {
+		n.friends[level] = append(n.friends[level], c.intId)
+	}
+}
+
+// searchLayer 在一层上从ep出发做最佳优先搜索，返回最近的ef个通过accept(为nil时都通过)的节点，不保证顺序。
+// 没通过accept的节点也会被扩展，所以过滤条件很严时会遍历大部分节点，但结果不会因为过滤而变少
+func (index *HNSW) searchLayer(q []float32, ep []candidate, ef int, level int, accept func(n *node) bool) []candidate {
+	visited := make(map[uint64]struct{}, ef*4)
+	candidates := &candidateHeap{}            // 待扩展的节点，最近的在堆顶
+	results := &candidateHeap{farthest: true} // 当前最近的ef个节点，最远的在堆顶
+	for _, c := range ep {
+		visited[c.intId] = struct{}{}
+		heap.Push(candidates, c)
+		if accept == nil || accept(index.nodes[c.intId]) {
+			heap.Push(results, c)
+		}
+	}
+	for candidates.Len() > 0 {
+		c := heap.Pop(candidates).(candidate)
+		if results.Len() >= ef && c.dist > results.items[0].dist {
+			break
+		}
+		n := index.nodes[c.intId]
+		if level >= len(n.friends) {
+			continue
+		}
+		for _, intId := range n.friends[level] {
+			if _, exists := visited[intId]; exists {
+				continue
+			}
+			visited[intId] = struct{}{}
+			friend := index.nodes[intId]
+			d := distance(q, friend.vector)
+			if results.Len() < ef || d < results.items[0].dist {
+				heap.Push(candidates, candidate{intId, d})
+				if accept == nil || accept(friend) {
+					heap.Push(results, candidate{intId, d})
+					if results.Len() > ef {
+						heap.Pop(results)
+					}
+				}
+			}
+		}
+	}
+	return results.items
+}
+
+// rebuild 用没删除的节点重建图，按IntId的顺序重新插入
+func (index *HNSW) rebuild() {
+	live := make([]*node, 0, len(index.nodes)-index.deleted)
+	for _, n := range index.nodes {
+		if !n.deleted {
+			live = append(live, n)
+		}
+	}
+	slices.SortFunc(live, func(a, b *node) int { return cmp.Compare(a.intId, b.intId) })
+	index.reset()
+	index.dim = len(live[0].vector)
+	for _, n := range live {
+		index.insert(n)
+	}
+}
+
+// reset 清空索引，之后可以加入其他维度的向量
+func (index *HNSW) reset() {
+	index.nodes = make(map[uint64]*node, len(index.nodes))
+	index.entry, index.maxLevel, index.deleted, index.dim = 0, 0, 0, 0
+}
+
+// randomLevel 节点所在的最高层，按指数分布，越高层节点越少
+func (index *HNSW) randomLevel() int {
+	level := int(math.Floor(-math.Log(1-index.rng.Float64()) / math.Log(HNSW_M)))
+	return min(level, MAX_LEVEL)
+}
+
+func maxFriends(level int) int {
+	if level == 0 {
+		return 2 * HNSW_M
+	}
+	return HNSW_M
+}
+
+// normalize 归一化成单位向量，调用方保证不是零向量
+func normalize(vector []float32) []float32 {
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	norm = math.Sqrt(norm)
+	result := make([]float32, len(vector))
+	for i, v := range vector {
+		result[i] = float32(float64(v) / norm)
+	}
+	return result
+}
+
+// distance 两个单位向量的余弦距离
+func distance(a, b []float32) float32 {
+	var dot float32
+	for i := range a {
+		dot += a[i] * b[i]
+	}
+	return 1 - dot
+}
+
+type candidate struct {
+	intId uint64
+	dist  float32
+}
+
+// closest 最近的n个，按距离从小到大，距离相同时按IntId。会打乱candidates的顺序
+func closest(candidates []candidate, n int) []candidate {
+	slices.SortFunc(candidates, func(a, b candidate) int {
+		if c := cmp.Compare(a.dist, b.dist); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.intId, b.intId)
+	})
+	return candidates[:min(n, len(candidates))]
+}
+
+// candidateHeap farthest为false时最近的在堆顶，为true时最远的在堆顶
+type candidateHeap struct {
+	items    []candidate
+	farthest bool
+}
+
+func (h candidateHeap) Len() int { return len(h.items) }
+func (h candidateHeap) Less(i, j int) bool {
+	if h.farthest {
+		return h.items[i].dist > h.items[j].dist
+	}
+	return h.items[i].dist < h.items[j].dist
+}
+func (h candidateHeap) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *candidateHeap) Push(x any)   { h.items = append(h.items, x.(candidate)) }
+func (h *candidateHeap) Pop() any {
+	old := h.items
+	n := len(old)
+	x := old[n-1]
+	h.items = old[:n-1]
+	return x
+}
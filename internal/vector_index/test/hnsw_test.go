@@ -0,0 +1,197 @@
+package test
+
+import (
+	"RADIC/internal/reverse_index"
+	"RADIC/internal/vector_index"
+	"RADIC/types"
+	"cmp"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func randomVector(rng *rand.Rand, dim int) []float32 {
+	vector := make([]float32, dim)
+	for i := range vector {
+		vector[i] = rng.Float32()*2 - 1
+	}
+	return vector
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func TestHNSWRecall(t *testing.T) {
+	const n, dim, k = 2000, 16, 10
+	rng := rand.New(rand.NewPCG(7, 8))
+	index := vector_index.NewHNSW(n)
+	vectors := make([][]float32, n+1)
+	for i := 1; i <= n; i++ {
+		vectors[i] = randomVector(rng, dim)
+		doc := types.Document{Id: fmt.Sprint(i), IntId: uint64(i), BitsFeature: uint64(i % 2), Vector: vectors[i]}
+		if err := index.Add(doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 与暴力计算的结果比较召回率
+	exact := func(q []float32, accept func(i int) bool) []uint64 {
+		ids := make([]uint64, 0, n)
+		for i := 1; i <= n; i++ {
+			if accept(i) {
+				ids = append(ids, uint64(i))
+			}
+		}
+		slices.SortFunc(ids, func(a, b uint64) int { return cmp.Compare(cosine(q, vectors[b]), cosine(q, vectors[a])) })
+		return ids[:k]
+	}
+	recall := func(filter *vector_index.Filter, accept func(i int) bool) float64 {
+		hit, total := 0, 0
+		for range 50 {
+			q := randomVector(rng, dim)
+			docs, err := index.Search(q, k, 0, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, doc := range docs {
+				if !accept(int(doc.IntId)) {
+					t.Fatalf("doc %d should be filtered", doc.IntId)
+				}
+			}
+			for _, id := range exact(q, accept) {
+				total++
+				if slices.ContainsFunc(docs, func(doc reverse_index.ScoredDoc) bool { return doc.IntId == id }) {
+					hit++
+				}
+			}
+		}
+		return float64(hit) / float64(total)
+	}
+	if r := recall(nil, func(int) bool { return true }); r < 0.9 {
+		t.Errorf("recall %.2f", r)
+	}
+	if r := recall(&vector_index.Filter{OnFlag: 1}, func(i int) bool { return i%2 == 1 }); r < 0.9 {
+		t.Errorf("recall with flag filter %.2f", r)
+	}
+
+	// 限定了候选文档时逐个计算，结果是精确的
+	intIds := map[uint64]struct{}{}
+	for i := 1; i <= n; i += 30 {
+		intIds[uint64(i)] = struct{}{}
+	}
+	q := randomVector(rng, dim)
+	docs, _ := index.Search(q, k, 0, &vector_index.Filter{IntIds: intIds})
+	want := exact(q, func(i int) bool { _, exists := intIds[uint64(i)]; return exists })
+	got := make([]uint64, 0, len(docs))
+	for _, doc := range docs {
+		got = append(got, doc.IntId)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("search among ids got %v, want %v", got, want)
+	}
+}
+
+func TestHNSW(t *testing.T) {
+	index := vector_index.NewHNSW(10)
+	add := func(intId uint64, ints map[string]int64, vector ...float32) {
+		if err := index.Add(types.Document{Id: fmt.Sprint(intId), IntId: intId, IntFeatures: ints, Vector: vector}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add(1, map[string]int64{"view": 10}, 1, 0)
+	add(2, map[string]int64{"view": 20}, 1, 1)
+	add(3, nil, 0, 1)
+	add(4, nil) // 没有向量
+
+	ids := func(docs []reverse_index.ScoredDoc) []string {
+		result := make([]string, 0, len(docs))
+		for _, doc := range docs {
+			result = append(result, doc.Id)
+		}
+		return result
+	}
+	docs, err := index.Search([]float32{2, 0.1}, 10, 0, nil)
+	if err != nil || !slices.Equal(ids(docs), []string{"1", "2", "3"}) || docs[0].Score < 0.99 {
+		t.Errorf("search got %v %v", docs, err)
+	}
+	ranges := &types.RangeFilter{Ints: []*types.IntRange{types.NewIntRange("view").Gte(15)}}
+	if docs, _ := index.Search([]float32{1, 0}, 10, 0, &vector_index.Filter{Ranges: ranges}); !slices.Equal(ids(docs), []string{"2"}) {
+		t.Errorf("search with ranges got %v", docs)
+	}
+
+	if _, err := index.Search([]float32{1, 0, 0}, 10, 0, nil); err == nil {
+		t.Error("query with wrong dimensions should fail")
+	}
+	if err := index.Check([]float32{1, 0, 0}); err == nil {
+		t.Error("vector with wrong dimensions should fail")
+	}
+	if err := index.Check([]float32{0, 0}); err == nil {
+		t.Error("zero vector should fail")
+	}
+
+	index.Delete(1)
+	if docs, _ := index.Search([]float32{1, 0}, 10, 0, nil); !slices.Equal(ids(docs), []string{"2", "3"}) || index.Len() != 2 {
+		t.Errorf("search after delete got %v", docs)
+	}
+	index.Delete(2)
+	index.Delete(3)
+	if index.Len() != 0 || index.Check([]float32{1, 0, 0}) != nil {
+		t.Error("empty index should accept any dimensions")
+	}
+}
+
+func TestHNSWRebuild(t *testing.T) {
+	const n = 2000
+	rng := rand.New(rand.NewPCG(1, 1))
+	index := vector_index.NewHNSW(n)
+	for i := 1; i <= n; i++ {
+		index.Add(types.Document{Id: fmt.Sprint(i), IntId: uint64(i), Vector: randomVector(rng, 8)})
+	}
+	for i := 1; i <= n; i++ {
+		if i%4 != 0 {
+			index.Delete(uint64(i)) // 删掉一半以上时会重建
+		}
+	}
+	if index.Len() != n/4 {
+		t.Fatalf("len %d", index.Len())
+	}
+	docs, _ := index.Search(randomVector(rng, 8), 20, 0, nil)
+	if len(docs) != 20 {
+		t.Fatalf("search after rebuild got %d docs", len(docs))
+	}
+	for _, doc := range docs {
+		if doc.IntId%4 != 0 {
+			t.Errorf("deleted doc %d returned", doc.IntId)
+		}
+	}
+}
+
+func TestFuseRRF(t *testing.T) {
+	doc := func(intId uint64) reverse_index.ScoredDoc {
+		return reverse_index.ScoredDoc{Id: fmt.Sprint(intId), IntId: intId, Score: 100}
+	}
+	fused := vector_index.FuseRRF(
+		[]reverse_index.ScoredDoc{doc(1), doc(2), doc(3)},
+		[]reverse_index.ScoredDoc{doc(3), doc(4)},
+	)
+	got := make([]uint64, 0, len(fused))
+	for _, d := range fused {
+		got = append(got, d.IntId)
+	}
+	// 3在两路里都有，排在最前面；1排第一；2和4都排第二，得分相同时按IntId
+	if !slices.Equal(got, []uint64{3, 1, 2, 4}) {
+		t.Errorf("FuseRRF got %v", got)
+	}
+	if want := 1.0/63 + 1.0/61; fused[0].Score != want {
+		t.Errorf("score of 3 got %v, want %v", fused[0].Score, want)
+	}
+}
@@ -8,3 +8,11 @@ func (kw *Keyword) ToString() string {
 		return ""
 	}
 }
+
+// EffectiveWeight 建索引时的权重，没设置(<=0)时为1
+func (kw *Keyword) EffectiveWeight() float64 {
+	if kw.Weight <= 0 {
+		return 1
+	}
+	return float64(kw.Weight)
+}
@@ -1,9 +1,10 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: doc.proto
+// source: types/doc.proto
 
 package types
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	io "io"
@@ -23,15 +24,17 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Keyword struct {
-	Field string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
-	Word  string `protobuf:"bytes,2,opt,name=Word,proto3" json:"Word,omitempty"`
+	Field     string  `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Word      string  `protobuf:"bytes,2,opt,name=Word,proto3" json:"Word,omitempty"`
+	Positions []int32 `protobuf:"varint,3,rep,packed,name=Positions,proto3" json:"Positions,omitempty"`
+	Weight    float32 `protobuf:"fixed32,4,opt,name=Weight,proto3" json:"Weight,omitempty"`
 }
 
 func (m *Keyword) Reset()         { *m = Keyword{} }
 func (m *Keyword) String() string { return proto.CompactTextString(m) }
 func (*Keyword) ProtoMessage()    {}
 func (*Keyword) Descriptor() ([]byte, []int) {
-	return fileDescriptor_37cb16cf10c66117, []int{0}
+	return fileDescriptor_39c71457b15deadd, []int{0}
 }
 func (m *Keyword) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -74,19 +77,37 @@ func (m *Keyword) GetWord() string {
 	return ""
 }
 
+func (m *Keyword) GetPositions() []int32 {
+	if m != nil {
+		return m.Positions
+	}
+	return nil
+}
+
+func (m *Keyword) GetWeight() float32 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
 type Document struct {
-	Id          string     `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
-	IntId       uint64     `protobuf:"varint,2,opt,name=IntId,proto3" json:"IntId,omitempty"`
-	BitsFeature uint64     `protobuf:"varint,3,opt,name=BitsFeature,proto3" json:"BitsFeature,omitempty"`
-	Keywords    []*Keyword `protobuf:"bytes,4,rep,name=Keywords,proto3" json:"Keywords,omitempty"`
-	Bytes       []byte     `protobuf:"bytes,5,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	Id            string             `protobuf:"bytes,1,opt,name=Id,proto3" json:"Id,omitempty"`
+	IntId         uint64             `protobuf:"varint,2,opt,name=IntId,proto3" json:"IntId,omitempty"`
+	BitsFeature   uint64             `protobuf:"varint,3,opt,name=BitsFeature,proto3" json:"BitsFeature,omitempty"`
+	Keywords      []*Keyword         `protobuf:"bytes,4,rep,name=Keywords,proto3" json:"Keywords,omitempty"`
+	Bytes         []byte             `protobuf:"bytes,5,opt,name=Bytes,proto3" json:"Bytes,omitempty"`
+	IntFeatures   map[string]int64   `protobuf:"bytes,6,rep,name=IntFeatures,proto3" json:"IntFeatures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	FloatFeatures map[string]float64 `protobuf:"bytes,7,rep,name=FloatFeatures,proto3" json:"FloatFeatures,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	TextFields    map[string]string  `protobuf:"bytes,8,rep,name=TextFields,proto3" json:"TextFields,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Vector        []float32          `protobuf:"fixed32,9,rep,packed,name=Vector,proto3" json:"Vector,omitempty"`
 }
 
 func (m *Document) Reset()         { *m = Document{} }
 func (m *Document) String() string { return proto.CompactTextString(m) }
 func (*Document) ProtoMessage()    {}
 func (*Document) Descriptor() ([]byte, []int) {
-	return fileDescriptor_37cb16cf10c66117, []int{1}
+	return fileDescriptor_39c71457b15deadd, []int{1}
 }
 func (m *Document) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -150,29 +171,72 @@ func (m *Document) GetBytes() []byte {
 	return nil
 }
 
+func (m *Document) GetIntFeatures() map[string]int64 {
+	if m != nil {
+		return m.IntFeatures
+	}
+	return nil
+}
+
+func (m *Document) GetFloatFeatures() map[string]float64 {
+	if m != nil {
+		return m.FloatFeatures
+	}
+	return nil
+}
+
+func (m *Document) GetTextFields() map[string]string {
+	if m != nil {
+		return m.TextFields
+	}
+	return nil
+}
+
+func (m *Document) GetVector() []float32 {
+	if m != nil {
+		return m.Vector
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Keyword)(nil), "types.Keyword")
 	proto.RegisterType((*Document)(nil), "types.Document")
+	proto.RegisterMapType((map[string]float64)(nil), "types.Document.FloatFeaturesEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "types.Document.IntFeaturesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "types.Document.TextFieldsEntry")
 }
 
-func init() { proto.RegisterFile("doc.proto", fileDescriptor_37cb16cf10c66117) }
+func init() { proto.RegisterFile("types/doc.proto", fileDescriptor_39c71457b15deadd) }
 
-var fileDescriptor_37cb16cf10c66117 = []byte{
-	// 212 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x4c, 0xc9, 0x4f, 0xd6,
-	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0xa9, 0x2c, 0x48, 0x2d, 0x56, 0x32, 0xe6, 0x62,
-	0xf7, 0x4e, 0xad, 0x2c, 0xcf, 0x2f, 0x4a, 0x11, 0x12, 0xe1, 0x62, 0x75, 0xcb, 0x4c, 0xcd, 0x49,
-	0x91, 0x60, 0x54, 0x60, 0xd4, 0xe0, 0x0c, 0x82, 0x70, 0x84, 0x84, 0xb8, 0x58, 0xc2, 0xf3, 0x8b,
-	0x52, 0x24, 0x98, 0xc0, 0x82, 0x60, 0xb6, 0xd2, 0x14, 0x46, 0x2e, 0x0e, 0x97, 0xfc, 0xe4, 0xd2,
-	0xdc, 0xd4, 0xbc, 0x12, 0x21, 0x3e, 0x2e, 0x26, 0x4f, 0x98, 0x1e, 0x26, 0x4f, 0xb0, 0x31, 0x9e,
-	0x79, 0x25, 0x9e, 0x10, 0x1d, 0x2c, 0x41, 0x10, 0x8e, 0x90, 0x02, 0x17, 0xb7, 0x53, 0x66, 0x49,
-	0xb1, 0x5b, 0x6a, 0x62, 0x49, 0x69, 0x51, 0xaa, 0x04, 0x33, 0x58, 0x0e, 0x59, 0x48, 0x48, 0x8b,
-	0x8b, 0x03, 0xea, 0x92, 0x62, 0x09, 0x16, 0x05, 0x66, 0x0d, 0x6e, 0x23, 0x3e, 0x3d, 0xb0, 0x1b,
-	0xf5, 0xa0, 0xc2, 0x41, 0x70, 0x79, 0x90, 0x1d, 0x4e, 0x95, 0x25, 0xa9, 0xc5, 0x12, 0xac, 0x0a,
-	0x8c, 0x1a, 0x3c, 0x41, 0x10, 0x8e, 0x93, 0xc4, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31,
-	0x3e, 0x78, 0x24, 0xc7, 0x38, 0xe1, 0xb1, 0x1c, 0xc3, 0x85, 0xc7, 0x72, 0x0c, 0x37, 0x1e, 0xcb,
-	0x31, 0x24, 0xb1, 0x81, 0xfd, 0x6c, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff, 0x35, 0xf4, 0xa5, 0x58,
-	0x00, 0x01, 0x00, 0x00,
+var fileDescriptor_39c71457b15deadd = []byte{
+	// 403 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xcf, 0xaa, 0xd3, 0x40,
+	0x14, 0xc6, 0x3b, 0xf9, 0xd3, 0x36, 0x27, 0xda, 0x96, 0x41, 0x64, 0x28, 0x12, 0x87, 0x82, 0x10,
+	0x5c, 0xa4, 0xa0, 0x1b, 0x11, 0xfc, 0x17, 0x6b, 0x31, 0xb8, 0x91, 0x41, 0x2c, 0xb8, 0xab, 0xcd,
+	0xa0, 0xc1, 0x9a, 0x29, 0x99, 0xa9, 0x9a, 0xb7, 0xf0, 0x81, 0x7c, 0x00, 0x97, 0x5d, 0xba, 0x94,
+	0xf6, 0x45, 0x2e, 0x99, 0x49, 0x6f, 0xd3, 0xdc, 0x45, 0x77, 0xf9, 0xce, 0x9c, 0xef, 0x77, 0xce,
+	0x7c, 0x13, 0x18, 0xaa, 0x72, 0xc3, 0xe5, 0x34, 0x15, 0xab, 0x68, 0x53, 0x08, 0x25, 0xb0, 0xab,
+	0x0b, 0x93, 0x0c, 0x7a, 0xef, 0x78, 0xf9, 0x53, 0x14, 0x29, 0xbe, 0x03, 0xee, 0x3c, 0xe3, 0xeb,
+	0x94, 0x20, 0x8a, 0x42, 0x8f, 0x19, 0x81, 0x31, 0x38, 0x0b, 0x51, 0xa4, 0xc4, 0xd2, 0x45, 0xfd,
+	0x8d, 0xef, 0x81, 0xf7, 0x5e, 0xc8, 0x4c, 0x65, 0x22, 0x97, 0xc4, 0xa6, 0x76, 0xe8, 0xb2, 0x53,
+	0x01, 0xdf, 0x85, 0xee, 0x82, 0x67, 0x5f, 0xbe, 0x2a, 0xe2, 0x50, 0x14, 0x5a, 0xac, 0x56, 0x93,
+	0x3f, 0x0e, 0xf4, 0x67, 0x62, 0xb5, 0xfd, 0xce, 0x73, 0x85, 0x07, 0x60, 0x25, 0xc7, 0x49, 0x56,
+	0xa2, 0x87, 0x27, 0xb9, 0x4a, 0xcc, 0x1c, 0x87, 0x19, 0x81, 0x29, 0xf8, 0x71, 0xa6, 0xe4, 0x9c,
+	0x2f, 0xd5, 0xb6, 0xe0, 0xc4, 0xd6, 0x67, 0xcd, 0x12, 0x7e, 0x08, 0xfd, 0x7a, 0x7f, 0x49, 0x1c,
+	0x6a, 0x87, 0xfe, 0xa3, 0x41, 0xa4, 0x6f, 0x16, 0xd5, 0x65, 0x76, 0x7d, 0x5e, 0xcd, 0x88, 0x4b,
+	0xc5, 0x25, 0x71, 0x29, 0x0a, 0x6f, 0x31, 0x23, 0x70, 0x0c, 0x7e, 0x92, 0xab, 0x9a, 0x27, 0x49,
+	0x57, 0x43, 0x68, 0x0d, 0x39, 0xee, 0x1b, 0x35, 0x5a, 0xde, 0xe4, 0xaa, 0x28, 0x59, 0xd3, 0x84,
+	0xdf, 0xc2, 0xed, 0xf9, 0x5a, 0x2c, 0x4f, 0x94, 0x9e, 0xa6, 0x4c, 0xda, 0x94, 0xb3, 0x26, 0xc3,
+	0x39, 0x37, 0xe2, 0x17, 0x00, 0x1f, 0xf8, 0x2f, 0xa5, 0xb3, 0x97, 0xa4, 0xaf, 0x31, 0xf7, 0xdb,
+	0x98, 0x53, 0x87, 0x61, 0x34, 0x2c, 0x55, 0xfa, 0x1f, 0xf9, 0x4a, 0x89, 0x82, 0x78, 0xd4, 0xae,
+	0xd2, 0x37, 0x6a, 0xfc, 0x1c, 0x46, 0xed, 0x3b, 0xe0, 0x11, 0xd8, 0xdf, 0x78, 0x59, 0xbf, 0x42,
+	0xf5, 0x59, 0x45, 0xf4, 0x63, 0xb9, 0xde, 0x72, 0xfd, 0x0c, 0x36, 0x33, 0xe2, 0xa9, 0xf5, 0x04,
+	0x8d, 0x5f, 0x02, 0xbe, 0xb9, 0xfd, 0x25, 0x02, 0x6a, 0x12, 0x9e, 0xc1, 0xb0, 0xb5, 0xf8, 0x25,
+	0xbb, 0xd7, 0xb0, 0xc7, 0x0f, 0xfe, 0xee, 0x03, 0xb4, 0xdb, 0x07, 0xe8, 0xff, 0x3e, 0x40, 0xbf,
+	0x0f, 0x41, 0x67, 0x77, 0x08, 0x3a, 0xff, 0x0e, 0x41, 0xe7, 0x93, 0xcf, 0x5e, 0xcd, 0x92, 0xd7,
+	0x53, 0x1d, 0xd2, 0xe7, 0xae, 0xfe, 0xbd, 0x1f, 0x5f, 0x0d, 0x00, 0xce, 0xb8, 0xad, 0xbc, 0xf1,
+	0x02, 0x00, 0x00,
 }
 
 func (m *Keyword) Marshal() (dAtA []byte, err error) {
@@ -195,6 +259,31 @@ func (m *Keyword) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Weight != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.Weight))))
+		i--
+		dAtA[i] = 0x25
+	}
+	if len(m.Positions) > 0 {
+		dAtA2 := make([]byte, len(m.Positions)*10)
+		var j1 int
+		for _, num1 := range m.Positions {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintDoc(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if len(m.Word) > 0 {
 		i -= len(m.Word)
 		copy(dAtA[i:], m.Word)
@@ -232,6 +321,70 @@ func (m *Document) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Vector) > 0 {
+		for iNdEx := len(m.Vector) - 1; iNdEx >= 0; iNdEx-- {
+			f3 := math.Float32bits(float32(m.Vector[iNdEx]))
+			i -= 4
+			encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(f3))
+		}
+		i = encodeVarintDoc(dAtA, i, uint64(len(m.Vector)*4))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.TextFields) > 0 {
+		for k := range m.TextFields {
+			v := m.TextFields[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintDoc(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintDoc(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintDoc(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.FloatFeatures) > 0 {
+		for k := range m.FloatFeatures {
+			v := m.FloatFeatures[k]
+			baseI := i
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(v))))
+			i--
+			dAtA[i] = 0x11
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintDoc(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintDoc(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.IntFeatures) > 0 {
+		for k := range m.IntFeatures {
+			v := m.IntFeatures[k]
+			baseI := i
+			i = encodeVarintDoc(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintDoc(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintDoc(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
 	if len(m.Bytes) > 0 {
 		i -= len(m.Bytes)
 		copy(dAtA[i:], m.Bytes)
@@ -298,6 +451,16 @@ func (m *Keyword) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDoc(uint64(l))
 	}
+	if len(m.Positions) > 0 {
+		l = 0
+		for _, e := range m.Positions {
+			l += sovDoc(uint64(e))
+		}
+		n += 1 + sovDoc(uint64(l)) + l
+	}
+	if m.Weight != 0 {
+		n += 5
+	}
 	return n
 }
 
@@ -327,6 +490,33 @@ func (m *Document) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovDoc(uint64(l))
 	}
+	if len(m.IntFeatures) > 0 {
+		for k, v := range m.IntFeatures {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + sovDoc(uint64(v))
+			n += mapEntrySize + 1 + sovDoc(uint64(mapEntrySize))
+		}
+	}
+	if len(m.FloatFeatures) > 0 {
+		for k, v := range m.FloatFeatures {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + 8
+			n += mapEntrySize + 1 + sovDoc(uint64(mapEntrySize))
+		}
+	}
+	if len(m.TextFields) > 0 {
+		for k, v := range m.TextFields {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovDoc(uint64(len(k))) + 1 + len(v) + sovDoc(uint64(len(v)))
+			n += mapEntrySize + 1 + sovDoc(uint64(mapEntrySize))
+		}
+	}
+	if len(m.Vector) > 0 {
+		n += 1 + sovDoc(uint64(len(m.Vector)*4)) + len(m.Vector)*4
+	}
 	return n
 }
 
@@ -429,6 +619,93 @@ func (m *Keyword) Unmarshal(dAtA []byte) error {
 			}
 			m.Word = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v int32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Positions = append(m.Positions, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthDoc
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthDoc
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Positions) == 0 {
+					m.Positions = make([]int32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Positions = append(m.Positions, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Positions", wireType)
+			}
+		case 4:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Weight", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.Weight = float32(math.Float32frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
@@ -617,6 +894,406 @@ func (m *Document) Unmarshal(dAtA []byte) error {
 				m.Bytes = []byte{}
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntFeatures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.IntFeatures == nil {
+				m.IntFeatures = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDoc(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.IntFeatures[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FloatFeatures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.FloatFeatures == nil {
+				m.FloatFeatures = make(map[string]float64)
+			}
+			var mapkey string
+			var mapvalue float64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapvaluetemp uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvaluetemp = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					mapvalue = math.Float64frombits(mapvaluetemp)
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDoc(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.FloatFeatures[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TextFields", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDoc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDoc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDoc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TextFields == nil {
+				m.TextFields = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowDoc
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthDoc
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipDoc(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthDoc
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.TextFields[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 9:
+			if wireType == 5 {
+				var v uint32
+				if (iNdEx + 4) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+				iNdEx += 4
+				v2 := float32(math.Float32frombits(v))
+				m.Vector = append(m.Vector, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowDoc
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthDoc
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthDoc
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 4
+				if elementCount != 0 && len(m.Vector) == 0 {
+					m.Vector = make([]float32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					if (iNdEx + 4) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+					iNdEx += 4
+					v2 := float32(math.Float32frombits(v))
+					m.Vector = append(m.Vector, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vector", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDoc(dAtA[iNdEx:])
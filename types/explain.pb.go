@@ -0,0 +1,1633 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: types/explain.proto
+
+package types
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type QueryExplanation struct {
+	Query              string              `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	Matched            bool                `protobuf:"varint,2,opt,name=Matched,proto3" json:"Matched,omitempty"`
+	MatchedKeywords    []string            `protobuf:"bytes,3,rep,name=MatchedKeywords,proto3" json:"MatchedKeywords,omitempty"`
+	Score              float64             `protobuf:"fixed64,4,opt,name=Score,proto3" json:"Score,omitempty"`
+	Must               []*QueryExplanation `protobuf:"bytes,5,rep,name=Must,proto3" json:"Must,omitempty"`
+	Should             []*QueryExplanation `protobuf:"bytes,6,rep,name=Should,proto3" json:"Should,omitempty"`
+	MustNot            []*QueryExplanation `protobuf:"bytes,7,rep,name=MustNot,proto3" json:"MustNot,omitempty"`
+	MinimumShouldMatch int32               `protobuf:"varint,8,opt,name=MinimumShouldMatch,proto3" json:"MinimumShouldMatch,omitempty"`
+}
+
+func (m *QueryExplanation) Reset()         { *m = QueryExplanation{} }
+func (m *QueryExplanation) String() string { return proto.CompactTextString(m) }
+func (*QueryExplanation) ProtoMessage()    {}
+func (*QueryExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20fe06e06a9064a7, []int{0}
+}
+func (m *QueryExplanation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryExplanation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryExplanation.Merge(m, src)
+}
+func (m *QueryExplanation) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryExplanation proto.InternalMessageInfo
+
+func (m *QueryExplanation) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryExplanation) GetMatched() bool {
+	if m != nil {
+		return m.Matched
+	}
+	return false
+}
+
+func (m *QueryExplanation) GetMatchedKeywords() []string {
+	if m != nil {
+		return m.MatchedKeywords
+	}
+	return nil
+}
+
+func (m *QueryExplanation) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *QueryExplanation) GetMust() []*QueryExplanation {
+	if m != nil {
+		return m.Must
+	}
+	return nil
+}
+
+func (m *QueryExplanation) GetShould() []*QueryExplanation {
+	if m != nil {
+		return m.Should
+	}
+	return nil
+}
+
+func (m *QueryExplanation) GetMustNot() []*QueryExplanation {
+	if m != nil {
+		return m.MustNot
+	}
+	return nil
+}
+
+func (m *QueryExplanation) GetMinimumShouldMatch() int32 {
+	if m != nil {
+		return m.MinimumShouldMatch
+	}
+	return 0
+}
+
+type FlagExplanation struct {
+	Name   string `protobuf:"bytes,1,opt,name=Name,proto3" json:"Name,omitempty"`
+	Flag   uint64 `protobuf:"varint,2,opt,name=Flag,proto3" json:"Flag,omitempty"`
+	Passed bool   `protobuf:"varint,3,opt,name=Passed,proto3" json:"Passed,omitempty"`
+}
+
+func (m *FlagExplanation) Reset()         { *m = FlagExplanation{} }
+func (m *FlagExplanation) String() string { return proto.CompactTextString(m) }
+func (*FlagExplanation) ProtoMessage()    {}
+func (*FlagExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20fe06e06a9064a7, []int{1}
+}
+func (m *FlagExplanation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FlagExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FlagExplanation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FlagExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FlagExplanation.Merge(m, src)
+}
+func (m *FlagExplanation) XXX_Size() int {
+	return m.Size()
+}
+func (m *FlagExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_FlagExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FlagExplanation proto.InternalMessageInfo
+
+func (m *FlagExplanation) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FlagExplanation) GetFlag() uint64 {
+	if m != nil {
+		return m.Flag
+	}
+	return 0
+}
+
+func (m *FlagExplanation) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+type RangeExplanation struct {
+	Field    string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	HasValue bool   `protobuf:"varint,2,opt,name=HasValue,proto3" json:"HasValue,omitempty"`
+	Passed   bool   `protobuf:"varint,3,opt,name=Passed,proto3" json:"Passed,omitempty"`
+}
+
+func (m *RangeExplanation) Reset()         { *m = RangeExplanation{} }
+func (m *RangeExplanation) String() string { return proto.CompactTextString(m) }
+func (*RangeExplanation) ProtoMessage()    {}
+func (*RangeExplanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20fe06e06a9064a7, []int{2}
+}
+func (m *RangeExplanation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RangeExplanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RangeExplanation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RangeExplanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeExplanation.Merge(m, src)
+}
+func (m *RangeExplanation) XXX_Size() int {
+	return m.Size()
+}
+func (m *RangeExplanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeExplanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeExplanation proto.InternalMessageInfo
+
+func (m *RangeExplanation) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *RangeExplanation) GetHasValue() bool {
+	if m != nil {
+		return m.HasValue
+	}
+	return false
+}
+
+func (m *RangeExplanation) GetPassed() bool {
+	if m != nil {
+		return m.Passed
+	}
+	return false
+}
+
+type Explanation struct {
+	DocId       string              `protobuf:"bytes,1,opt,name=DocId,proto3" json:"DocId,omitempty"`
+	Matched     bool                `protobuf:"varint,2,opt,name=Matched,proto3" json:"Matched,omitempty"`
+	Query       *QueryExplanation   `protobuf:"bytes,3,opt,name=Query,proto3" json:"Query,omitempty"`
+	BitsFeature uint64              `protobuf:"varint,4,opt,name=BitsFeature,proto3" json:"BitsFeature,omitempty"`
+	Flags       []*FlagExplanation  `protobuf:"bytes,5,rep,name=Flags,proto3" json:"Flags,omitempty"`
+	Ranges      []*RangeExplanation `protobuf:"bytes,6,rep,name=Ranges,proto3" json:"Ranges,omitempty"`
+	Score       float64             `protobuf:"fixed64,7,opt,name=Score,proto3" json:"Score,omitempty"`
+}
+
+func (m *Explanation) Reset()         { *m = Explanation{} }
+func (m *Explanation) String() string { return proto.CompactTextString(m) }
+func (*Explanation) ProtoMessage()    {}
+func (*Explanation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_20fe06e06a9064a7, []int{3}
+}
+func (m *Explanation) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Explanation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Explanation.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Explanation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Explanation.Merge(m, src)
+}
+func (m *Explanation) XXX_Size() int {
+	return m.Size()
+}
+func (m *Explanation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Explanation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Explanation proto.InternalMessageInfo
+
+func (m *Explanation) GetDocId() string {
+	if m != nil {
+		return m.DocId
+	}
+	return ""
+}
+
+func (m *Explanation) GetMatched() bool {
+	if m != nil {
+		return m.Matched
+	}
+	return false
+}
+
+func (m *Explanation) GetQuery() *QueryExplanation {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *Explanation) GetBitsFeature() uint64 {
+	if m != nil {
+		return m.BitsFeature
+	}
+	return 0
+}
+
+func (m *Explanation) GetFlags() []*FlagExplanation {
+	if m != nil {
+		return m.Flags
+	}
+	return nil
+}
+
+func (m *Explanation) GetRanges() []*RangeExplanation {
+	if m != nil {
+		return m.Ranges
+	}
+	return nil
+}
+
+func (m *Explanation) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*QueryExplanation)(nil), "types.QueryExplanation")
+	proto.RegisterType((*FlagExplanation)(nil), "types.FlagExplanation")
+	proto.RegisterType((*RangeExplanation)(nil), "types.RangeExplanation")
+	proto.RegisterType((*Explanation)(nil), "types.Explanation")
+}
+
+func init() { proto.RegisterFile("types/explain.proto", fileDescriptor_20fe06e06a9064a7) }
+
+var fileDescriptor_20fe06e06a9064a7 = []byte{
+	// 418 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0x4f, 0x8b, 0xd3, 0x40,
+	0x1c, 0xed, 0x34, 0xff, 0xba, 0xbf, 0x1c, 0x76, 0x19, 0x65, 0x1d, 0x3c, 0x84, 0x50, 0x10, 0x02,
+	0x6a, 0x8a, 0xfa, 0x09, 0x5c, 0xd7, 0xe2, 0x22, 0x5d, 0xdc, 0x29, 0x78, 0x10, 0x2f, 0x63, 0x33,
+	0xb4, 0x81, 0x34, 0x53, 0x32, 0x13, 0xb4, 0x67, 0xbf, 0x80, 0xdf, 0xc9, 0x8b, 0xc7, 0x1e, 0x3d,
+	0x4a, 0xfb, 0x45, 0x64, 0x26, 0x93, 0x5a, 0x82, 0x9b, 0xdb, 0xef, 0xfd, 0xe6, 0xcd, 0x63, 0xf2,
+	0xde, 0x0b, 0x3c, 0x50, 0xdb, 0x0d, 0x97, 0x13, 0xfe, 0x6d, 0x53, 0xb0, 0xbc, 0x4c, 0x37, 0x95,
+	0x50, 0x02, 0x7b, 0x66, 0x39, 0xfe, 0x39, 0x84, 0x8b, 0xbb, 0x9a, 0x57, 0xdb, 0xb7, 0xfa, 0xb4,
+	0x64, 0x2a, 0x17, 0x25, 0x7e, 0x08, 0x9e, 0xd9, 0x11, 0x14, 0xa3, 0xe4, 0x8c, 0x36, 0x00, 0x13,
+	0x08, 0x66, 0x4c, 0x2d, 0x56, 0x3c, 0x23, 0xc3, 0x18, 0x25, 0x23, 0xda, 0x42, 0x9c, 0xc0, 0xb9,
+	0x1d, 0xdf, 0xf3, 0xed, 0x57, 0x51, 0x65, 0x92, 0x38, 0xb1, 0x93, 0x9c, 0xd1, 0xee, 0x5a, 0x2b,
+	0xcf, 0x17, 0xa2, 0xe2, 0xc4, 0x8d, 0x51, 0x82, 0x68, 0x03, 0xf0, 0x53, 0x70, 0x67, 0xb5, 0x54,
+	0xc4, 0x8b, 0x9d, 0x24, 0x7c, 0xf9, 0x28, 0x35, 0x4f, 0x4b, 0xbb, 0xcf, 0xa2, 0x86, 0x84, 0x27,
+	0xe0, 0xcf, 0x57, 0xa2, 0x2e, 0x32, 0xe2, 0xf7, 0xd3, 0x2d, 0x0d, 0xbf, 0x80, 0x40, 0x5f, 0xbc,
+	0x15, 0x8a, 0x04, 0xfd, 0x37, 0x5a, 0x1e, 0x4e, 0x01, 0xcf, 0xf2, 0x32, 0x5f, 0xd7, 0xeb, 0x46,
+	0xc3, 0x7c, 0x06, 0x19, 0xc5, 0x28, 0xf1, 0xe8, 0x7f, 0x4e, 0xc6, 0x77, 0x70, 0x3e, 0x2d, 0xd8,
+	0xf2, 0xd4, 0x43, 0x0c, 0xee, 0x2d, 0x5b, 0x73, 0x6b, 0xa1, 0x99, 0xf5, 0x4e, 0xd3, 0x8c, 0x7d,
+	0x2e, 0x35, 0x33, 0xbe, 0x04, 0xff, 0x03, 0x93, 0x92, 0x67, 0xc4, 0x31, 0xa6, 0x5a, 0x34, 0xfe,
+	0x0c, 0x17, 0x94, 0x95, 0x4b, 0xde, 0xc9, 0x65, 0x9a, 0xf3, 0x22, 0x6b, 0x73, 0x31, 0x00, 0x3f,
+	0x86, 0xd1, 0x3b, 0x26, 0x3f, 0xb2, 0xa2, 0xe6, 0x36, 0x98, 0x23, 0xbe, 0x57, 0xfd, 0xfb, 0x10,
+	0xc2, 0x8e, 0xf2, 0xb5, 0x58, 0xdc, 0x1c, 0x95, 0x0d, 0xe8, 0x49, 0xfc, 0x79, 0xdb, 0x10, 0x2d,
+	0xdb, 0xe3, 0xa8, 0xad, 0x4e, 0x0c, 0xe1, 0x55, 0xae, 0xe4, 0x94, 0x33, 0x55, 0xdb, 0xf0, 0x5d,
+	0x7a, 0xba, 0xc2, 0xcf, 0xc0, 0xd3, 0x76, 0x48, 0xdb, 0x81, 0x4b, 0x2b, 0xd8, 0x71, 0x95, 0x36,
+	0x24, 0xdd, 0x01, 0x63, 0x8e, 0xec, 0x74, 0xa0, 0xeb, 0x18, 0xb5, 0xb4, 0x7f, 0xbd, 0x0b, 0x4e,
+	0x7a, 0x77, 0xf5, 0xe4, 0xd7, 0x3e, 0x42, 0xbb, 0x7d, 0x84, 0xfe, 0xec, 0x23, 0xf4, 0xe3, 0x10,
+	0x0d, 0x76, 0x87, 0x68, 0xf0, 0xfb, 0x10, 0x0d, 0x3e, 0x85, 0xf4, 0xf5, 0xf5, 0xcd, 0x9b, 0x89,
+	0x51, 0xfd, 0xe2, 0x9b, 0x3f, 0xe6, 0xd5, 0xdf, 0x01, 0x00, 0x80, 0x42, 0x2e, 0xf2, 0x48, 0x03,
+	0x00, 0x00,
+}
+
+func (m *QueryExplanation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryExplanation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryExplanation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MinimumShouldMatch != 0 {
+		i = encodeVarintExplain(dAtA, i, uint64(m.MinimumShouldMatch))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.MustNot) > 0 {
+		for iNdEx := len(m.MustNot) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MustNot[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExplain(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Should) > 0 {
+		for iNdEx := len(m.Should) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Should[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExplain(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Must) > 0 {
+		for iNdEx := len(m.Must) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Must[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExplain(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if len(m.MatchedKeywords) > 0 {
+		for iNdEx := len(m.MatchedKeywords) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.MatchedKeywords[iNdEx])
+			copy(dAtA[i:], m.MatchedKeywords[iNdEx])
+			i = encodeVarintExplain(dAtA, i, uint64(len(m.MatchedKeywords[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Matched {
+		i--
+		if m.Matched {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintExplain(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FlagExplanation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FlagExplanation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FlagExplanation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Passed {
+		i--
+		if m.Passed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Flag != 0 {
+		i = encodeVarintExplain(dAtA, i, uint64(m.Flag))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintExplain(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RangeExplanation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RangeExplanation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RangeExplanation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Passed {
+		i--
+		if m.Passed {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.HasValue {
+		i--
+		if m.HasValue {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintExplain(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Explanation) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Explanation) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Explanation) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if len(m.Ranges) > 0 {
+		for iNdEx := len(m.Ranges) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ranges[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExplain(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Flags) > 0 {
+		for iNdEx := len(m.Flags) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Flags[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintExplain(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.BitsFeature != 0 {
+		i = encodeVarintExplain(dAtA, i, uint64(m.BitsFeature))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Query != nil {
+		{
+			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintExplain(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Matched {
+		i--
+		if m.Matched {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.DocId) > 0 {
+		i -= len(m.DocId)
+		copy(dAtA[i:], m.DocId)
+		i = encodeVarintExplain(dAtA, i, uint64(len(m.DocId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintExplain(dAtA []byte, offset int, v uint64) int {
+	offset -= sovExplain(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryExplanation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovExplain(uint64(l))
+	}
+	if m.Matched {
+		n += 2
+	}
+	if len(m.MatchedKeywords) > 0 {
+		for _, s := range m.MatchedKeywords {
+			l = len(s)
+			n += 1 + l + sovExplain(uint64(l))
+		}
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	if len(m.Must) > 0 {
+		for _, e := range m.Must {
+			l = e.Size()
+			n += 1 + l + sovExplain(uint64(l))
+		}
+	}
+	if len(m.Should) > 0 {
+		for _, e := range m.Should {
+			l = e.Size()
+			n += 1 + l + sovExplain(uint64(l))
+		}
+	}
+	if len(m.MustNot) > 0 {
+		for _, e := range m.MustNot {
+			l = e.Size()
+			n += 1 + l + sovExplain(uint64(l))
+		}
+	}
+	if m.MinimumShouldMatch != 0 {
+		n += 1 + sovExplain(uint64(m.MinimumShouldMatch))
+	}
+	return n
+}
+
+func (m *FlagExplanation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovExplain(uint64(l))
+	}
+	if m.Flag != 0 {
+		n += 1 + sovExplain(uint64(m.Flag))
+	}
+	if m.Passed {
+		n += 2
+	}
+	return n
+}
+
+func (m *RangeExplanation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovExplain(uint64(l))
+	}
+	if m.HasValue {
+		n += 2
+	}
+	if m.Passed {
+		n += 2
+	}
+	return n
+}
+
+func (m *Explanation) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DocId)
+	if l > 0 {
+		n += 1 + l + sovExplain(uint64(l))
+	}
+	if m.Matched {
+		n += 2
+	}
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovExplain(uint64(l))
+	}
+	if m.BitsFeature != 0 {
+		n += 1 + sovExplain(uint64(m.BitsFeature))
+	}
+	if len(m.Flags) > 0 {
+		for _, e := range m.Flags {
+			l = e.Size()
+			n += 1 + l + sovExplain(uint64(l))
+		}
+	}
+	if len(m.Ranges) > 0 {
+		for _, e := range m.Ranges {
+			l = e.Size()
+			n += 1 + l + sovExplain(uint64(l))
+		}
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	return n
+}
+
+func sovExplain(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozExplain(x uint64) (n int) {
+	return sovExplain(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *QueryExplanation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExplain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryExplanation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryExplanation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matched", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Matched = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MatchedKeywords", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MatchedKeywords = append(m.MatchedKeywords, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Must", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Must = append(m.Must, &QueryExplanation{})
+			if err := m.Must[len(m.Must)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Should", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Should = append(m.Should, &QueryExplanation{})
+			if err := m.Should[len(m.Should)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MustNot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MustNot = append(m.MustNot, &QueryExplanation{})
+			if err := m.MustNot[len(m.MustNot)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinimumShouldMatch", wireType)
+			}
+			m.MinimumShouldMatch = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinimumShouldMatch |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExplain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FlagExplanation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExplain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FlagExplanation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FlagExplanation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flag", wireType)
+			}
+			m.Flag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Flag |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Passed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Passed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExplain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RangeExplanation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExplain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RangeExplanation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RangeExplanation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasValue", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasValue = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Passed", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Passed = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExplain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Explanation) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowExplain
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Explanation: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Explanation: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DocId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DocId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matched", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Matched = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Query == nil {
+				m.Query = &QueryExplanation{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BitsFeature", wireType)
+			}
+			m.BitsFeature = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BitsFeature |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Flags", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Flags = append(m.Flags, &FlagExplanation{})
+			if err := m.Flags[len(m.Flags)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ranges", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthExplain
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ranges = append(m.Ranges, &RangeExplanation{})
+			if err := m.Ranges[len(m.Ranges)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipExplain(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthExplain
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipExplain(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowExplain
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowExplain
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthExplain
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupExplain
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthExplain
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthExplain        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowExplain          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupExplain = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,43 @@
+package types
+
+import "fmt"
+
+const (
+	DEFAULT_FACET_SIZE = 10 // TermsFacet没指定TopN时返回的词数
+)
+
+// NewTermsFacet 统计field上文档数最多的topN个词，topN<=0时取DEFAULT_FACET_SIZE
+func NewTermsFacet(field string, topN int) *TermsFacet {
+	return &TermsFacet{Field: field, TopN: int32(topN)}
+}
+
+// Limit 实际返回的词数
+func (f *TermsFacet) Limit() int {
+	if f.TopN <= 0 {
+		return DEFAULT_FACET_SIZE
+	}
+	return int(f.TopN)
+}
+
+// Empty 没有任何统计项，nil也是空的
+func (f *FacetRequest) Empty() bool {
+	return f == nil || (len(f.Terms) == 0 && len(f.Bits) == 0)
+}
+
+// Validate 词的统计要指定field，标志位不能为0
+func (f *FacetRequest) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, t := range f.Terms {
+		if t == nil || t.Field == "" {
+			return fmt.Errorf("terms facet without field")
+		}
+	}
+	for _, flag := range f.Bits {
+		if flag == 0 {
+			return fmt.Errorf("bits facet with zero flag")
+		}
+	}
+	return nil
+}
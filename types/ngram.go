@@ -0,0 +1,61 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// n-gram索引：把field的原文切成长度为n的子串(按rune)，放在单独的field里，子串查询时求交集
+
+// NgramField field的n-gram放在哪个field下，与分词得到的词分开。n编码在field名里，按不同的n建的索引不会混在一起
+func NgramField(field string, n int) string {
+	return field + "#" + strconv.Itoa(n) + "gram"
+}
+
+// ParseNgramField NgramField的逆操作，不是n-gram的field时返回false
+func ParseNgramField(ngramField string) (field string, n int, ok bool) {
+	rest, found := strings.CutSuffix(ngramField, "gram")
+	if !found {
+		return "", 0, false
+	}
+	i := strings.LastIndexByte(rest, '#')
+	if i < 0 {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(rest[i+1:])
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return rest[:i], n, true
+}
+
+// Ngrams 从text的每个字符开始取长度为n的子串，去重。末尾不足n个字符的也取，这样比n短的子串也能用前缀在gram里找到
+func Ngrams(text string, n int) []string {
+	runes := []rune(text)
+	grams := make([]string, 0, len(runes))
+	seen := make(map[string]struct{}, len(runes))
+	for i := range runes {
+		gram := string(runes[i:min(i+n, len(runes))])
+		if _, exists := seen[gram]; !exists {
+			seen[gram] = struct{}{}
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// NgramKeywords field的原文切成n-gram后的keyword，每个gram一个，不带位置
+func NgramKeywords(field string, text string, n int) []*Keyword {
+	ngramField := NgramField(field, n)
+	grams := Ngrams(text, n)
+	keywords := make([]*Keyword, 0, len(grams))
+	for _, gram := range grams {
+		keywords = append(keywords, &Keyword{Field: ngramField, Word: gram})
+	}
+	return keywords
+}
+
+// NewSubstringQuery 子串查询
+func NewSubstringQuery(field string, text string) *TermQuery {
+	return &TermQuery{Substring: &SubstringQuery{Field: field, Text: text}}
+}
@@ -0,0 +1,1148 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: types/profile.proto
+
+package types
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type QueryProfile struct {
+	Query       string          `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	Nanos       int64           `protobuf:"varint,2,opt,name=Nanos,proto3" json:"Nanos,omitempty"`
+	PostingSize int64           `protobuf:"varint,3,opt,name=PostingSize,proto3" json:"PostingSize,omitempty"`
+	ResultCount int64           `protobuf:"varint,4,opt,name=ResultCount,proto3" json:"ResultCount,omitempty"`
+	Must        []*QueryProfile `protobuf:"bytes,5,rep,name=Must,proto3" json:"Must,omitempty"`
+	Should      []*QueryProfile `protobuf:"bytes,6,rep,name=Should,proto3" json:"Should,omitempty"`
+	MustNot     []*QueryProfile `protobuf:"bytes,7,rep,name=MustNot,proto3" json:"MustNot,omitempty"`
+}
+
+func (m *QueryProfile) Reset()         { *m = QueryProfile{} }
+func (m *QueryProfile) String() string { return proto.CompactTextString(m) }
+func (*QueryProfile) ProtoMessage()    {}
+func (*QueryProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1ee915e051a6a67c, []int{0}
+}
+func (m *QueryProfile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryProfile.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryProfile.Merge(m, src)
+}
+func (m *QueryProfile) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryProfile proto.InternalMessageInfo
+
+func (m *QueryProfile) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *QueryProfile) GetNanos() int64 {
+	if m != nil {
+		return m.Nanos
+	}
+	return 0
+}
+
+func (m *QueryProfile) GetPostingSize() int64 {
+	if m != nil {
+		return m.PostingSize
+	}
+	return 0
+}
+
+func (m *QueryProfile) GetResultCount() int64 {
+	if m != nil {
+		return m.ResultCount
+	}
+	return 0
+}
+
+func (m *QueryProfile) GetMust() []*QueryProfile {
+	if m != nil {
+		return m.Must
+	}
+	return nil
+}
+
+func (m *QueryProfile) GetShould() []*QueryProfile {
+	if m != nil {
+		return m.Should
+	}
+	return nil
+}
+
+func (m *QueryProfile) GetMustNot() []*QueryProfile {
+	if m != nil {
+		return m.MustNot
+	}
+	return nil
+}
+
+type SearchProfile struct {
+	Query        *QueryProfile `protobuf:"bytes,1,opt,name=Query,proto3" json:"Query,omitempty"`
+	MatchNanos   int64         `protobuf:"varint,2,opt,name=MatchNanos,proto3" json:"MatchNanos,omitempty"`
+	FilterNanos  int64         `protobuf:"varint,3,opt,name=FilterNanos,proto3" json:"FilterNanos,omitempty"`
+	MatchedDocs  int64         `protobuf:"varint,4,opt,name=MatchedDocs,proto3" json:"MatchedDocs,omitempty"`
+	RankNanos    int64         `protobuf:"varint,5,opt,name=RankNanos,proto3" json:"RankNanos,omitempty"`
+	FetchNanos   int64         `protobuf:"varint,6,opt,name=FetchNanos,proto3" json:"FetchNanos,omitempty"`
+	DecodeNanos  int64         `protobuf:"varint,7,opt,name=DecodeNanos,proto3" json:"DecodeNanos,omitempty"`
+	FetchedDocs  int64         `protobuf:"varint,8,opt,name=FetchedDocs,proto3" json:"FetchedDocs,omitempty"`
+	FetchedBytes int64         `protobuf:"varint,9,opt,name=FetchedBytes,proto3" json:"FetchedBytes,omitempty"`
+	TotalNanos   int64         `protobuf:"varint,10,opt,name=TotalNanos,proto3" json:"TotalNanos,omitempty"`
+	RerankNanos  int64         `protobuf:"varint,11,opt,name=RerankNanos,proto3" json:"RerankNanos,omitempty"`
+}
+
+func (m *SearchProfile) Reset()         { *m = SearchProfile{} }
+func (m *SearchProfile) String() string { return proto.CompactTextString(m) }
+func (*SearchProfile) ProtoMessage()    {}
+func (*SearchProfile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_1ee915e051a6a67c, []int{1}
+}
+func (m *SearchProfile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SearchProfile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SearchProfile.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SearchProfile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchProfile.Merge(m, src)
+}
+func (m *SearchProfile) XXX_Size() int {
+	return m.Size()
+}
+func (m *SearchProfile) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchProfile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchProfile proto.InternalMessageInfo
+
+func (m *SearchProfile) GetQuery() *QueryProfile {
+	if m != nil {
+		return m.Query
+	}
+	return nil
+}
+
+func (m *SearchProfile) GetMatchNanos() int64 {
+	if m != nil {
+		return m.MatchNanos
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetFilterNanos() int64 {
+	if m != nil {
+		return m.FilterNanos
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetMatchedDocs() int64 {
+	if m != nil {
+		return m.MatchedDocs
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetRankNanos() int64 {
+	if m != nil {
+		return m.RankNanos
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetFetchNanos() int64 {
+	if m != nil {
+		return m.FetchNanos
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetDecodeNanos() int64 {
+	if m != nil {
+		return m.DecodeNanos
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetFetchedDocs() int64 {
+	if m != nil {
+		return m.FetchedDocs
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetFetchedBytes() int64 {
+	if m != nil {
+		return m.FetchedBytes
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetTotalNanos() int64 {
+	if m != nil {
+		return m.TotalNanos
+	}
+	return 0
+}
+
+func (m *SearchProfile) GetRerankNanos() int64 {
+	if m != nil {
+		return m.RerankNanos
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*QueryProfile)(nil), "types.QueryProfile")
+	proto.RegisterType((*SearchProfile)(nil), "types.SearchProfile")
+}
+
+func init() { proto.RegisterFile("types/profile.proto", fileDescriptor_1ee915e051a6a67c) }
+
+var fileDescriptor_1ee915e051a6a67c = []byte{
+	// 371 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x92, 0x31, 0x4f, 0xf2, 0x40,
+	0x18, 0xc7, 0x29, 0xa5, 0xe5, 0xe5, 0xca, 0xbb, 0x1c, 0x0e, 0x37, 0x98, 0x4b, 0x43, 0x62, 0xc4,
+	0x18, 0x21, 0xd1, 0x4f, 0x20, 0x10, 0x12, 0x07, 0x08, 0x16, 0x27, 0xb7, 0x5a, 0x1e, 0xa5, 0xb1,
+	0xe9, 0x91, 0xde, 0x75, 0xc0, 0xd5, 0xc4, 0xd9, 0x8f, 0xe5, 0xc8, 0xe8, 0x68, 0xe0, 0x8b, 0x98,
+	0xbb, 0x6b, 0xe9, 0x39, 0x30, 0x3e, 0xbf, 0xfb, 0xdd, 0xf1, 0xe7, 0xdf, 0x07, 0x75, 0xc4, 0x66,
+	0x0d, 0x7c, 0xb0, 0xce, 0xd8, 0x73, 0x9c, 0x40, 0x7f, 0x9d, 0x31, 0xc1, 0xb0, 0xa3, 0x60, 0xf7,
+	0xbd, 0x8e, 0xda, 0xf7, 0x39, 0x64, 0x9b, 0xb9, 0x3e, 0xc5, 0x27, 0xc8, 0x51, 0x33, 0xb1, 0x7c,
+	0xab, 0xd7, 0x0a, 0xf4, 0x20, 0xe9, 0x2c, 0x4c, 0x19, 0x27, 0x75, 0xdf, 0xea, 0xd9, 0x81, 0x1e,
+	0xb0, 0x8f, 0xbc, 0x39, 0xe3, 0x22, 0x4e, 0x5f, 0x16, 0xf1, 0x1b, 0x10, 0x5b, 0x9d, 0x99, 0x48,
+	0x1a, 0x01, 0xf0, 0x3c, 0x11, 0x23, 0x96, 0xa7, 0x82, 0x34, 0xb4, 0x61, 0x20, 0x7c, 0x8e, 0x1a,
+	0xd3, 0x9c, 0x0b, 0xe2, 0xf8, 0x76, 0xcf, 0xbb, 0xee, 0xf4, 0x55, 0xac, 0xbe, 0x19, 0x29, 0x50,
+	0x02, 0xbe, 0x44, 0xee, 0x62, 0xc5, 0xf2, 0x64, 0x49, 0xdc, 0xe3, 0x6a, 0xa1, 0xe0, 0x2b, 0xd4,
+	0x94, 0x97, 0x66, 0x4c, 0x90, 0xe6, 0x71, 0xbb, 0x74, 0xba, 0x1f, 0x36, 0xfa, 0xbf, 0x80, 0x30,
+	0x8b, 0x56, 0x65, 0x0d, 0x17, 0x66, 0x0d, 0x47, 0xae, 0x17, 0xdd, 0x50, 0x84, 0xa6, 0xa1, 0x88,
+	0x56, 0x66, 0x41, 0x06, 0x91, 0x1d, 0x4c, 0xe2, 0x44, 0x40, 0xa6, 0x85, 0xa2, 0x25, 0x03, 0x49,
+	0x43, 0xf9, 0xb0, 0x1c, 0xb3, 0x88, 0x97, 0x2d, 0x19, 0x08, 0x9f, 0xa2, 0x56, 0x10, 0xa6, 0xaf,
+	0xfa, 0x05, 0x47, 0x9d, 0x57, 0x40, 0x26, 0x98, 0xc0, 0x21, 0x81, 0xab, 0x13, 0x54, 0x44, 0xbe,
+	0x3f, 0x86, 0x88, 0x2d, 0x41, 0x0b, 0x4d, 0xfd, 0xbe, 0x81, 0x54, 0x46, 0xa8, 0x12, 0xfc, 0x2b,
+	0x32, 0x56, 0x08, 0x77, 0x51, 0xbb, 0x18, 0x87, 0x1b, 0x01, 0x9c, 0xb4, 0x94, 0xf2, 0x87, 0xc9,
+	0x1c, 0x0f, 0x4c, 0x84, 0x89, 0xfe, 0x19, 0xa4, 0x73, 0x54, 0x44, 0x6f, 0x43, 0x76, 0xf8, 0x1f,
+	0x5e, 0xb9, 0x0d, 0x07, 0x34, 0x3c, 0xfb, 0xda, 0x51, 0x6b, 0xbb, 0xa3, 0xd6, 0xcf, 0x8e, 0x5a,
+	0x9f, 0x7b, 0x5a, 0xdb, 0xee, 0x69, 0xed, 0x7b, 0x4f, 0x6b, 0x8f, 0x5e, 0x70, 0x3b, 0xbe, 0x1b,
+	0x0d, 0xd4, 0x67, 0x78, 0x72, 0xd5, 0x0e, 0xdf, 0xfc, 0x0e, 0x00, 0xc8, 0x4f, 0x39, 0x30, 0xda,
+	0x02, 0x00, 0x00,
+}
+
+func (m *QueryProfile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryProfile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryProfile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MustNot) > 0 {
+		for iNdEx := len(m.MustNot) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MustNot[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProfile(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if len(m.Should) > 0 {
+		for iNdEx := len(m.Should) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Should[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProfile(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Must) > 0 {
+		for iNdEx := len(m.Must) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Must[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintProfile(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.ResultCount != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.ResultCount))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.PostingSize != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.PostingSize))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Nanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.Nanos))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintProfile(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchProfile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchProfile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchProfile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RerankNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.RerankNanos))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.TotalNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.TotalNanos))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.FetchedBytes != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.FetchedBytes))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.FetchedDocs != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.FetchedDocs))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.DecodeNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.DecodeNanos))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.FetchNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.FetchNanos))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.RankNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.RankNanos))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.MatchedDocs != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.MatchedDocs))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.FilterNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.FilterNanos))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MatchNanos != 0 {
+		i = encodeVarintProfile(dAtA, i, uint64(m.MatchNanos))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Query != nil {
+		{
+			size, err := m.Query.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintProfile(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintProfile(dAtA []byte, offset int, v uint64) int {
+	offset -= sovProfile(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryProfile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovProfile(uint64(l))
+	}
+	if m.Nanos != 0 {
+		n += 1 + sovProfile(uint64(m.Nanos))
+	}
+	if m.PostingSize != 0 {
+		n += 1 + sovProfile(uint64(m.PostingSize))
+	}
+	if m.ResultCount != 0 {
+		n += 1 + sovProfile(uint64(m.ResultCount))
+	}
+	if len(m.Must) > 0 {
+		for _, e := range m.Must {
+			l = e.Size()
+			n += 1 + l + sovProfile(uint64(l))
+		}
+	}
+	if len(m.Should) > 0 {
+		for _, e := range m.Should {
+			l = e.Size()
+			n += 1 + l + sovProfile(uint64(l))
+		}
+	}
+	if len(m.MustNot) > 0 {
+		for _, e := range m.MustNot {
+			l = e.Size()
+			n += 1 + l + sovProfile(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SearchProfile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Query != nil {
+		l = m.Query.Size()
+		n += 1 + l + sovProfile(uint64(l))
+	}
+	if m.MatchNanos != 0 {
+		n += 1 + sovProfile(uint64(m.MatchNanos))
+	}
+	if m.FilterNanos != 0 {
+		n += 1 + sovProfile(uint64(m.FilterNanos))
+	}
+	if m.MatchedDocs != 0 {
+		n += 1 + sovProfile(uint64(m.MatchedDocs))
+	}
+	if m.RankNanos != 0 {
+		n += 1 + sovProfile(uint64(m.RankNanos))
+	}
+	if m.FetchNanos != 0 {
+		n += 1 + sovProfile(uint64(m.FetchNanos))
+	}
+	if m.DecodeNanos != 0 {
+		n += 1 + sovProfile(uint64(m.DecodeNanos))
+	}
+	if m.FetchedDocs != 0 {
+		n += 1 + sovProfile(uint64(m.FetchedDocs))
+	}
+	if m.FetchedBytes != 0 {
+		n += 1 + sovProfile(uint64(m.FetchedBytes))
+	}
+	if m.TotalNanos != 0 {
+		n += 1 + sovProfile(uint64(m.TotalNanos))
+	}
+	if m.RerankNanos != 0 {
+		n += 1 + sovProfile(uint64(m.RerankNanos))
+	}
+	return n
+}
+
+func sovProfile(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozProfile(x uint64) (n int) {
+	return sovProfile(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *QueryProfile) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProfile
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryProfile: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryProfile: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Nanos", wireType)
+			}
+			m.Nanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Nanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PostingSize", wireType)
+			}
+			m.PostingSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PostingSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResultCount", wireType)
+			}
+			m.ResultCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResultCount |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Must", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Must = append(m.Must, &QueryProfile{})
+			if err := m.Must[len(m.Must)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Should", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Should = append(m.Should, &QueryProfile{})
+			if err := m.Should[len(m.Should)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MustNot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MustNot = append(m.MustNot, &QueryProfile{})
+			if err := m.MustNot[len(m.MustNot)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProfile(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchProfile) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowProfile
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchProfile: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchProfile: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthProfile
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Query == nil {
+				m.Query = &QueryProfile{}
+			}
+			if err := m.Query.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MatchNanos", wireType)
+			}
+			m.MatchNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MatchNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FilterNanos", wireType)
+			}
+			m.FilterNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FilterNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MatchedDocs", wireType)
+			}
+			m.MatchedDocs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MatchedDocs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RankNanos", wireType)
+			}
+			m.RankNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RankNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchNanos", wireType)
+			}
+			m.FetchNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DecodeNanos", wireType)
+			}
+			m.DecodeNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DecodeNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedDocs", wireType)
+			}
+			m.FetchedDocs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedDocs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FetchedBytes", wireType)
+			}
+			m.FetchedBytes = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FetchedBytes |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalNanos", wireType)
+			}
+			m.TotalNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.TotalNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RerankNanos", wireType)
+			}
+			m.RerankNanos = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RerankNanos |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipProfile(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthProfile
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipProfile(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowProfile
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowProfile
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthProfile
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupProfile
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthProfile
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthProfile        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowProfile          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupProfile = fmt.Errorf("proto: unexpected end of group")
+)
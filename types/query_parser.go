@@ -0,0 +1,604 @@
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 查询字符串 <-> TermQuery 的相互转换
+// 语法(AND的优先级高于OR，相邻的两个子句之间省略AND即表示AND)：
+//
+//	query   := orExpr EOF
+//	orExpr  := andExpr ( "OR" andExpr )*
+//	andExpr := unary ( ["AND"] unary )*
+//	unary   := ( "-" | "NOT" ) primary | primary
+//	primary := ( "(" orExpr ")" [ "~" msm ] | term | phrase | substr ) [ "^" boost ]
+//	boost   := 正数                        // 子句的得分乘以boost，只影响打分，^紧跟在子句后面，数字紧跟在^后面
+//	msm     := 整数 | 整数 "%"             // 括号里的OR子句至少命中几个，即MinimumShouldMatch
+//	term    := [ text ":" ] text           // field:word，省略field时field为空
+//	                                       // 不带引号的word里有*或?时是通配符查询，只有末尾一个*时是前缀查询
+//	fuzzy   := term "~" [ 0|1|2 [ "," 整数 [ "," 整数 ] ] ]  // 模糊查询，~后面紧跟最大编辑距离(省略时为2)，
+//	                                       // 之后可以接前缀长度和最大扩展词数，如go~1,1,50
+//	phrase  := [ text ":" ] "[" item+ "]" [ "~" 整数 ]  // 短语查询，~N是Slop
+//	item    := [ text ":" ] text           // 短语里的词默认使用短语的field
+//	substr  := [ text ":" ] "*" "带引号的文本" "*"  // 子串查询，*和引号之间不能有空白，field要按n-gram建索引
+//	text    := 裸词 | "带引号的词"          // 引号内可以用 \" 和 \\ 转义
+//
+// 例如：title:go AND (tag:java OR tag:rust) -tag:php
+//      title:[分布式 搜索]~2
+//      title:gola* OR title:go*lang
+//      title:golnag~1
+//      title:golnag~1,2,50
+//      (tag:go OR tag:java OR tag:rust OR tag:c)~2
+//      title:go^3 OR tag:go
+//      title:*"式搜"*
+// 每个and组里至少要有一个不带"-"的子句，因为只有MustNot的节点不会命中任何文档
+
+// SyntaxError 查询字符串的语法错误，Pos是出错位置(从0开始的字符下标，按rune计)
+type SyntaxError struct {
+	Pos      int
+	Expected string
+	Found    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: expected %s, found %s", e.Pos, e.Expected, e.Found)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenText
+	tokenLParen
+	tokenRParen
+	tokenLBracket
+	tokenRBracket
+	tokenTilde
+	tokenCaret
+	tokenColon
+	tokenMinus
+	tokenAnd
+	tokenOr
+	tokenNot
+)
+
+type queryToken struct {
+	kind   tokenKind
+	text   string // 带引号的text也是tokenText，不会被当成AND/OR/NOT
+	quoted bool   // 带引号的text里的*和?不是通配符
+	pos    int
+	end    int // token之后的第一个字符的位置，用来判断两个token是否紧挨着
+}
+
+// describe 出错时展示给用户的token描述
+func (t queryToken) describe() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenText:
+		return fmt.Sprintf("%q", t.text)
+	default:
+		return "'" + t.text + "'"
+	}
+}
+
+// isSpecial 裸词里不能出现的字符
+func isSpecial(r rune) bool {
+	return r == '(' || r == ')' || r == '[' || r == ']' || r == '~' || r == '^' || r == ':' || r == '"'
+}
+
+// tokenize 词法分析
+func tokenize(input string) ([]queryToken, error) {
+	runes := []rune(input)
+	tokens := make([]queryToken, 0, 8)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, text: "(", pos: i, end: i + 1})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, text: ")", pos: i, end: i + 1})
+			i++
+		case r == '[':
+			tokens = append(tokens, queryToken{kind: tokenLBracket, text: "[", pos: i, end: i + 1})
+			i++
+		case r == ']':
+			tokens = append(tokens, queryToken{kind: tokenRBracket, text: "]", pos: i, end: i + 1})
+			i++
+		case r == '~':
+			tokens = append(tokens, queryToken{kind: tokenTilde, text: "~", pos: i, end: i + 1})
+			i++
+		case r == '^':
+			tokens = append(tokens, queryToken{kind: tokenCaret, text: "^", pos: i, end: i + 1})
+			i++
+		case r == ':':
+			tokens = append(tokens, queryToken{kind: tokenColon, text: ":", pos: i, end: i + 1})
+			i++
+		case r == '-':
+			// 只有出现在子句开头的"-"表示排除，词中间的"-"是词的一部分，比如c-lang
+			tokens = append(tokens, queryToken{kind: tokenMinus, text: "-", pos: i, end: i + 1})
+			i++
+		case r == '"':
+			start := i
+			var sb strings.Builder
+			i++
+			closed := false
+			for i < len(runes) {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					sb.WriteRune(runes[i+1])
+					i += 2
+					continue
+				}
+				if runes[i] == '"' {
+					closed = true
+					i++
+					break
+				}
+				sb.WriteRune(runes[i])
+				i++
+			}
+			if !closed {
+				return nil, &SyntaxError{Pos: len(runes), Expected: `closing '"' for quote at position ` + fmt.Sprint(start), Found: "end of input"}
+			}
+			tokens = append(tokens, queryToken{kind: tokenText, text: sb.String(), quoted: true, pos: start, end: i})
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && !isSpecial(runes[i]) {
+				i++
+			}
+			text := string(runes[start:i])
+			token := queryToken{kind: tokenText, text: text, pos: start, end: i}
+			switch text {
+			case "AND":
+				token.kind = tokenAnd
+			case "OR":
+				token.kind = tokenOr
+			case "NOT":
+				token.kind = tokenNot
+			}
+			tokens = append(tokens, token)
+		}
+	}
+	tokens = append(tokens, queryToken{kind: tokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+// queryParser 递归下降语法分析
+type queryParser struct {
+	tokens []queryToken
+	cur    int
+}
+
+func (p *queryParser) peek() queryToken {
+	return p.tokens[p.cur]
+}
+
+func (p *queryParser) next() queryToken {
+	token := p.tokens[p.cur]
+	if token.kind != tokenEOF {
+		p.cur++
+	}
+	return token
+}
+
+func (p *queryParser) errorf(expected string) error {
+	token := p.peek()
+	return &SyntaxError{Pos: token.pos, Expected: expected, Found: token.describe()}
+}
+
+func (p *queryParser) parseOr() (*TermQuery, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]*TermQuery, 0, 2)
+	for p.peek().kind == tokenOr {
+		p.next()
+		q, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, q)
+	}
+	return first.Or(queries...), nil
+}
+
+// startsUnary 当前token能否作为一个子句的开头，用于识别省略了AND的情况
+func (p *queryParser) startsUnary() bool {
+	switch p.peek().kind {
+	case tokenText, tokenLParen, tokenLBracket, tokenMinus, tokenNot:
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) parseAnd() (*TermQuery, error) {
+	start := p.peek()
+	positives := make([]*TermQuery, 0, 2)
+	negatives := make([]*TermQuery, 0, 1)
+	for {
+		negative := false
+		if kind := p.peek().kind; kind == tokenMinus || kind == tokenNot {
+			p.next()
+			negative = true
+		}
+		q, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		if negative {
+			negatives = append(negatives, q)
+		} else {
+			positives = append(positives, q)
+		}
+
+		if p.peek().kind == tokenAnd {
+			p.next()
+			if !p.startsUnary() {
+				return nil, p.errorf("term, '(' or '-'")
+			}
+		} else if !p.startsUnary() {
+			break
+		}
+	}
+	if len(positives) == 0 {
+		return nil, &SyntaxError{Pos: start.pos, Expected: "at least one term without '-' or NOT", Found: start.describe()}
+	}
+	return positives[0].And(positives[1:]...).Not(negatives...), nil
+}
+
+func (p *queryParser) parsePrimary() (*TermQuery, error) {
+	q, err := p.parseUnboosted()
+	if err != nil || p.peek().kind != tokenCaret {
+		return q, err
+	}
+	if caret := p.peek(); caret.pos != p.tokens[p.cur-1].end {
+		return nil, p.errorf("'^' right after the clause without whitespace")
+	}
+	caret := p.next()
+	token := p.peek()
+	boost, parseErr := strconv.ParseFloat(token.text, 32)
+	if token.kind != tokenText || token.pos != caret.pos+1 || token.quoted || parseErr != nil || !(boost > 0) || math.IsInf(boost, 0) {
+		return nil, p.errorf("positive boost like 2 or 0.5 after '^'")
+	}
+	p.next()
+	return q.WithBoost(q.EffectiveBoost() * boost), nil // 括号里的子句本身可能带了boost，如((a)^2)^3
+}
+
+// parseUnboosted 不带"^"的primary
+func (p *queryParser) parseUnboosted() (*TermQuery, error) {
+	switch p.peek().kind {
+	case tokenLParen:
+		p.next()
+		q, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokenRParen {
+			return nil, p.errorf("')'")
+		}
+		p.next()
+		if p.peek().kind == tokenTilde {
+			return p.parseMinimumShouldMatch(q)
+		}
+		return q, nil
+	case tokenText:
+		return p.parseTerm()
+	case tokenLBracket:
+		return p.parsePhrase("")
+	}
+	return nil, p.errorf("term or '('")
+}
+
+func (p *queryParser) parseTerm() (*TermQuery, error) {
+	first := p.next()
+	field, word := "", first
+	if p.peek().kind == tokenColon {
+		p.next()
+		if p.peek().kind == tokenLBracket {
+			return p.parsePhrase(first.text)
+		}
+		if p.peek().kind != tokenText {
+			return nil, p.errorf("word or '[' after ':'")
+		}
+		field, word = first.text, p.next()
+	}
+	if word.text == "" {
+		return nil, &SyntaxError{Pos: word.pos, Expected: "non-empty word", Found: `""`}
+	}
+	if next := p.peek(); !word.quoted && word.text == "*" && next.kind == tokenText && next.quoted && next.pos == word.end {
+		return p.parseSubstring(field)
+	}
+	if next := p.peek(); next.kind == tokenText && next.pos == word.end { // 如"go lang"*、go"lang"
+		return nil, p.errorf("whitespace or operator after the word")
+	}
+	if p.peek().kind == tokenTilde {
+		return p.parseFuzzy(field, word.text)
+	}
+	if !word.quoted {
+		if i := strings.IndexAny(word.text, "*?"); i == len(word.text)-1 && word.text[i] == '*' {
+			return NewPrefixQuery(field, word.text[:i], 0), nil
+		} else if i >= 0 {
+			return NewWildcardQuery(field, word.text, 0), nil
+		}
+	}
+	return NewTermQuery(field, word.text), nil
+}
+
+// parseSubstring 解析第一个"*"之后的 "带引号的文本" "*"
+func (p *queryParser) parseSubstring(field string) (*TermQuery, error) {
+	text := p.next()
+	if closing := p.peek(); closing.kind != tokenText || closing.quoted || closing.text != "*" || closing.pos != text.end {
+		return nil, p.errorf("'*' right after the quoted text")
+	}
+	p.next()
+	if text.text == "" {
+		return nil, &SyntaxError{Pos: text.pos, Expected: "non-empty text", Found: `""`}
+	}
+	return NewSubstringQuery(field, text.text), nil
+}
+
+// parseFuzzy 解析 "~" [ 0|1|2 [ "," prefixLength [ "," maxExpansions ] ] ]，数字必须紧跟在~后面，否则视为省略
+func (p *queryParser) parseFuzzy(field string, word string) (*TermQuery, error) {
+	tilde := p.next()
+	q := NewFuzzyQuery(field, word, 2, 0)
+	if token := p.peek(); token.kind == tokenText && token.pos == tilde.pos+1 && !token.quoted {
+		parts := strings.Split(token.text, ",")
+		numbers := make([]int, len(parts))
+		for i, part := range parts {
+			n, err := strconv.ParseInt(part, 10, 32)
+			if err != nil || n < 0 || len(parts) > 3 || (i == 0 && n > 2) {
+				return nil, p.errorf("edit distance 0, 1 or 2 after '~', optionally followed by ,prefix length and ,max expansions")
+			}
+			numbers[i] = int(n)
+		}
+		p.next()
+		q.Fuzzy.MaxEdits = int32(numbers[0])
+		if len(numbers) > 1 {
+			q.Fuzzy.PrefixLength = int32(numbers[1])
+		}
+		if len(numbers) > 2 {
+			q.Fuzzy.MaxExpansions = int32(numbers[2])
+		}
+	}
+	return q, nil
+}
+
+// parseMinimumShouldMatch 解析括号后面的 "~" msm。括号里是OR组时直接设置它的MinimumShouldMatch，
+// 否则(比如单个词、AND组、已经带了~的OR组)把整个括号当成唯一的Should子句。不带%的msm不能超过OR子句的个数，比如(a b c)~2是错的
+func (p *queryParser) parseMinimumShouldMatch(q *TermQuery) (*TermQuery, error) {
+	tilde := p.next()
+	token := p.peek()
+	if token.kind != tokenText || token.pos != tilde.pos+1 || token.quoted {
+		return nil, p.errorf("minimum should match like 2 or 60% after '~'")
+	}
+	n, percent, err := parseMinimumShouldMatch(token.text)
+	if err != nil {
+		return nil, p.errorf("minimum should match like 2 or 60% after '~'")
+	}
+	if !q.isShouldContainer() {
+		q = &TermQuery{Should: []*TermQuery{q}}
+	}
+	if !percent && n > len(q.Should) {
+		return nil, p.errorf(fmt.Sprintf("minimum should match at most %d, the number of OR clauses", len(q.Should)))
+	}
+	p.next()
+	q.MinimumShouldMatch = token.text
+	return q, nil
+}
+
+// parsePhrase 解析 "[" item+ "]" [ "~" 整数 ]，field是写在"["前面的字段名
+func (p *queryParser) parsePhrase(field string) (*TermQuery, error) {
+	p.next() // "["
+	keywords := make([]*Keyword, 0, 4)
+	for p.peek().kind != tokenRBracket {
+		if p.peek().kind != tokenText {
+			return nil, p.errorf("word or ']'")
+		}
+		token := p.next()
+		keyword := &Keyword{Field: field, Word: token.text}
+		if p.peek().kind == tokenColon {
+			p.next()
+			if p.peek().kind != tokenText {
+				return nil, p.errorf("word after ':'")
+			}
+			keyword = &Keyword{Field: token.text, Word: p.next().text}
+		}
+		if keyword.Word == "" {
+			return nil, &SyntaxError{Pos: token.pos, Expected: "non-empty word", Found: `""`}
+		}
+		keywords = append(keywords, keyword)
+	}
+	if len(keywords) == 0 {
+		return nil, p.errorf("word")
+	}
+	p.next() // "]"
+
+	var slop int32
+	if p.peek().kind == tokenTilde {
+		p.next()
+		token := p.peek()
+		n, err := strconv.ParseInt(token.text, 10, 32)
+		if token.kind != tokenText || err != nil || n < 0 {
+			return nil, p.errorf("non-negative integer after '~'")
+		}
+		p.next()
+		slop = int32(n)
+	}
+	return NewPhraseQuery(slop, keywords...), nil
+}
+
+// ParseQuery 把查询字符串解析成TermQuery，叶子节点使用结构化的Term
+func ParseQuery(query string) (*TermQuery, error) {
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	parser := &queryParser{tokens: tokens}
+	if parser.peek().kind == tokenEOF {
+		return nil, parser.errorf("term or '('")
+	}
+	q, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.peek().kind != tokenEOF {
+		return nil, parser.errorf("AND, OR or end of input")
+	}
+	return q, nil
+}
+
+// quoteIfNeeded 词里有空白、特殊字符、通配符，或者是AND/OR/NOT、以"-"开头时需要加引号
+func quoteIfNeeded(text string) string {
+	needQuote := text == "" || text == "AND" || text == "OR" || text == "NOT" || strings.HasPrefix(text, "-")
+	for _, r := range text {
+		if unicode.IsSpace(r) || isSpecial(r) || r == '\\' || r == '*' || r == '?' {
+			needQuote = true
+			break
+		}
+	}
+	if !needQuote {
+		return text
+	}
+	return quote(text)
+}
+
+// quote 加引号，引号和反斜杠要转义
+func quote(text string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text) + `"`
+}
+
+// isLeaf 叶子节点：只有Keyword、Phrase等检索条件，没有子节点
+func (q *TermQuery) isLeaf() bool {
+	return q.hasLeaf() && len(q.Must) == 0 && len(q.Should) == 0 && len(q.MustNot) == 0
+}
+
+// keywordToQueryString 把Keyword.ToString编码的keyword输出成field:word，defaultField与field相同时省略field
+func keywordToQueryString(keyword string, defaultField string) string {
+	field, word, found := strings.Cut(keyword, "\001")
+	if !found {
+		return quoteIfNeeded(keyword)
+	}
+	if field == defaultField {
+		return quoteIfNeeded(word)
+	}
+	return quoteIfNeeded(field) + ":" + quoteIfNeeded(word)
+}
+
+// fieldPrefix 输出"field:"，field为空时什么也不输出
+func fieldPrefix(field string) string {
+	if field == "" {
+		return ""
+	}
+	return quoteIfNeeded(field) + ":"
+}
+
+// phraseToQueryString 短语里所有词的field相同时把field提到"["前面
+func phraseToQueryString(phrase *PhraseQuery) string {
+	keys := phrase.Keys()
+	field := ""
+	for i, keyword := range keys {
+		f, _, _ := strings.Cut(keyword, "\001")
+		if i == 0 {
+			field = f
+		} else if f != field {
+			field = ""
+			break
+		}
+	}
+	items := make([]string, 0, len(keys))
+	for _, keyword := range keys {
+		items = append(items, keywordToQueryString(keyword, field))
+	}
+	result := "[" + strings.Join(items, " ") + "]"
+	if field != "" {
+		result = quoteIfNeeded(field) + ":" + result
+	}
+	if phrase.Slop > 0 {
+		result += "~" + strconv.Itoa(int(phrase.Slop))
+	}
+	return result
+}
+
+// boosted 节点带有不为1的boost，输出查询字符串时要加上"^boost"
+func (q *TermQuery) boosted() bool {
+	return q.Boost != 0 && q.Boost != 1
+}
+
+// ToQueryString 把TermQuery输出成ParseQuery能解析的查询字符串
+func (q *TermQuery) ToQueryString() string {
+	if q == nil {
+		return ""
+	}
+	if !q.boosted() {
+		return q.toQueryString()
+	}
+	s := q.toQueryString()
+	if !q.isLeaf() {
+		s = "(" + s + ")"
+	}
+	return s + "^" + strconv.FormatFloat(float64(q.Boost), 'g', -1, 32)
+}
+
+// toQueryString 不带boost的查询字符串
+func (q *TermQuery) toQueryString() string {
+	parts := make([]string, 0, 4)
+	// 子节点不是叶子时加括号，避免优先级问题。带boost的非叶子节点ToQueryString已经加过括号了
+	child := func(c *TermQuery) string {
+		if c.isLeaf() || c.boosted() {
+			return c.ToQueryString()
+		}
+		return "(" + c.ToQueryString() + ")"
+	}
+
+	if key := q.Key(); key != "" {
+		parts = append(parts, keywordToQueryString(key, ""))
+	} else if q.Phrase != nil {
+		parts = append(parts, phraseToQueryString(q.Phrase))
+	} else if q.Prefix != nil {
+		// 模式里的字符不能加引号，否则*会被当成普通字符
+		parts = append(parts, fieldPrefix(q.Prefix.Field)+q.Prefix.Prefix+"*")
+	} else if q.Wildcard != nil {
+		parts = append(parts, fieldPrefix(q.Wildcard.Field)+q.Wildcard.Pattern)
+	} else if q.Fuzzy != nil {
+		fuzzy := fieldPrefix(q.Fuzzy.Field) + quoteIfNeeded(q.Fuzzy.Word) + "~" + strconv.Itoa(int(q.Fuzzy.MaxEdits))
+		if q.Fuzzy.PrefixLength > 0 || q.Fuzzy.MaxExpansions > 0 {
+			fuzzy += "," + strconv.Itoa(int(q.Fuzzy.PrefixLength))
+		}
+		if q.Fuzzy.MaxExpansions > 0 {
+			fuzzy += "," + strconv.Itoa(int(q.Fuzzy.MaxExpansions))
+		}
+		parts = append(parts, fuzzy)
+	} else if q.Substring != nil {
+		parts = append(parts, fieldPrefix(q.Substring.Field)+"*"+quote(q.Substring.Text)+"*")
+	} else if len(q.Must) > 0 {
+		musts := make([]string, 0, len(q.Must))
+		for _, c := range q.Must {
+			musts = append(musts, child(c))
+		}
+		parts = append(parts, strings.Join(musts, " AND "))
+	} else if len(q.Should) > 0 {
+		shoulds := make([]string, 0, len(q.Should))
+		for _, c := range q.Should {
+			shoulds = append(shoulds, child(c))
+		}
+		should := strings.Join(shoulds, " OR ")
+		if q.MinimumShouldMatch != "" {
+			should = "(" + should + ")~" + q.MinimumShouldMatch
+		} else if len(q.MustNot) > 0 && len(shoulds) > 1 {
+			should = "(" + should + ")"
+		}
+		parts = append(parts, should)
+	}
+	for _, c := range q.MustNot {
+		parts = append(parts, "-"+child(c))
+	}
+	return strings.Join(parts, " ")
+}
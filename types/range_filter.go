@@ -0,0 +1,123 @@
+package types
+
+import "fmt"
+
+// 数值属性的范围过滤，builder模式：
+//
+//	NewIntRange("view").Gt(10000)                             // view > 10000
+//	NewIntRange("post_time").Gte(time.Now().Unix() - 7*86400) // 最近7天发布
+//	NewFloatRange("score").Gte(8.5).Lt(10)
+//	&RangeFilter{Ints: []*IntRange{NewIntRange("view").Gt(10000)}}
+
+// NewIntRange 没有上下界的范围，用Gt、Gte、Lt、Lte设置边界
+func NewIntRange(field string) *IntRange {
+	return &IntRange{Field: field}
+}
+
+func (r *IntRange) Gt(v int64) *IntRange {
+	r.Min, r.HasMin, r.ExcludeMin = v, true, true
+	return r
+}
+
+func (r *IntRange) Gte(v int64) *IntRange {
+	r.Min, r.HasMin, r.ExcludeMin = v, true, false
+	return r
+}
+
+func (r *IntRange) Lt(v int64) *IntRange {
+	r.Max, r.HasMax, r.ExcludeMax = v, true, true
+	return r
+}
+
+func (r *IntRange) Lte(v int64) *IntRange {
+	r.Max, r.HasMax, r.ExcludeMax = v, true, false
+	return r
+}
+
+// Contains v是否在范围内
+func (r *IntRange) Contains(v int64) bool {
+	if r.HasMin && (v < r.Min || (r.ExcludeMin && v == r.Min)) {
+		return false
+	}
+	if r.HasMax && (v > r.Max || (r.ExcludeMax && v == r.Max)) {
+		return false
+	}
+	return true
+}
+
+// NewFloatRange 没有上下界的范围，用Gt、Gte、Lt、Lte设置边界
+func NewFloatRange(field string) *FloatRange {
+	return &FloatRange{Field: field}
+}
+
+func (r *FloatRange) Gt(v float64) *FloatRange {
+	r.Min, r.HasMin, r.ExcludeMin = v, true, true
+	return r
+}
+
+func (r *FloatRange) Gte(v float64) *FloatRange {
+	r.Min, r.HasMin, r.ExcludeMin = v, true, false
+	return r
+}
+
+func (r *FloatRange) Lt(v float64) *FloatRange {
+	r.Max, r.HasMax, r.ExcludeMax = v, true, true
+	return r
+}
+
+func (r *FloatRange) Lte(v float64) *FloatRange {
+	r.Max, r.HasMax, r.ExcludeMax = v, true, false
+	return r
+}
+
+// Contains v是否在范围内，NaN不在任何有边界的范围内
+func (r *FloatRange) Contains(v float64) bool {
+	if r.HasMin && !(v > r.Min || (!r.ExcludeMin && v == r.Min)) {
+		return false
+	}
+	if r.HasMax && !(v < r.Max || (!r.ExcludeMax && v == r.Max)) {
+		return false
+	}
+	return true
+}
+
+// Empty 没有任何过滤条件，nil也是空的
+func (f *RangeFilter) Empty() bool {
+	return f == nil || (len(f.Ints) == 0 && len(f.Floats) == 0)
+}
+
+// Match 文档的数值属性是否满足所有范围条件，没有某个属性的文档不满足该属性上的条件
+func (f *RangeFilter) Match(ints map[string]int64, floats map[string]float64) bool {
+	if f.Empty() {
+		return true
+	}
+	for _, r := range f.Ints {
+		if v, ok := ints[r.Field]; !ok || !r.Contains(v) {
+			return false
+		}
+	}
+	for _, r := range f.Floats {
+		if v, ok := floats[r.Field]; !ok || !r.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate 每个范围都要指定属性名
+func (f *RangeFilter) Validate() error {
+	if f == nil {
+		return nil
+	}
+	for _, r := range f.Ints {
+		if r.Field == "" {
+			return fmt.Errorf("int range without field")
+		}
+	}
+	for _, r := range f.Floats {
+		if r.Field == "" {
+			return fmt.Errorf("float range without field")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,1190 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: types/range_filter.proto
+
+package types
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type IntRange struct {
+	Field      string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Min        int64  `protobuf:"varint,2,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max        int64  `protobuf:"varint,3,opt,name=Max,proto3" json:"Max,omitempty"`
+	HasMin     bool   `protobuf:"varint,4,opt,name=HasMin,proto3" json:"HasMin,omitempty"`
+	HasMax     bool   `protobuf:"varint,5,opt,name=HasMax,proto3" json:"HasMax,omitempty"`
+	ExcludeMin bool   `protobuf:"varint,6,opt,name=ExcludeMin,proto3" json:"ExcludeMin,omitempty"`
+	ExcludeMax bool   `protobuf:"varint,7,opt,name=ExcludeMax,proto3" json:"ExcludeMax,omitempty"`
+}
+
+func (m *IntRange) Reset()         { *m = IntRange{} }
+func (m *IntRange) String() string { return proto.CompactTextString(m) }
+func (*IntRange) ProtoMessage()    {}
+func (*IntRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ced6f2e53bec6dbf, []int{0}
+}
+func (m *IntRange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *IntRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_IntRange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *IntRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IntRange.Merge(m, src)
+}
+func (m *IntRange) XXX_Size() int {
+	return m.Size()
+}
+func (m *IntRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_IntRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IntRange proto.InternalMessageInfo
+
+func (m *IntRange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *IntRange) GetMin() int64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *IntRange) GetMax() int64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *IntRange) GetHasMin() bool {
+	if m != nil {
+		return m.HasMin
+	}
+	return false
+}
+
+func (m *IntRange) GetHasMax() bool {
+	if m != nil {
+		return m.HasMax
+	}
+	return false
+}
+
+func (m *IntRange) GetExcludeMin() bool {
+	if m != nil {
+		return m.ExcludeMin
+	}
+	return false
+}
+
+func (m *IntRange) GetExcludeMax() bool {
+	if m != nil {
+		return m.ExcludeMax
+	}
+	return false
+}
+
+type FloatRange struct {
+	Field      string  `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Min        float64 `protobuf:"fixed64,2,opt,name=Min,proto3" json:"Min,omitempty"`
+	Max        float64 `protobuf:"fixed64,3,opt,name=Max,proto3" json:"Max,omitempty"`
+	HasMin     bool    `protobuf:"varint,4,opt,name=HasMin,proto3" json:"HasMin,omitempty"`
+	HasMax     bool    `protobuf:"varint,5,opt,name=HasMax,proto3" json:"HasMax,omitempty"`
+	ExcludeMin bool    `protobuf:"varint,6,opt,name=ExcludeMin,proto3" json:"ExcludeMin,omitempty"`
+	ExcludeMax bool    `protobuf:"varint,7,opt,name=ExcludeMax,proto3" json:"ExcludeMax,omitempty"`
+}
+
+func (m *FloatRange) Reset()         { *m = FloatRange{} }
+func (m *FloatRange) String() string { return proto.CompactTextString(m) }
+func (*FloatRange) ProtoMessage()    {}
+func (*FloatRange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ced6f2e53bec6dbf, []int{1}
+}
+func (m *FloatRange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FloatRange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FloatRange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FloatRange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FloatRange.Merge(m, src)
+}
+func (m *FloatRange) XXX_Size() int {
+	return m.Size()
+}
+func (m *FloatRange) XXX_DiscardUnknown() {
+	xxx_messageInfo_FloatRange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FloatRange proto.InternalMessageInfo
+
+func (m *FloatRange) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *FloatRange) GetMin() float64 {
+	if m != nil {
+		return m.Min
+	}
+	return 0
+}
+
+func (m *FloatRange) GetMax() float64 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *FloatRange) GetHasMin() bool {
+	if m != nil {
+		return m.HasMin
+	}
+	return false
+}
+
+func (m *FloatRange) GetHasMax() bool {
+	if m != nil {
+		return m.HasMax
+	}
+	return false
+}
+
+func (m *FloatRange) GetExcludeMin() bool {
+	if m != nil {
+		return m.ExcludeMin
+	}
+	return false
+}
+
+func (m *FloatRange) GetExcludeMax() bool {
+	if m != nil {
+		return m.ExcludeMax
+	}
+	return false
+}
+
+type RangeFilter struct {
+	Ints   []*IntRange   `protobuf:"bytes,1,rep,name=Ints,proto3" json:"Ints,omitempty"`
+	Floats []*FloatRange `protobuf:"bytes,2,rep,name=Floats,proto3" json:"Floats,omitempty"`
+}
+
+func (m *RangeFilter) Reset()         { *m = RangeFilter{} }
+func (m *RangeFilter) String() string { return proto.CompactTextString(m) }
+func (*RangeFilter) ProtoMessage()    {}
+func (*RangeFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ced6f2e53bec6dbf, []int{2}
+}
+func (m *RangeFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RangeFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RangeFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RangeFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RangeFilter.Merge(m, src)
+}
+func (m *RangeFilter) XXX_Size() int {
+	return m.Size()
+}
+func (m *RangeFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_RangeFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RangeFilter proto.InternalMessageInfo
+
+func (m *RangeFilter) GetInts() []*IntRange {
+	if m != nil {
+		return m.Ints
+	}
+	return nil
+}
+
+func (m *RangeFilter) GetFloats() []*FloatRange {
+	if m != nil {
+		return m.Floats
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*IntRange)(nil), "types.IntRange")
+	proto.RegisterType((*FloatRange)(nil), "types.FloatRange")
+	proto.RegisterType((*RangeFilter)(nil), "types.RangeFilter")
+}
+
+func init() { proto.RegisterFile("types/range_filter.proto", fileDescriptor_ced6f2e53bec6dbf) }
+
+var fileDescriptor_ced6f2e53bec6dbf = []byte{
+	// 275 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x28, 0xa9, 0x2c, 0x48,
+	0x2d, 0xd6, 0x2f, 0x4a, 0xcc, 0x4b, 0x4f, 0x8d, 0x4f, 0xcb, 0xcc, 0x29, 0x49, 0x2d, 0xd2, 0x2b,
+	0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0xcb, 0x28, 0x6d, 0x61, 0xe4, 0xe2, 0xf0, 0xcc, 0x2b,
+	0x09, 0x02, 0x29, 0x10, 0x12, 0xe1, 0x62, 0x75, 0xcb, 0x4c, 0xcd, 0x49, 0x91, 0x60, 0x54, 0x60,
+	0xd4, 0xe0, 0x0c, 0x82, 0x70, 0x84, 0x04, 0xb8, 0x98, 0x7d, 0x33, 0xf3, 0x24, 0x98, 0x14, 0x18,
+	0x35, 0x98, 0x83, 0x40, 0x4c, 0xb0, 0x48, 0x62, 0x85, 0x04, 0x33, 0x54, 0x24, 0xb1, 0x42, 0x48,
+	0x8c, 0x8b, 0xcd, 0x23, 0xb1, 0x18, 0xa4, 0x8c, 0x45, 0x81, 0x51, 0x83, 0x23, 0x08, 0xca, 0x83,
+	0x89, 0x27, 0x56, 0x48, 0xb0, 0x22, 0xc4, 0x13, 0x2b, 0x84, 0xe4, 0xb8, 0xb8, 0x5c, 0x2b, 0x92,
+	0x73, 0x4a, 0x53, 0x52, 0x41, 0x7a, 0xd8, 0xc0, 0x72, 0x48, 0x22, 0xc8, 0xf2, 0x89, 0x15, 0x12,
+	0xec, 0xa8, 0xf2, 0x89, 0x15, 0x4a, 0xdb, 0x18, 0xb9, 0xb8, 0xdc, 0x72, 0xf2, 0x13, 0x89, 0x75,
+	0x38, 0x23, 0x86, 0xc3, 0x19, 0x07, 0xc6, 0xe1, 0xb1, 0x5c, 0xdc, 0x60, 0x27, 0xbb, 0x81, 0xe3,
+	0x42, 0x48, 0x99, 0x8b, 0xc5, 0x33, 0xaf, 0xa4, 0x58, 0x82, 0x51, 0x81, 0x59, 0x83, 0xdb, 0x88,
+	0x5f, 0x0f, 0x1c, 0x29, 0x7a, 0xb0, 0x08, 0x09, 0x02, 0x4b, 0x0a, 0x69, 0x72, 0xb1, 0x81, 0xfd,
+	0x5a, 0x2c, 0xc1, 0x04, 0x56, 0x26, 0x08, 0x55, 0x86, 0x08, 0x80, 0x20, 0xa8, 0x02, 0x27, 0xd5,
+	0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39,
+	0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0xe2, 0x0e, 0x72, 0x74, 0xf1, 0x74,
+	0xd6, 0x07, 0xeb, 0x4c, 0x62, 0x03, 0xa7, 0x01, 0x63, 0xc0, 0x00, 0x39, 0xef, 0x3a, 0xa8, 0x1f,
+	0x02, 0x00, 0x00,
+}
+
+func (m *IntRange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *IntRange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *IntRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExcludeMax {
+		i--
+		if m.ExcludeMax {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ExcludeMin {
+		i--
+		if m.ExcludeMin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.HasMax {
+		i--
+		if m.HasMax {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.HasMin {
+		i--
+		if m.HasMin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Max != 0 {
+		i = encodeVarintRangeFilter(dAtA, i, uint64(m.Max))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Min != 0 {
+		i = encodeVarintRangeFilter(dAtA, i, uint64(m.Min))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintRangeFilter(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FloatRange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FloatRange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FloatRange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExcludeMax {
+		i--
+		if m.ExcludeMax {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ExcludeMin {
+		i--
+		if m.ExcludeMin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.HasMax {
+		i--
+		if m.HasMax {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.HasMin {
+		i--
+		if m.HasMin {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Max != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Max))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.Min != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Min))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintRangeFilter(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RangeFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RangeFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RangeFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Floats) > 0 {
+		for iNdEx := len(m.Floats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Floats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRangeFilter(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Ints) > 0 {
+		for iNdEx := len(m.Ints) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ints[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRangeFilter(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRangeFilter(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRangeFilter(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *IntRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovRangeFilter(uint64(l))
+	}
+	if m.Min != 0 {
+		n += 1 + sovRangeFilter(uint64(m.Min))
+	}
+	if m.Max != 0 {
+		n += 1 + sovRangeFilter(uint64(m.Max))
+	}
+	if m.HasMin {
+		n += 2
+	}
+	if m.HasMax {
+		n += 2
+	}
+	if m.ExcludeMin {
+		n += 2
+	}
+	if m.ExcludeMax {
+		n += 2
+	}
+	return n
+}
+
+func (m *FloatRange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovRangeFilter(uint64(l))
+	}
+	if m.Min != 0 {
+		n += 9
+	}
+	if m.Max != 0 {
+		n += 9
+	}
+	if m.HasMin {
+		n += 2
+	}
+	if m.HasMax {
+		n += 2
+	}
+	if m.ExcludeMin {
+		n += 2
+	}
+	if m.ExcludeMax {
+		n += 2
+	}
+	return n
+}
+
+func (m *RangeFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ints) > 0 {
+		for _, e := range m.Ints {
+			l = e.Size()
+			n += 1 + l + sovRangeFilter(uint64(l))
+		}
+	}
+	if len(m.Floats) > 0 {
+		for _, e := range m.Floats {
+			l = e.Size()
+			n += 1 + l + sovRangeFilter(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovRangeFilter(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozRangeFilter(x uint64) (n int) {
+	return sovRangeFilter(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *IntRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRangeFilter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: IntRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: IntRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			m.Min = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Min |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			m.Max = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Max |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasMin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasMin = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasMax", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasMax = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeMin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeMin = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeMax", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeMax = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRangeFilter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FloatRange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRangeFilter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FloatRange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FloatRange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Min", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Min = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Max", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Max = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasMin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasMin = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HasMax", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.HasMax = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeMin", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeMin = bool(v != 0)
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExcludeMax", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ExcludeMax = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRangeFilter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *RangeFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRangeFilter
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RangeFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RangeFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ints", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ints = append(m.Ints, &IntRange{})
+			if err := m.Ints[len(m.Ints)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Floats", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Floats = append(m.Floats, &FloatRange{})
+			if err := m.Floats[len(m.Floats)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRangeFilter(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRangeFilter
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipRangeFilter(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowRangeFilter
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowRangeFilter
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthRangeFilter
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupRangeFilter
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthRangeFilter
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthRangeFilter        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowRangeFilter          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupRangeFilter = fmt.Errorf("proto: unexpected end of group")
+)
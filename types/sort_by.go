@@ -0,0 +1,32 @@
+package types
+
+import "fmt"
+
+const (
+	SORT_BY_SCORE = "_score" // 按BM25得分排序的伪属性名
+)
+
+// NewSortBy 按field排序，desc为true时降序
+func NewSortBy(field string, desc bool) *SortBy {
+	return &SortBy{Field: field, Desc: desc}
+}
+
+// SortsByScore 排序条件里有没有按得分排序，有的话检索时需要打分
+func SortsByScore(sortBy []*SortBy) bool {
+	for _, s := range sortBy {
+		if s.Field == SORT_BY_SCORE {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateSortBy 每个排序条件都要指定属性名
+func ValidateSortBy(sortBy []*SortBy) error {
+	for _, s := range sortBy {
+		if s == nil || s.Field == "" {
+			return fmt.Errorf("sort by without field")
+		}
+	}
+	return nil
+}
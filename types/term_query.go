@@ -1,5 +1,12 @@
 package types
 
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
 // AST（抽象语法树）实现搜索表达式
 // builder模式
 
@@ -7,10 +14,99 @@ package types
 //	Must    []*TermQuery
 //	Should  []*TermQuery
 //	Keyword string
+//	MustNot []*TermQuery
+//	Phrase  *PhraseQuery
+//	Prefix   *PrefixQuery
+//	Wildcard *WildcardQuery
+//	Fuzzy    *FuzzyQuery
+//	MinimumShouldMatch string
+//	Term     *Keyword
+//	Boost    float32
+//	Substring *SubstringQuery
 //}
 
+// 注意：MustNot只做排除，只有MustNot没有其他条件的节点不会命中任何文档
+
+// hasLeaf 节点自身带有检索条件(Term、Keyword、Phrase、Prefix、Wildcard、Fuzzy、Substring)，而不只是子节点的容器
+func (q *TermQuery) hasLeaf() bool {
+	return q.Key() != "" || q.Phrase != nil || q.Prefix != nil || q.Wildcard != nil || q.Fuzzy != nil || q.Substring != nil
+}
+
 func (q *TermQuery) Empty() bool {
-	return q.Keyword == "" && len(q.Must) == 0 && len(q.Should) == 0
+	return !q.hasLeaf() && len(q.Must) == 0 && len(q.Should) == 0 && len(q.MustNot) == 0
+}
+
+// EffectiveBoost 节点自身的boost，没设置(0)时为1
+func (q *TermQuery) EffectiveBoost() float64 {
+	if q.Boost == 0 {
+		return 1
+	}
+	return float64(q.Boost)
+}
+
+// WithBoost 设置节点的boost，返回q本身，方便链式调用
+func (q *TermQuery) WithBoost(boost float64) *TermQuery {
+	q.Boost = float32(boost)
+	return q
+}
+
+// boostChildren 打平容器节点时把它的boost乘到子节点上，打平前后每个keyword的得分不变。
+// 子节点可能还被别的查询树引用，不能原地修改，带boost时复制一份
+func boostChildren(children []*TermQuery, boost float32) []*TermQuery {
+	if boost == 0 || boost == 1 {
+		return children
+	}
+	result := make([]*TermQuery, 0, len(children))
+	for _, child := range children {
+		boosted := *child
+		boosted.Boost = float32(child.EffectiveBoost() * float64(boost))
+		result = append(result, &boosted)
+	}
+	return result
+}
+
+// NewTermQuery 单个keyword的查询
+func NewTermQuery(field string, word string) *TermQuery {
+	return &TermQuery{Term: &Keyword{Field: field, Word: word}}
+}
+
+// Key 叶子节点的keyword编码后的key，与建索引时Keyword.ToString的结果一致。Term优先，没有Term时用旧的Keyword字符串
+func (q *TermQuery) Key() string {
+	if q.Term != nil {
+		return q.Term.ToString()
+	}
+	return q.Keyword
+}
+
+// NewPhraseQuery 短语查询，keywords按在文档中出现的顺序排列，slop为0表示精确短语
+func NewPhraseQuery(slop int32, keywords ...*Keyword) *TermQuery {
+	phrase := &PhraseQuery{Terms: make([]*Keyword, 0, len(keywords)), Slop: slop}
+	for _, keyword := range keywords {
+		phrase.Terms = append(phrase.Terms, &Keyword{Field: keyword.Field, Word: keyword.Word})
+	}
+	return &TermQuery{Phrase: phrase}
+}
+
+// Keys 短语里按顺序排列的keyword编码后的key。Terms优先，没有Terms时用旧的Keywords
+func (p *PhraseQuery) Keys() []string {
+	if len(p.Terms) == 0 {
+		return p.Keywords
+	}
+	keys := make([]string, 0, len(p.Terms))
+	for _, term := range p.Terms {
+		keys = append(keys, term.ToString())
+	}
+	return keys
+}
+
+// NewPrefixQuery 前缀查询，maxExpansions为0时使用默认的展开上限
+func NewPrefixQuery(field string, prefix string, maxExpansions int32) *TermQuery {
+	return &TermQuery{Prefix: &PrefixQuery{Field: field, Prefix: prefix, MaxExpansions: maxExpansions}}
+}
+
+// NewWildcardQuery 通配符查询，maxExpansions为0时使用默认的展开上限
+func NewWildcardQuery(field string, pattern string, maxExpansions int32) *TermQuery {
+	return &TermQuery{Wildcard: &WildcardQuery{Field: field, Pattern: pattern, MaxExpansions: maxExpansions}}
 }
 
 // And 实现 AND 逻辑
@@ -21,12 +117,15 @@ func (q *TermQuery) And(queries ...*TermQuery) *TermQuery {
 
 	// 预估容量：1 (q本身) + 参数长度
 	mergedMust := make([]*TermQuery, 0, 1+len(queries))
+	var mergedMustNot []*TermQuery
 
 	// 逻辑优化：扁平化 (Flatten)
 	// 如果 q 本身就是一个纯粹的 "Must" 容器（没有 Keyword 也没有 Should），
 	// 我们可以把它的子节点直接提取出来，而不是把它作为一层嵌套。
-	if q.Keyword == "" && len(q.Should) == 0 && len(q.Must) > 0 {
-		mergedMust = append(mergedMust, q.Must...)
+	// AND满足结合律：(A AND NOT C) AND B = A AND B AND NOT C，所以MustNot也一并提取出来。MustNot不打分，不用管boost
+	if !q.hasLeaf() && len(q.Should) == 0 && len(q.Must) > 0 {
+		mergedMust = append(mergedMust, boostChildren(q.Must, q.Boost)...)
+		mergedMustNot = append(mergedMustNot, q.MustNot...)
 	} else if !q.Empty() {
 		mergedMust = append(mergedMust, q)
 	}
@@ -37,14 +136,21 @@ func (q *TermQuery) And(queries ...*TermQuery) *TermQuery {
 			continue
 		}
 		// 同样的逻辑，如果传入的也是纯 Must 容器，也可以打平
-		if ele.Keyword == "" && len(ele.Should) == 0 && len(ele.Must) > 0 {
-			mergedMust = append(mergedMust, ele.Must...)
+		if !ele.hasLeaf() && len(ele.Should) == 0 && len(ele.Must) > 0 {
+			mergedMust = append(mergedMust, boostChildren(ele.Must, ele.Boost)...)
+			mergedMustNot = append(mergedMustNot, ele.MustNot...)
 		} else {
 			mergedMust = append(mergedMust, ele)
 		}
 	}
 
-	return &TermQuery{Must: mergedMust}
+	return &TermQuery{Must: mergedMust, MustNot: mergedMustNot}
+}
+
+// isShouldContainer 纯粹的Should容器，OR时可以打平。
+// 带MustNot的不能打平：(A OR B) AND NOT C 打平后MustNot就丢了；带MinimumShouldMatch的也不能，(A OR B)~2 OR C 和 A OR B OR C 不等价
+func (q *TermQuery) isShouldContainer() bool {
+	return !q.hasLeaf() && len(q.Must) == 0 && len(q.MustNot) == 0 && len(q.Should) > 0 && q.MinimumShouldMatch == ""
 }
 
 // Or 实现 OR 逻辑 (对应 Should 字段)
@@ -59,8 +165,8 @@ func (q *TermQuery) Or(queries ...*TermQuery) *TermQuery {
 	// 1. 处理接收者 q
 	// 逻辑优化：如果 q 本身就是一个纯粹的 "Should" 容器（没有 Keyword 也没有 Must），
 	// 我们可以把它的子节点直接提取出来合并，实现扁平化。
-	if q.Keyword == "" && len(q.Must) == 0 && len(q.Should) > 0 {
-		mergedShould = append(mergedShould, q.Should...) // 存元素 Must: [ A, B, C ]
+	if q.isShouldContainer() {
+		mergedShould = append(mergedShould, boostChildren(q.Should, q.Boost)...) // 存元素 Must: [ A, B, C ]
 	} else if !q.Empty() {
 		mergedShould = append(mergedShould, q) // 存切片 Must: [ {Must: [A, B]}, C ]
 	}
@@ -71,8 +177,8 @@ func (q *TermQuery) Or(queries ...*TermQuery) *TermQuery {
 			continue
 		}
 		// 对参数也做同样的扁平化处理
-		if ele.Keyword == "" && len(ele.Must) == 0 && len(ele.Should) > 0 {
-			mergedShould = append(mergedShould, ele.Should...)
+		if ele.isShouldContainer() {
+			mergedShould = append(mergedShould, boostChildren(ele.Should, ele.Boost)...)
 		} else {
 			mergedShould = append(mergedShould, ele)
 		}
@@ -80,3 +186,218 @@ func (q *TermQuery) Or(queries ...*TermQuery) *TermQuery {
 
 	return &TermQuery{Should: mergedShould}
 }
+
+// AtLeast 至少命中queries中的n个，n<=1时等价于Or
+func AtLeast(n int, queries ...*TermQuery) *TermQuery {
+	q := &TermQuery{Should: make([]*TermQuery, 0, len(queries))}
+	for _, ele := range queries {
+		if !ele.Empty() {
+			q.Should = append(q.Should, ele)
+		}
+	}
+	if n > 1 {
+		q.MinimumShouldMatch = strconv.Itoa(n)
+	}
+	return q
+}
+
+// Not 实现 NOT 逻辑 (对应 MustNot 字段)：q AND NOT (queries[0] OR queries[1] ...)
+func (q *TermQuery) Not(queries ...*TermQuery) *TermQuery {
+	if len(queries) == 0 {
+		return q
+	}
+
+	result := &TermQuery{}
+
+	// 1. 处理接收者 q：纯 Must 容器直接打平，保留它已有的 MustNot
+	if !q.hasLeaf() && len(q.Should) == 0 && len(q.Must) > 0 {
+		result.Must = append(result.Must, boostChildren(q.Must, q.Boost)...)
+		result.MustNot = append(result.MustNot, q.MustNot...)
+	} else if !q.Empty() {
+		result.Must = append(result.Must, q)
+	}
+
+	// 2. 处理要排除的 queries
+	for _, ele := range queries {
+		if ele.Empty() {
+			continue
+		}
+		result.MustNot = append(result.MustNot, ele)
+	}
+
+	return result
+}
+
+// NewFuzzyQuery 模糊查询，maxEdits只支持0~2，前prefixLength个字符必须完全一致
+func NewFuzzyQuery(field string, word string, maxEdits int32, prefixLength int32) *TermQuery {
+	return &TermQuery{Fuzzy: &FuzzyQuery{Field: field, Word: word, MaxEdits: maxEdits, PrefixLength: prefixLength}}
+}
+
+// LeafKeywords 返回查询树所有叶子节点上的keyword(去重)，打分时用来查找每个词的倒排链。MustNot下的keyword不参与打分，不会返回
+func (q *TermQuery) LeafKeywords() []string {
+	terms := q.LeafTerms()
+	keywords := make([]string, 0, len(terms))
+	for _, term := range terms {
+		keywords = append(keywords, term.Key)
+	}
+	return keywords
+}
+
+// BoostedKey 参与打分的keyword和它的boost
+type BoostedKey struct {
+	Key   string
+	Boost float64 // 从根节点到叶子路径上所有节点boost的乘积
+}
+
+// LeafTerms 与LeafKeywords一样去重、顺序一致，同时给出每个keyword的boost。同一个keyword出现在多处时取最大的boost。
+// 前缀、通配符、模糊、子串查询要查词典才知道展开成哪些词，不在其中，打分时见LeafTermsWith
+func (q *TermQuery) LeafTerms() []BoostedKey {
+	return q.LeafTermsWith(nil)
+}
+
+// LeafTermsWith 与LeafTerms相同，expand不为nil时再加上它对每个节点返回的词(比如前缀查询展开出的词)，boost与该节点相同
+func (q *TermQuery) LeafTermsWith(expand func(q *TermQuery) []string) []BoostedKey {
+	index := make(map[string]int, 4)
+	terms := make([]BoostedKey, 0, 4)
+	var walk func(q *TermQuery, boost float64)
+	walk = func(q *TermQuery, boost float64) {
+		if q == nil {
+			return
+		}
+		boost *= q.EffectiveBoost()
+		add := func(keyword string) {
+			if i, exists := index[keyword]; !exists {
+				index[keyword] = len(terms)
+				terms = append(terms, BoostedKey{Key: keyword, Boost: boost})
+			} else if boost > terms[i].Boost {
+				terms[i].Boost = boost
+			}
+		}
+		if key := q.Key(); key != "" {
+			add(key)
+		}
+		if q.Phrase != nil {
+			for _, keyword := range q.Phrase.Keys() {
+				add(keyword)
+			}
+		}
+		if expand != nil {
+			for _, keyword := range expand(q) {
+				add(keyword)
+			}
+		}
+		for _, ele := range q.Must {
+			walk(ele, boost)
+		}
+		for _, ele := range q.Should {
+			walk(ele, boost)
+		}
+	}
+	walk(q, 1)
+	return terms
+}
+
+// parseMinimumShouldMatch 解析MinimumShouldMatch，"N"返回(N, false)，"N%"返回(N, true)
+func parseMinimumShouldMatch(spec string) (int, bool, error) {
+	text, percent := strings.CutSuffix(spec, "%")
+	n, err := strconv.Atoi(text)
+	if err != nil || n < 0 || (percent && n > 100) {
+		return 0, false, fmt.Errorf("invalid MinimumShouldMatch %q, want a non-negative integer or a percentage between 0%% and 100%%", spec)
+	}
+	return n, percent, nil
+}
+
+// MinShouldMatch 至少要命中的Should子句个数。没设置时为1，即普通的OR；百分比按Should子句个数向下取整，但至少为1。
+// 结果可能大于Should子句的个数(Validate会拒绝这样的查询)，此时不会命中任何文档
+func (q *TermQuery) MinShouldMatch() (int, error) {
+	if q.MinimumShouldMatch == "" {
+		return 1, nil
+	}
+	n, percent, err := parseMinimumShouldMatch(q.MinimumShouldMatch)
+	if err != nil {
+		return 0, err
+	}
+	if percent {
+		n = len(q.Should) * n / 100
+	}
+	return max(n, 1), nil
+}
+
+// validateKey 旧写法的keyword字符串必须是Keyword.ToString编码过的，否则一定查不到
+func validateKey(key string) error {
+	if !strings.Contains(key, "\001") {
+		return fmt.Errorf("keyword %q is not encoded as Keyword.ToString, use Term instead", key)
+	}
+	return nil
+}
+
+// validateTerm 结构化的keyword，Word不能为空
+func validateTerm(term *Keyword) error {
+	if term.Word == "" {
+		return fmt.Errorf("keyword with field %q has empty word", term.Field)
+	}
+	return nil
+}
+
+// Validate 检查查询树是否合法：keyword的编码、Term与Keyword是否冲突、短语是否为空、MinimumShouldMatch的格式和是否超过Should子句个数等。
+// 这些错误在倒排索引里都只会表现为查不到文档，所以在服务的入口处检查，把错误返回给调用方
+func (q *TermQuery) Validate() error {
+	if q == nil {
+		return fmt.Errorf("empty query")
+	}
+	if q.Term != nil {
+		if err := validateTerm(q.Term); err != nil {
+			return err
+		}
+		if q.Keyword != "" && q.Keyword != q.Term.ToString() {
+			return fmt.Errorf("both Term %s:%s and Keyword %q are set but differ", q.Term.Field, q.Term.Word, q.Keyword)
+		}
+	} else if q.Keyword != "" {
+		if err := validateKey(q.Keyword); err != nil {
+			return err
+		}
+	}
+	if q.Phrase != nil {
+		if len(q.Phrase.Terms) == 0 && len(q.Phrase.Keywords) == 0 {
+			return fmt.Errorf("phrase has no keyword")
+		}
+		for _, term := range q.Phrase.Terms {
+			if err := validateTerm(term); err != nil {
+				return err
+			}
+		}
+		if len(q.Phrase.Terms) == 0 {
+			for _, key := range q.Phrase.Keywords {
+				if err := validateKey(key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	if q.Substring != nil && q.Substring.Text == "" {
+		return fmt.Errorf("substring with field %q has empty text", q.Substring.Field)
+	}
+	if q.MinimumShouldMatch != "" {
+		n, percent, err := parseMinimumShouldMatch(q.MinimumShouldMatch)
+		if err != nil {
+			return err
+		}
+		if !percent && n > len(q.Should) {
+			return fmt.Errorf("MinimumShouldMatch %d is larger than the number of Should clauses %d", n, len(q.Should))
+		}
+	}
+	if q.Boost < 0 || math.IsNaN(float64(q.Boost)) || math.IsInf(float64(q.Boost), 0) {
+		return fmt.Errorf("invalid boost %v, want a positive number", q.Boost)
+	}
+	if q.Empty() {
+		return fmt.Errorf("empty query")
+	}
+	for _, children := range [][]*TermQuery{q.Must, q.Should, q.MustNot} {
+		for _, child := range children {
+			if err := child.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -1,9 +1,10 @@
 // Code generated by protoc-gen-gogo. DO NOT EDIT.
-// source: term_query.proto
+// source: types/term_query.proto
 
 package types
 
 import (
+	encoding_binary "encoding/binary"
 	fmt "fmt"
 	proto "github.com/gogo/protobuf/proto"
 	io "io"
@@ -22,17 +23,334 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type PhraseQuery struct {
+	Keywords []string   `protobuf:"bytes,1,rep,name=Keywords,proto3" json:"Keywords,omitempty"`
+	Slop     int32      `protobuf:"varint,2,opt,name=Slop,proto3" json:"Slop,omitempty"`
+	Terms    []*Keyword `protobuf:"bytes,3,rep,name=Terms,proto3" json:"Terms,omitempty"`
+}
+
+func (m *PhraseQuery) Reset()         { *m = PhraseQuery{} }
+func (m *PhraseQuery) String() string { return proto.CompactTextString(m) }
+func (*PhraseQuery) ProtoMessage()    {}
+func (*PhraseQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_218f21b8949236d1, []int{0}
+}
+func (m *PhraseQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PhraseQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PhraseQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PhraseQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhraseQuery.Merge(m, src)
+}
+func (m *PhraseQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *PhraseQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhraseQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PhraseQuery proto.InternalMessageInfo
+
+func (m *PhraseQuery) GetKeywords() []string {
+	if m != nil {
+		return m.Keywords
+	}
+	return nil
+}
+
+func (m *PhraseQuery) GetSlop() int32 {
+	if m != nil {
+		return m.Slop
+	}
+	return 0
+}
+
+func (m *PhraseQuery) GetTerms() []*Keyword {
+	if m != nil {
+		return m.Terms
+	}
+	return nil
+}
+
+type PrefixQuery struct {
+	Field         string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Prefix        string `protobuf:"bytes,2,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	MaxExpansions int32  `protobuf:"varint,3,opt,name=MaxExpansions,proto3" json:"MaxExpansions,omitempty"`
+}
+
+func (m *PrefixQuery) Reset()         { *m = PrefixQuery{} }
+func (m *PrefixQuery) String() string { return proto.CompactTextString(m) }
+func (*PrefixQuery) ProtoMessage()    {}
+func (*PrefixQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_218f21b8949236d1, []int{1}
+}
+func (m *PrefixQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PrefixQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PrefixQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PrefixQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrefixQuery.Merge(m, src)
+}
+func (m *PrefixQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *PrefixQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrefixQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrefixQuery proto.InternalMessageInfo
+
+func (m *PrefixQuery) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *PrefixQuery) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *PrefixQuery) GetMaxExpansions() int32 {
+	if m != nil {
+		return m.MaxExpansions
+	}
+	return 0
+}
+
+type WildcardQuery struct {
+	Field         string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Pattern       string `protobuf:"bytes,2,opt,name=Pattern,proto3" json:"Pattern,omitempty"`
+	MaxExpansions int32  `protobuf:"varint,3,opt,name=MaxExpansions,proto3" json:"MaxExpansions,omitempty"`
+}
+
+func (m *WildcardQuery) Reset()         { *m = WildcardQuery{} }
+func (m *WildcardQuery) String() string { return proto.CompactTextString(m) }
+func (*WildcardQuery) ProtoMessage()    {}
+func (*WildcardQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_218f21b8949236d1, []int{2}
+}
+func (m *WildcardQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WildcardQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WildcardQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WildcardQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WildcardQuery.Merge(m, src)
+}
+func (m *WildcardQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *WildcardQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_WildcardQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WildcardQuery proto.InternalMessageInfo
+
+func (m *WildcardQuery) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *WildcardQuery) GetPattern() string {
+	if m != nil {
+		return m.Pattern
+	}
+	return ""
+}
+
+func (m *WildcardQuery) GetMaxExpansions() int32 {
+	if m != nil {
+		return m.MaxExpansions
+	}
+	return 0
+}
+
+type FuzzyQuery struct {
+	Field         string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Word          string `protobuf:"bytes,2,opt,name=Word,proto3" json:"Word,omitempty"`
+	MaxEdits      int32  `protobuf:"varint,3,opt,name=MaxEdits,proto3" json:"MaxEdits,omitempty"`
+	PrefixLength  int32  `protobuf:"varint,4,opt,name=PrefixLength,proto3" json:"PrefixLength,omitempty"`
+	MaxExpansions int32  `protobuf:"varint,5,opt,name=MaxExpansions,proto3" json:"MaxExpansions,omitempty"`
+}
+
+func (m *FuzzyQuery) Reset()         { *m = FuzzyQuery{} }
+func (m *FuzzyQuery) String() string { return proto.CompactTextString(m) }
+func (*FuzzyQuery) ProtoMessage()    {}
+func (*FuzzyQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_218f21b8949236d1, []int{3}
+}
+func (m *FuzzyQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FuzzyQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FuzzyQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FuzzyQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FuzzyQuery.Merge(m, src)
+}
+func (m *FuzzyQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *FuzzyQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_FuzzyQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FuzzyQuery proto.InternalMessageInfo
+
+func (m *FuzzyQuery) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *FuzzyQuery) GetWord() string {
+	if m != nil {
+		return m.Word
+	}
+	return ""
+}
+
+func (m *FuzzyQuery) GetMaxEdits() int32 {
+	if m != nil {
+		return m.MaxEdits
+	}
+	return 0
+}
+
+func (m *FuzzyQuery) GetPrefixLength() int32 {
+	if m != nil {
+		return m.PrefixLength
+	}
+	return 0
+}
+
+func (m *FuzzyQuery) GetMaxExpansions() int32 {
+	if m != nil {
+		return m.MaxExpansions
+	}
+	return 0
+}
+
+type SubstringQuery struct {
+	Field string `protobuf:"bytes,1,opt,name=Field,proto3" json:"Field,omitempty"`
+	Text  string `protobuf:"bytes,2,opt,name=Text,proto3" json:"Text,omitempty"`
+}
+
+func (m *SubstringQuery) Reset()         { *m = SubstringQuery{} }
+func (m *SubstringQuery) String() string { return proto.CompactTextString(m) }
+func (*SubstringQuery) ProtoMessage()    {}
+func (*SubstringQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_218f21b8949236d1, []int{4}
+}
+func (m *SubstringQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubstringQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubstringQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubstringQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubstringQuery.Merge(m, src)
+}
+func (m *SubstringQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubstringQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubstringQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubstringQuery proto.InternalMessageInfo
+
+func (m *SubstringQuery) GetField() string {
+	if m != nil {
+		return m.Field
+	}
+	return ""
+}
+
+func (m *SubstringQuery) GetText() string {
+	if m != nil {
+		return m.Text
+	}
+	return ""
+}
+
 type TermQuery struct {
-	Must    []*TermQuery `protobuf:"bytes,1,rep,name=Must,proto3" json:"Must,omitempty"`
-	Should  []*TermQuery `protobuf:"bytes,2,rep,name=Should,proto3" json:"Should,omitempty"`
-	Keyword string       `protobuf:"bytes,3,opt,name=Keyword,proto3" json:"Keyword,omitempty"`
+	Must               []*TermQuery    `protobuf:"bytes,1,rep,name=Must,proto3" json:"Must,omitempty"`
+	Should             []*TermQuery    `protobuf:"bytes,2,rep,name=Should,proto3" json:"Should,omitempty"`
+	Keyword            string          `protobuf:"bytes,3,opt,name=Keyword,proto3" json:"Keyword,omitempty"`
+	MustNot            []*TermQuery    `protobuf:"bytes,4,rep,name=MustNot,proto3" json:"MustNot,omitempty"`
+	Phrase             *PhraseQuery    `protobuf:"bytes,5,opt,name=Phrase,proto3" json:"Phrase,omitempty"`
+	Prefix             *PrefixQuery    `protobuf:"bytes,6,opt,name=Prefix,proto3" json:"Prefix,omitempty"`
+	Wildcard           *WildcardQuery  `protobuf:"bytes,7,opt,name=Wildcard,proto3" json:"Wildcard,omitempty"`
+	Fuzzy              *FuzzyQuery     `protobuf:"bytes,8,opt,name=Fuzzy,proto3" json:"Fuzzy,omitempty"`
+	MinimumShouldMatch string          `protobuf:"bytes,9,opt,name=MinimumShouldMatch,proto3" json:"MinimumShouldMatch,omitempty"`
+	Term               *Keyword        `protobuf:"bytes,10,opt,name=Term,proto3" json:"Term,omitempty"`
+	Boost              float32         `protobuf:"fixed32,11,opt,name=Boost,proto3" json:"Boost,omitempty"`
+	Substring          *SubstringQuery `protobuf:"bytes,12,opt,name=Substring,proto3" json:"Substring,omitempty"`
 }
 
 func (m *TermQuery) Reset()         { *m = TermQuery{} }
 func (m *TermQuery) String() string { return proto.CompactTextString(m) }
 func (*TermQuery) ProtoMessage()    {}
 func (*TermQuery) Descriptor() ([]byte, []int) {
-	return fileDescriptor_cbb9280914c3e3fe, []int{0}
+	return fileDescriptor_218f21b8949236d1, []int{5}
 }
 func (m *TermQuery) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -82,27 +400,119 @@ func (m *TermQuery) GetKeyword() string {
 	return ""
 }
 
+func (m *TermQuery) GetMustNot() []*TermQuery {
+	if m != nil {
+		return m.MustNot
+	}
+	return nil
+}
+
+func (m *TermQuery) GetPhrase() *PhraseQuery {
+	if m != nil {
+		return m.Phrase
+	}
+	return nil
+}
+
+func (m *TermQuery) GetPrefix() *PrefixQuery {
+	if m != nil {
+		return m.Prefix
+	}
+	return nil
+}
+
+func (m *TermQuery) GetWildcard() *WildcardQuery {
+	if m != nil {
+		return m.Wildcard
+	}
+	return nil
+}
+
+func (m *TermQuery) GetFuzzy() *FuzzyQuery {
+	if m != nil {
+		return m.Fuzzy
+	}
+	return nil
+}
+
+func (m *TermQuery) GetMinimumShouldMatch() string {
+	if m != nil {
+		return m.MinimumShouldMatch
+	}
+	return ""
+}
+
+func (m *TermQuery) GetTerm() *Keyword {
+	if m != nil {
+		return m.Term
+	}
+	return nil
+}
+
+func (m *TermQuery) GetBoost() float32 {
+	if m != nil {
+		return m.Boost
+	}
+	return 0
+}
+
+func (m *TermQuery) GetSubstring() *SubstringQuery {
+	if m != nil {
+		return m.Substring
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterType((*PhraseQuery)(nil), "types.PhraseQuery")
+	proto.RegisterType((*PrefixQuery)(nil), "types.PrefixQuery")
+	proto.RegisterType((*WildcardQuery)(nil), "types.WildcardQuery")
+	proto.RegisterType((*FuzzyQuery)(nil), "types.FuzzyQuery")
+	proto.RegisterType((*SubstringQuery)(nil), "types.SubstringQuery")
 	proto.RegisterType((*TermQuery)(nil), "types.TermQuery")
 }
 
-func init() { proto.RegisterFile("term_query.proto", fileDescriptor_cbb9280914c3e3fe) }
+func init() { proto.RegisterFile("types/term_query.proto", fileDescriptor_218f21b8949236d1) }
 
-var fileDescriptor_cbb9280914c3e3fe = []byte{
-	// 153 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x28, 0x49, 0x2d, 0xca,
-	0x8d, 0x2f, 0x2c, 0x4d, 0x2d, 0xaa, 0xd4, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2d, 0xa9,
-	0x2c, 0x48, 0x2d, 0x56, 0x2a, 0xe5, 0xe2, 0x0c, 0x49, 0x2d, 0xca, 0x0d, 0x04, 0xc9, 0x08, 0xa9,
-	0x70, 0xb1, 0xf8, 0x96, 0x16, 0x97, 0x48, 0x30, 0x2a, 0x30, 0x6b, 0x70, 0x1b, 0x09, 0xe8, 0x81,
-	0x95, 0xe8, 0xc1, 0xe5, 0x83, 0xc0, 0xb2, 0x42, 0x1a, 0x5c, 0x6c, 0xc1, 0x19, 0xf9, 0xa5, 0x39,
-	0x29, 0x12, 0x4c, 0x38, 0xd4, 0x41, 0xe5, 0x85, 0x24, 0xb8, 0xd8, 0xbd, 0x53, 0x2b, 0xcb, 0xf3,
-	0x8b, 0x52, 0x24, 0x98, 0x15, 0x18, 0x35, 0x38, 0x83, 0x60, 0x5c, 0x27, 0x89, 0x13, 0x8f, 0xe4,
-	0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71, 0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f,
-	0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x48, 0x62, 0x03, 0x3b, 0xcf, 0x18, 0x10, 0x00, 0x00, 0xff,
-	0xff, 0x70, 0x35, 0xca, 0x77, 0xb2, 0x00, 0x00, 0x00,
+var fileDescriptor_218f21b8949236d1 = []byte{
+	// 532 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcb, 0x6e, 0xd3, 0x40,
+	0x14, 0xcd, 0x34, 0x71, 0x12, 0x5f, 0xb7, 0x05, 0x46, 0xa5, 0x1a, 0x75, 0x61, 0x59, 0x56, 0x10,
+	0x56, 0x17, 0x29, 0x4a, 0x77, 0xec, 0x28, 0x50, 0x09, 0x41, 0x50, 0x98, 0x54, 0xaa, 0xc4, 0x06,
+	0xb9, 0xf1, 0xd0, 0x58, 0x4a, 0x3c, 0x61, 0x3c, 0x16, 0x49, 0xbf, 0x82, 0x0f, 0xe0, 0x83, 0x58,
+	0x76, 0xc9, 0x12, 0x25, 0x9f, 0xc0, 0x0f, 0xa0, 0x79, 0xd8, 0x21, 0x22, 0x14, 0x76, 0x73, 0xe7,
+	0x9c, 0xb9, 0xe7, 0x3e, 0x8e, 0x0d, 0x87, 0x72, 0x31, 0x63, 0xf9, 0x89, 0x64, 0x62, 0xfa, 0xe1,
+	0x53, 0xc1, 0xc4, 0xa2, 0x3b, 0x13, 0x5c, 0x72, 0xec, 0xe8, 0xfb, 0xa3, 0x7b, 0x06, 0x4e, 0xf8,
+	0xc8, 0xdc, 0x87, 0x23, 0xf0, 0x06, 0x63, 0x11, 0xe7, 0xec, 0x9d, 0x22, 0xe3, 0x23, 0x68, 0xbf,
+	0x66, 0x8b, 0xcf, 0x5c, 0x24, 0x39, 0x41, 0x41, 0x3d, 0x72, 0x69, 0x15, 0x63, 0x0c, 0x8d, 0xe1,
+	0x84, 0xcf, 0xc8, 0x4e, 0x80, 0x22, 0x87, 0xea, 0x33, 0xee, 0x80, 0x73, 0xc1, 0xc4, 0x34, 0x27,
+	0xf5, 0xa0, 0x1e, 0x79, 0xbd, 0xfd, 0xae, 0xce, 0xdf, 0xb5, 0x6f, 0xa8, 0x01, 0xc3, 0x18, 0xbc,
+	0x81, 0x60, 0x1f, 0xd3, 0xb9, 0x11, 0x39, 0x00, 0xe7, 0x3c, 0x65, 0x93, 0x84, 0xa0, 0x00, 0x45,
+	0x2e, 0x35, 0x01, 0x3e, 0x84, 0xa6, 0x21, 0x69, 0x01, 0x97, 0xda, 0x08, 0x77, 0x60, 0xaf, 0x1f,
+	0xcf, 0x5f, 0xce, 0x67, 0x71, 0x96, 0xa7, 0x3c, 0x53, 0x52, 0x4a, 0x7f, 0xf3, 0x32, 0x64, 0xb0,
+	0x77, 0x99, 0x4e, 0x92, 0x51, 0x2c, 0x92, 0xbb, 0x44, 0x08, 0xb4, 0x06, 0xb1, 0x94, 0x4c, 0x64,
+	0x56, 0xa5, 0x0c, 0xff, 0x53, 0xe6, 0x2b, 0x02, 0x38, 0x2f, 0x6e, 0x6e, 0x16, 0x77, 0x89, 0x60,
+	0x68, 0x5c, 0x72, 0x91, 0x58, 0x05, 0x7d, 0x56, 0x83, 0x55, 0x99, 0x92, 0x54, 0x96, 0x99, 0xab,
+	0x18, 0x87, 0xb0, 0x6b, 0x7a, 0x7d, 0xc3, 0xb2, 0x6b, 0x39, 0x26, 0x0d, 0x8d, 0x6f, 0xdc, 0xfd,
+	0x59, 0x9e, 0xb3, 0xad, 0xbc, 0xa7, 0xb0, 0x3f, 0x2c, 0xae, 0x72, 0x29, 0xd2, 0xec, 0xfa, 0x1f,
+	0x15, 0x5e, 0xb0, 0xb9, 0x2c, 0x2b, 0x54, 0xe7, 0xf0, 0x67, 0x1d, 0x5c, 0xb5, 0x2e, 0xf3, 0xae,
+	0x03, 0x8d, 0x7e, 0x91, 0x4b, 0x6d, 0x02, 0xaf, 0x77, 0xdf, 0xee, 0xb5, 0xc2, 0xa9, 0x46, 0x71,
+	0x04, 0xcd, 0xe1, 0x98, 0x17, 0x13, 0xd5, 0xeb, 0x76, 0x9e, 0xc5, 0xd5, 0xe0, 0xad, 0x29, 0x74,
+	0xfb, 0x2e, 0x2d, 0x43, 0x7c, 0x0c, 0x2d, 0x95, 0xeb, 0x2d, 0x97, 0xa4, 0xf1, 0x97, 0x24, 0x25,
+	0x01, 0x1f, 0x43, 0xd3, 0xb8, 0x55, 0xb7, 0xef, 0xf5, 0xb0, 0xa5, 0xfe, 0x66, 0x61, 0x6a, 0x19,
+	0x9a, 0x6b, 0xfc, 0xd4, 0xdc, 0xe4, 0xae, 0x9d, 0x58, 0x79, 0xec, 0x09, 0xb4, 0x4b, 0xf7, 0x90,
+	0x96, 0x66, 0x1f, 0x58, 0xf6, 0x86, 0xa9, 0x68, 0xc5, 0xc2, 0x8f, 0xc1, 0xd1, 0x3e, 0x20, 0x6d,
+	0x4d, 0x7f, 0x60, 0xe9, 0x6b, 0x6f, 0x50, 0x83, 0xe3, 0x2e, 0xe0, 0x7e, 0x9a, 0xa5, 0xd3, 0x62,
+	0x6a, 0x26, 0xd1, 0x8f, 0xe5, 0x68, 0x4c, 0x5c, 0x3d, 0x83, 0x2d, 0x08, 0x0e, 0xd5, 0x6a, 0xc4,
+	0x94, 0x40, 0x80, 0xb6, 0x7c, 0x50, 0x1a, 0x53, 0x4b, 0x3d, 0xe3, 0x3c, 0x97, 0xc4, 0x0b, 0x50,
+	0xb4, 0x43, 0x4d, 0x80, 0x4f, 0xc1, 0xad, 0x96, 0x4f, 0x76, 0xf5, 0xf3, 0x87, 0xf6, 0xf9, 0xa6,
+	0x29, 0xe8, 0x9a, 0x77, 0xf6, 0xe8, 0xdb, 0xd2, 0x47, 0xb7, 0x4b, 0x1f, 0xfd, 0x58, 0xfa, 0xe8,
+	0xcb, 0xca, 0xaf, 0xdd, 0xae, 0xfc, 0xda, 0xf7, 0x95, 0x5f, 0x7b, 0xef, 0xd1, 0x67, 0x2f, 0x5e,
+	0x3d, 0x3f, 0xd1, 0x09, 0xae, 0x9a, 0xfa, 0x6f, 0x71, 0xfa, 0x6b, 0x00, 0x1c, 0x85, 0x0a, 0xe1,
+	0x5f, 0x04, 0x00, 0x00,
 }
 
-func (m *TermQuery) Marshal() (dAtA []byte, err error) {
+func (m *PhraseQuery) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -112,27 +522,20 @@ func (m *TermQuery) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *TermQuery) MarshalTo(dAtA []byte) (int, error) {
+func (m *PhraseQuery) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *TermQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *PhraseQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Keyword) > 0 {
-		i -= len(m.Keyword)
-		copy(dAtA[i:], m.Keyword)
-		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Keyword)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Should) > 0 {
-		for iNdEx := len(m.Should) - 1; iNdEx >= 0; iNdEx-- {
+	if len(m.Terms) > 0 {
+		for iNdEx := len(m.Terms) - 1; iNdEx >= 0; iNdEx-- {
 			{
-				size, err := m.Should[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				size, err := m.Terms[iNdEx].MarshalToSizedBuffer(dAtA[:i])
 				if err != nil {
 					return 0, err
 				}
@@ -140,19 +543,19 @@ func (m *TermQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 				i = encodeVarintTermQuery(dAtA, i, uint64(size))
 			}
 			i--
-			dAtA[i] = 0x12
+			dAtA[i] = 0x1a
 		}
 	}
-	if len(m.Must) > 0 {
-		for iNdEx := len(m.Must) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Must[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintTermQuery(dAtA, i, uint64(size))
-			}
+	if m.Slop != 0 {
+		i = encodeVarintTermQuery(dAtA, i, uint64(m.Slop))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Keywords) > 0 {
+		for iNdEx := len(m.Keywords) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Keywords[iNdEx])
+			copy(dAtA[i:], m.Keywords[iNdEx])
+			i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Keywords[iNdEx])))
 			i--
 			dAtA[i] = 0xa
 		}
@@ -160,47 +563,1207 @@ func (m *TermQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintTermQuery(dAtA []byte, offset int, v uint64) int {
-	offset -= sovTermQuery(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *PrefixQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrefixQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrefixQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxExpansions != 0 {
+		i = encodeVarintTermQuery(dAtA, i, uint64(m.MaxExpansions))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Prefix) > 0 {
+		i -= len(m.Prefix)
+		copy(dAtA[i:], m.Prefix)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Prefix)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WildcardQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WildcardQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WildcardQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxExpansions != 0 {
+		i = encodeVarintTermQuery(dAtA, i, uint64(m.MaxExpansions))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Pattern) > 0 {
+		i -= len(m.Pattern)
+		copy(dAtA[i:], m.Pattern)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Pattern)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FuzzyQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FuzzyQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FuzzyQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxExpansions != 0 {
+		i = encodeVarintTermQuery(dAtA, i, uint64(m.MaxExpansions))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.PrefixLength != 0 {
+		i = encodeVarintTermQuery(dAtA, i, uint64(m.PrefixLength))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.MaxEdits != 0 {
+		i = encodeVarintTermQuery(dAtA, i, uint64(m.MaxEdits))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Word) > 0 {
+		i -= len(m.Word)
+		copy(dAtA[i:], m.Word)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Word)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SubstringQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubstringQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubstringQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Text) > 0 {
+		i -= len(m.Text)
+		copy(dAtA[i:], m.Text)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Text)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Field) > 0 {
+		i -= len(m.Field)
+		copy(dAtA[i:], m.Field)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Field)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TermQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TermQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TermQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Substring != nil {
+		{
+			size, err := m.Substring.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTermQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Boost != 0 {
+		i -= 4
+		encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(math.Float32bits(float32(m.Boost))))
+		i--
+		dAtA[i] = 0x5d
+	}
+	if m.Term != nil {
+		{
+			size, err := m.Term.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTermQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	if len(m.MinimumShouldMatch) > 0 {
+		i -= len(m.MinimumShouldMatch)
+		copy(dAtA[i:], m.MinimumShouldMatch)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.MinimumShouldMatch)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.Fuzzy != nil {
+		{
+			size, err := m.Fuzzy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTermQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Wildcard != nil {
+		{
+			size, err := m.Wildcard.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTermQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Prefix != nil {
+		{
+			size, err := m.Prefix.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTermQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Phrase != nil {
+		{
+			size, err := m.Phrase.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTermQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.MustNot) > 0 {
+		for iNdEx := len(m.MustNot) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MustNot[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTermQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Keyword) > 0 {
+		i -= len(m.Keyword)
+		copy(dAtA[i:], m.Keyword)
+		i = encodeVarintTermQuery(dAtA, i, uint64(len(m.Keyword)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Should) > 0 {
+		for iNdEx := len(m.Should) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Should[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTermQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Must) > 0 {
+		for iNdEx := len(m.Must) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Must[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTermQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTermQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTermQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
 	}
 	dAtA[offset] = uint8(v)
 	return base
 }
-func (m *TermQuery) Size() (n int) {
+func (m *PhraseQuery) Size() (n int) {
 	if m == nil {
 		return 0
 	}
 	var l int
 	_ = l
-	if len(m.Must) > 0 {
-		for _, e := range m.Must {
-			l = e.Size()
+	if len(m.Keywords) > 0 {
+		for _, s := range m.Keywords {
+			l = len(s)
 			n += 1 + l + sovTermQuery(uint64(l))
 		}
 	}
-	if len(m.Should) > 0 {
-		for _, e := range m.Should {
+	if m.Slop != 0 {
+		n += 1 + sovTermQuery(uint64(m.Slop))
+	}
+	if len(m.Terms) > 0 {
+		for _, e := range m.Terms {
 			l = e.Size()
 			n += 1 + l + sovTermQuery(uint64(l))
 		}
 	}
-	l = len(m.Keyword)
+	return n
+}
+
+func (m *PrefixQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	l = len(m.Prefix)
 	if l > 0 {
 		n += 1 + l + sovTermQuery(uint64(l))
 	}
+	if m.MaxExpansions != 0 {
+		n += 1 + sovTermQuery(uint64(m.MaxExpansions))
+	}
 	return n
 }
 
-func sovTermQuery(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *WildcardQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	l = len(m.Pattern)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.MaxExpansions != 0 {
+		n += 1 + sovTermQuery(uint64(m.MaxExpansions))
+	}
+	return n
 }
-func sozTermQuery(x uint64) (n int) {
-	return sovTermQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *FuzzyQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	l = len(m.Word)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.MaxEdits != 0 {
+		n += 1 + sovTermQuery(uint64(m.MaxEdits))
+	}
+	if m.PrefixLength != 0 {
+		n += 1 + sovTermQuery(uint64(m.PrefixLength))
+	}
+	if m.MaxExpansions != 0 {
+		n += 1 + sovTermQuery(uint64(m.MaxExpansions))
+	}
+	return n
+}
+
+func (m *SubstringQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Field)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	l = len(m.Text)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *TermQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Must) > 0 {
+		for _, e := range m.Must {
+			l = e.Size()
+			n += 1 + l + sovTermQuery(uint64(l))
+		}
+	}
+	if len(m.Should) > 0 {
+		for _, e := range m.Should {
+			l = e.Size()
+			n += 1 + l + sovTermQuery(uint64(l))
+		}
+	}
+	l = len(m.Keyword)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if len(m.MustNot) > 0 {
+		for _, e := range m.MustNot {
+			l = e.Size()
+			n += 1 + l + sovTermQuery(uint64(l))
+		}
+	}
+	if m.Phrase != nil {
+		l = m.Phrase.Size()
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.Prefix != nil {
+		l = m.Prefix.Size()
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.Wildcard != nil {
+		l = m.Wildcard.Size()
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.Fuzzy != nil {
+		l = m.Fuzzy.Size()
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	l = len(m.MinimumShouldMatch)
+	if l > 0 {
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.Term != nil {
+		l = m.Term.Size()
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	if m.Boost != 0 {
+		n += 5
+	}
+	if m.Substring != nil {
+		l = m.Substring.Size()
+		n += 1 + l + sovTermQuery(uint64(l))
+	}
+	return n
+}
+
+func sovTermQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTermQuery(x uint64) (n int) {
+	return sovTermQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *PhraseQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTermQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PhraseQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PhraseQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Keywords", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Keywords = append(m.Keywords, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Slop", wireType)
+			}
+			m.Slop = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Slop |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Terms", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Terms = append(m.Terms, &Keyword{})
+			if err := m.Terms[len(m.Terms)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTermQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrefixQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTermQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrefixQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrefixQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Prefix = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxExpansions", wireType)
+			}
+			m.MaxExpansions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxExpansions |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTermQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WildcardQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTermQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WildcardQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WildcardQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pattern", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Pattern = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxExpansions", wireType)
+			}
+			m.MaxExpansions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxExpansions |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTermQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FuzzyQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTermQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FuzzyQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FuzzyQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Word", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Word = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxEdits", wireType)
+			}
+			m.MaxEdits = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxEdits |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrefixLength", wireType)
+			}
+			m.PrefixLength = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PrefixLength |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxExpansions", wireType)
+			}
+			m.MaxExpansions = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxExpansions |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTermQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubstringQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTermQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubstringQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubstringQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Field", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Field = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Text", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Text = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTermQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
 func (m *TermQuery) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
@@ -331,6 +1894,299 @@ func (m *TermQuery) Unmarshal(dAtA []byte) error {
 			}
 			m.Keyword = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MustNot", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MustNot = append(m.MustNot, &TermQuery{})
+			if err := m.MustNot[len(m.MustNot)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Phrase", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Phrase == nil {
+				m.Phrase = &PhraseQuery{}
+			}
+			if err := m.Phrase.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Prefix", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Prefix == nil {
+				m.Prefix = &PrefixQuery{}
+			}
+			if err := m.Prefix.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Wildcard", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Wildcard == nil {
+				m.Wildcard = &WildcardQuery{}
+			}
+			if err := m.Wildcard.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fuzzy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Fuzzy == nil {
+				m.Fuzzy = &FuzzyQuery{}
+			}
+			if err := m.Fuzzy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinimumShouldMatch", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.MinimumShouldMatch = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Term", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Term == nil {
+				m.Term = &Keyword{}
+			}
+			if err := m.Term.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 11:
+			if wireType != 5 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Boost", wireType)
+			}
+			var v uint32
+			if (iNdEx + 4) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+			iNdEx += 4
+			m.Boost = float32(math.Float32frombits(v))
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Substring", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTermQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTermQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Substring == nil {
+				m.Substring = &SubstringQuery{}
+			}
+			if err := m.Substring.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTermQuery(dAtA[iNdEx:])
@@ -0,0 +1,179 @@
+package test
+
+import (
+	"RADIC/types"
+	"errors"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	kw := types.NewTermQuery
+
+	q, err := types.ParseQuery("title:go AND (tag:java OR tag:rust) -tag:php")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := kw("title", "go").And(kw("tag", "java").Or(kw("tag", "rust"))).Not(kw("tag", "php"))
+	if q.String() != want.String() {
+		t.Errorf("got %s, want %s", q.String(), want.String())
+	}
+
+	// 输出的查询字符串可以再解析回同样的TermQuery
+	tests := []string{
+		"title:go AND (tag:java OR tag:rust) -tag:php",
+		"go OR java OR rust",
+		"title:分布式 AND title:搜索",
+		`tag:"c++ primer" AND tag:"AND"`,
+		"(go OR java) -php -rust",
+		"go AND (java -php)",
+		"title:[分布式 搜索]~2 OR [go tag:语言]",
+		"title:gola* OR tag:go*lang OR tag:\"go*\"",
+		"title:golnag~1 AND 搜素~2",
+		"(tag:go OR tag:java OR tag:rust)~2 -tag:php",
+		"title:go AND ((tag:go OR tag:java)~60% OR tag:c)",
+		"(go java)~1",
+		"title:go^3 OR tag:go",
+		"(title:go AND title:java)^2 OR [go 语言]~1^0.5 -php",
+		"(tag:go OR tag:java)~2^1.5 go~1^2",
+		`title:*"式搜"* OR *"a \"b\""*^2`,
+		"title:golnag~1,2,50 OR go~2,0,10 OR java~0,3",
+	}
+	for _, s := range tests {
+		q, err := types.ParseQuery(s)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) failed: %v", s, err)
+			continue
+		}
+		again, err := types.ParseQuery(q.ToQueryString())
+		if err != nil {
+			t.Errorf("ParseQuery(%q) failed: %v", q.ToQueryString(), err)
+			continue
+		}
+		if again.String() != q.String() {
+			t.Errorf("round trip of %q: got %s, want %s", s, again.String(), q.String())
+		}
+	}
+	if s := kw("title", "go").And(kw("tag", "java").Or(kw("tag", "rust"))).Not(kw("tag", "php")).ToQueryString(); s != "title:go AND (tag:java OR tag:rust) -tag:php" {
+		t.Errorf("ToQueryString got %s", s)
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{"", 0},
+		{"title:go AND", 12},
+		{"(go OR java", 11},
+		{"go OR -php", 6},
+		{"title:", 6},
+		{"go )", 3},
+		{`tag:"go`, 7},
+		{"标题:分布式 AND OR", 11},
+		{"title:[分布式 搜索", 13},
+		{"title:[分布式]~x", 12},
+		{"go~3", 3},
+		{"(go OR java)~x", 13},
+		{"(go OR java)~120%", 13},
+		{"(go OR java)~ 2", 14},
+		{"go^", 3},
+		{"go^ 2", 4},
+		{"go^-1", 3},
+		{"go^0", 3},
+		{`title:*"go"`, 11},
+		{`title:*""*`, 7},
+		{`title:"go lang"*`, 15},
+		{`go"lang"`, 2},
+		{"go ^2", 3},
+		{"(go OR java) ^2", 13},
+		{"go~3,1", 3},
+		{"go~1,-1", 3},
+		{"go~1,2,50,1", 3},
+		{"(a b c)~2", 8},
+		{"(go OR java)~3", 13},
+	}
+	for _, test := range tests {
+		_, err := types.ParseQuery(test.query)
+		var syntaxErr *types.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("ParseQuery(%q) got err %v, want SyntaxError", test.query, err)
+			continue
+		}
+		if syntaxErr.Pos != test.pos {
+			t.Errorf("ParseQuery(%q) got %v, want position %d", test.query, err, test.pos)
+		}
+	}
+}
+
+func TestParsePhrase(t *testing.T) {
+	q, err := types.ParseQuery("title:[分布式 搜索]~2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := types.NewPhraseQuery(2, &types.Keyword{Field: "title", Word: "分布式"}, &types.Keyword{Field: "title", Word: "搜索"})
+	if q.String() != want.String() {
+		t.Errorf("got %s, want %s", q.String(), want.String())
+	}
+	if s := q.ToQueryString(); s != "title:[分布式 搜索]~2" {
+		t.Errorf("ToQueryString got %s", s)
+	}
+}
+
+func TestParseBoost(t *testing.T) {
+	q, err := types.ParseQuery("(title:go OR tag:go)^2 OR tag:java^0.5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 打平括号时boost乘到每个子句上
+	if len(q.Should) != 3 || q.Should[0].GetBoost() != 2 || q.Should[1].GetBoost() != 2 || q.Should[2].GetBoost() != 0.5 || q.Boost != 0 {
+		t.Errorf("got %s", q.String())
+	}
+	if s := q.ToQueryString(); s != "title:go^2 OR tag:go^2 OR tag:java^0.5" {
+		t.Errorf("ToQueryString got %s", s)
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	q, err := types.ParseQuery(`title:gola* tag:go?lang "c*"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Must) != 3 || q.Must[0].Prefix.GetPrefix() != "gola" || q.Must[1].Wildcard.GetPattern() != "go?lang" || q.Must[2].Key() != "\001c*" {
+		t.Errorf("got %s", q.String())
+	}
+}
+
+func TestParseFuzzy(t *testing.T) {
+	q, err := types.ParseQuery("title:golnag~1,2,50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := q.Fuzzy; f.GetMaxEdits() != 1 || f.GetPrefixLength() != 2 || f.GetMaxExpansions() != 50 {
+		t.Errorf("got %s", q.String())
+	}
+	// 前缀长度和最大扩展词数是默认值时省略
+	for want, q := range map[string]*types.TermQuery{
+		"title:golnag~1":      types.NewFuzzyQuery("title", "golnag", 1, 0),
+		"title:golnag~1,2":    types.NewFuzzyQuery("title", "golnag", 1, 2),
+		"title:golnag~1,0,50": {Fuzzy: &types.FuzzyQuery{Field: "title", Word: "golnag", MaxEdits: 1, MaxExpansions: 50}},
+	} {
+		if s := q.ToQueryString(); s != want {
+			t.Errorf("ToQueryString got %s, want %s", s, want)
+		}
+	}
+}
+
+func TestParseSubstring(t *testing.T) {
+	q, err := types.ParseQuery(`title:*"式 搜"* title:* "go"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// *和引号之间有空白时不是子串查询
+	if len(q.Must) != 3 || q.Must[0].Substring.GetText() != "式 搜" || q.Must[1].Prefix.GetPrefix() != "" || q.Must[2].Key() != "\001go" {
+		t.Errorf("got %s", q.String())
+	}
+	if s := q.Must[0].ToQueryString(); s != `title:*"式 搜"*` {
+		t.Errorf("ToQueryString got %s", s)
+	}
+}
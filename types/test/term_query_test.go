@@ -0,0 +1,75 @@
+package test
+
+import (
+	"RADIC/types"
+	"testing"
+)
+
+func TestTermQueryValidate(t *testing.T) {
+	encoded := func(field, word string) string {
+		keyword := types.Keyword{Field: field, Word: word}
+		return keyword.ToString()
+	}
+	tests := []struct {
+		name  string
+		query *types.TermQuery
+		valid bool
+	}{
+		{"term", types.NewTermQuery("title", "go"), true},
+		{"legacy keyword", &types.TermQuery{Keyword: encoded("title", "go")}, true},
+		{"legacy keyword without field", &types.TermQuery{Keyword: encoded("", "go")}, true},
+		{"nested", types.NewTermQuery("title", "go").And(types.NewTermQuery("tag", "java").Or(types.NewPhraseQuery(0, &types.Keyword{Word: "x"}))), true},
+		{"legacy phrase", &types.TermQuery{Phrase: &types.PhraseQuery{Keywords: []string{encoded("title", "分布式"), encoded("title", "搜索")}}}, true},
+		{"minimum should match", types.AtLeast(2, types.NewTermQuery("tag", "go"), types.NewTermQuery("tag", "java")), true},
+		{"same term and keyword", &types.TermQuery{Term: &types.Keyword{Field: "title", Word: "go"}, Keyword: encoded("title", "go")}, true},
+
+		{"nil", nil, false},
+		{"empty", &types.TermQuery{}, false},
+		{"unencoded keyword", &types.TermQuery{Keyword: "go"}, false},
+		{"empty word", types.NewTermQuery("title", ""), false},
+		{"term and keyword differ", &types.TermQuery{Term: &types.Keyword{Field: "title", Word: "go"}, Keyword: encoded("title", "java")}, false},
+		{"empty phrase", &types.TermQuery{Phrase: &types.PhraseQuery{Slop: 1}}, false},
+		{"unencoded phrase", &types.TermQuery{Phrase: &types.PhraseQuery{Keywords: []string{"分布式", "搜索"}}}, false},
+		{"nested unencoded", types.NewTermQuery("title", "go").Not(&types.TermQuery{Keyword: "php"}), false},
+		{"bad minimum should match", &types.TermQuery{Should: []*types.TermQuery{types.NewTermQuery("tag", "go")}, MinimumShouldMatch: "two"}, false},
+		{"minimum should match larger than should", types.AtLeast(3, types.NewTermQuery("tag", "go"), types.NewTermQuery("tag", "java")), false},
+		{"negative boost", types.NewTermQuery("tag", "go").WithBoost(-1), false},
+	}
+	for _, test := range tests {
+		err := test.query.Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("%s: Validate() = %v, want valid=%v", test.name, err, test.valid)
+		}
+	}
+}
+
+func TestTermQueryBoost(t *testing.T) {
+	title, tag := types.NewTermQuery("title", "go"), types.NewTermQuery("tag", "go")
+	java := types.NewTermQuery("tag", "java").WithBoost(3)
+
+	// And、Or、Not打平带boost的容器时，boost乘到子节点上，被打平的子节点本身不受影响
+	must := title.And(tag).WithBoost(2)
+	for _, q := range []*types.TermQuery{must.And(java), java.And(must), must.Not(types.NewTermQuery("tag", "php"))} {
+		boosts := map[string]float64{}
+		for _, term := range q.LeafTerms() {
+			boosts[term.Key] = term.Boost
+		}
+		if boosts[title.Key()] != 2 || boosts[tag.Key()] != 2 || (len(boosts) == 3 && boosts[java.Key()] != 3) || q.Boost != 0 {
+			t.Errorf("%s: boosts %v", q.ToQueryString(), boosts)
+		}
+	}
+	should := title.Or(tag).WithBoost(0.5).Or(java)
+	if len(should.Should) != 3 || should.Should[0].GetBoost() != 0.5 || should.Should[2].GetBoost() != 3 {
+		t.Errorf("or got %s", should.String())
+	}
+	if title.Boost != 0 || tag.Boost != 0 {
+		t.Error("flattening modified the shared children")
+	}
+
+	// 嵌套的boost逐层相乘，同一个keyword出现多次取最大的
+	nested := types.AtLeast(1, title.And(java).WithBoost(2), title).WithBoost(1.5)
+	terms := nested.LeafTerms()
+	if len(terms) != 2 || terms[0].Key != title.Key() || terms[0].Boost != 3 || terms[1].Boost != 9 {
+		t.Errorf("nested terms %v", terms)
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	DEFAULT_VECTOR_K = 100   // VectorQuery.K为0时向量检索召回的文档数
+	MAX_VECTOR_K     = 10000 // 向量检索最多召回的文档数
+)
+
+// NewVectorQuery 向量检索，k为0时召回默认的DEFAULT_VECTOR_K个
+func NewVectorQuery(vector []float32, k int32, fusion FusionMode) *VectorQuery {
+	return &VectorQuery{Vector: vector, K: k, Fusion: fusion}
+}
+
+// Limit 向量检索召回的文档数
+func (q *VectorQuery) Limit() int {
+	if q.K <= 0 {
+		return DEFAULT_VECTOR_K
+	}
+	return int(q.K)
+}
+
+// Validate 向量不能为空，不能有NaN、Inf，也不能全是0(没法算余弦相似度)。nil是合法的，即不做向量检索
+func (q *VectorQuery) Validate() error {
+	if q == nil {
+		return nil
+	}
+	if len(q.Vector) == 0 {
+		return fmt.Errorf("empty vector")
+	}
+	zero := true
+	for i, v := range q.Vector {
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return fmt.Errorf("invalid value %v at dimension %d", v, i)
+		}
+		zero = zero && v == 0
+	}
+	if zero {
+		return fmt.Errorf("zero vector")
+	}
+	if q.K < 0 || q.K > MAX_VECTOR_K {
+		return fmt.Errorf("invalid k %d, want 0~%d", q.K, MAX_VECTOR_K)
+	}
+	if q.Ef < 0 {
+		return fmt.Errorf("invalid ef %d", q.Ef)
+	}
+	if _, exists := FusionMode_name[int32(q.Fusion)]; !exists {
+		return fmt.Errorf("unknown fusion mode %d", q.Fusion)
+	}
+	return nil
+}
@@ -0,0 +1,473 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: types/vector.proto
+
+package types
+
+import (
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+type FusionMode int32
+
+const (
+	FusionMode_FUSION_RRF    FusionMode = 0
+	FusionMode_FUSION_FILTER FusionMode = 1
+)
+
+var FusionMode_name = map[int32]string{
+	0: "FUSION_RRF",
+	1: "FUSION_FILTER",
+}
+
+var FusionMode_value = map[string]int32{
+	"FUSION_RRF":    0,
+	"FUSION_FILTER": 1,
+}
+
+func (x FusionMode) String() string {
+	return proto.EnumName(FusionMode_name, int32(x))
+}
+
+func (FusionMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_32ff01a2990be7db, []int{0}
+}
+
+type VectorQuery struct {
+	Vector []float32  `protobuf:"fixed32,1,rep,packed,name=Vector,proto3" json:"Vector,omitempty"`
+	K      int32      `protobuf:"varint,2,opt,name=K,proto3" json:"K,omitempty"`
+	Fusion FusionMode `protobuf:"varint,3,opt,name=Fusion,proto3,enum=types.FusionMode" json:"Fusion,omitempty"`
+	Ef     int32      `protobuf:"varint,4,opt,name=Ef,proto3" json:"Ef,omitempty"`
+}
+
+func (m *VectorQuery) Reset()         { *m = VectorQuery{} }
+func (m *VectorQuery) String() string { return proto.CompactTextString(m) }
+func (*VectorQuery) ProtoMessage()    {}
+func (*VectorQuery) Descriptor() ([]byte, []int) {
+	return fileDescriptor_32ff01a2990be7db, []int{0}
+}
+func (m *VectorQuery) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *VectorQuery) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_VectorQuery.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *VectorQuery) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VectorQuery.Merge(m, src)
+}
+func (m *VectorQuery) XXX_Size() int {
+	return m.Size()
+}
+func (m *VectorQuery) XXX_DiscardUnknown() {
+	xxx_messageInfo_VectorQuery.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VectorQuery proto.InternalMessageInfo
+
+func (m *VectorQuery) GetVector() []float32 {
+	if m != nil {
+		return m.Vector
+	}
+	return nil
+}
+
+func (m *VectorQuery) GetK() int32 {
+	if m != nil {
+		return m.K
+	}
+	return 0
+}
+
+func (m *VectorQuery) GetFusion() FusionMode {
+	if m != nil {
+		return m.Fusion
+	}
+	return FusionMode_FUSION_RRF
+}
+
+func (m *VectorQuery) GetEf() int32 {
+	if m != nil {
+		return m.Ef
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterEnum("types.FusionMode", FusionMode_name, FusionMode_value)
+	proto.RegisterType((*VectorQuery)(nil), "types.VectorQuery")
+}
+
+func init() { proto.RegisterFile("types/vector.proto", fileDescriptor_32ff01a2990be7db) }
+
+var fileDescriptor_32ff01a2990be7db = []byte{
+	// 208 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x2a, 0xa9, 0x2c, 0x48,
+	0x2d, 0xd6, 0x2f, 0x4b, 0x4d, 0x2e, 0xc9, 0x2f, 0xd2, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62,
+	0x05, 0x8b, 0x29, 0xe5, 0x71, 0x71, 0x87, 0x81, 0x85, 0x03, 0x4b, 0x53, 0x8b, 0x2a, 0x85, 0xc4,
+	0xb8, 0xd8, 0x20, 0x5c, 0x09, 0x46, 0x05, 0x66, 0x0d, 0xa6, 0x20, 0x28, 0x4f, 0x88, 0x87, 0x8b,
+	0xd1, 0x5b, 0x82, 0x49, 0x81, 0x51, 0x83, 0x35, 0x88, 0xd1, 0x5b, 0x48, 0x93, 0x8b, 0xcd, 0xad,
+	0xb4, 0x38, 0x33, 0x3f, 0x4f, 0x82, 0x59, 0x81, 0x51, 0x83, 0xcf, 0x48, 0x50, 0x0f, 0x6c, 0x98,
+	0x1e, 0x44, 0xd0, 0x37, 0x3f, 0x25, 0x35, 0x08, 0xaa, 0x40, 0x88, 0x8f, 0x8b, 0xc9, 0x35, 0x4d,
+	0x82, 0x05, 0xac, 0x93, 0xc9, 0x35, 0x4d, 0x4b, 0x9f, 0x8b, 0x0b, 0xa1, 0x4a, 0x88, 0x8f, 0x8b,
+	0xcb, 0x2d, 0x34, 0xd8, 0xd3, 0xdf, 0x2f, 0x3e, 0x28, 0xc8, 0x4d, 0x80, 0x41, 0x48, 0x90, 0x8b,
+	0x17, 0xca, 0x77, 0xf3, 0xf4, 0x09, 0x71, 0x0d, 0x12, 0x60, 0x74, 0x52, 0x3d, 0xf1, 0x48, 0x8e,
+	0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07, 0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58,
+	0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86, 0x28, 0xee, 0x20, 0x47, 0x17, 0x4f, 0x67, 0x7d, 0xb0, 0xd5,
+	0x49, 0x6c, 0x60, 0x5f, 0x19, 0x03, 0x06, 0x00, 0xd0, 0x0e, 0x16, 0x0a, 0xeb, 0x00, 0x00, 0x00,
+}
+
+func (m *VectorQuery) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *VectorQuery) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *VectorQuery) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Ef != 0 {
+		i = encodeVarintVector(dAtA, i, uint64(m.Ef))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Fusion != 0 {
+		i = encodeVarintVector(dAtA, i, uint64(m.Fusion))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.K != 0 {
+		i = encodeVarintVector(dAtA, i, uint64(m.K))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Vector) > 0 {
+		for iNdEx := len(m.Vector) - 1; iNdEx >= 0; iNdEx-- {
+			f1 := math.Float32bits(float32(m.Vector[iNdEx]))
+			i -= 4
+			encoding_binary.LittleEndian.PutUint32(dAtA[i:], uint32(f1))
+		}
+		i = encodeVarintVector(dAtA, i, uint64(len(m.Vector)*4))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintVector(dAtA []byte, offset int, v uint64) int {
+	offset -= sovVector(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *VectorQuery) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Vector) > 0 {
+		n += 1 + sovVector(uint64(len(m.Vector)*4)) + len(m.Vector)*4
+	}
+	if m.K != 0 {
+		n += 1 + sovVector(uint64(m.K))
+	}
+	if m.Fusion != 0 {
+		n += 1 + sovVector(uint64(m.Fusion))
+	}
+	if m.Ef != 0 {
+		n += 1 + sovVector(uint64(m.Ef))
+	}
+	return n
+}
+
+func sovVector(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozVector(x uint64) (n int) {
+	return sovVector(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *VectorQuery) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowVector
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: VectorQuery: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: VectorQuery: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 5 {
+				var v uint32
+				if (iNdEx + 4) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+				iNdEx += 4
+				v2 := float32(math.Float32frombits(v))
+				m.Vector = append(m.Vector, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowVector
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthVector
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthVector
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 4
+				if elementCount != 0 && len(m.Vector) == 0 {
+					m.Vector = make([]float32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					if (iNdEx + 4) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint32(encoding_binary.LittleEndian.Uint32(dAtA[iNdEx:]))
+					iNdEx += 4
+					v2 := float32(math.Float32frombits(v))
+					m.Vector = append(m.Vector, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vector", wireType)
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field K", wireType)
+			}
+			m.K = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVector
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.K |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fusion", wireType)
+			}
+			m.Fusion = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVector
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Fusion |= FusionMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ef", wireType)
+			}
+			m.Ef = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowVector
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ef |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipVector(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthVector
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipVector(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowVector
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowVector
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowVector
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthVector
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupVector
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthVector
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthVector        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowVector          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupVector = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,488 @@
+package util
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// 压缩位图，思路同Roaring Bitmap：
+// uint64按高48位分桶(container)，每个桶存低16位；桶内元素少时用有序的[]uint16(数组容器)，
+// 元素多于4096个时改用65536位的位图(位图容器)，两种容器在4096个元素时占用的内存都是8KB
+// 稀疏的倒排链用数组容器，稠密的倒排链用位图容器，求交并差时按容器类型选择最快的算法
+
+const (
+	arrayContainerMax = 4096       // 数组容器最多存放的元素个数
+	bitmapWords       = 65536 / 64 // 位图容器的uint64个数
+)
+
+type container struct {
+	key    uint64   // 高48位
+	array  []uint16 // 数组容器，升序
+	bitmap []uint64 // 位图容器，非nil时表示该容器是位图容器
+	card   int      // 元素个数
+}
+
+func (c *container) isBitmap() bool {
+	return c.bitmap != nil
+}
+
+func (c *container) contains(low uint16) bool {
+	if c.isBitmap() {
+		return c.bitmap[low>>6]&(1<<(low&63)) != 0
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	return i < len(c.array) && c.array[i] == low
+}
+
+// add 返回是否是新加入的元素
+func (c *container) add(low uint16) bool {
+	if c.isBitmap() {
+		word, mask := low>>6, uint64(1)<<(low&63)
+		if c.bitmap[word]&mask != 0 {
+			return false
+		}
+		c.bitmap[word] |= mask
+		c.card++
+		return true
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if i < len(c.array) && c.array[i] == low {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = low
+	c.card++
+	if c.card > arrayContainerMax {
+		c.toBitmap()
+	}
+	return true
+}
+
+// remove 返回元素是否存在
+func (c *container) remove(low uint16) bool {
+	if c.isBitmap() {
+		word, mask := low>>6, uint64(1)<<(low&63)
+		if c.bitmap[word]&mask == 0 {
+			return false
+		}
+		c.bitmap[word] &^= mask
+		c.card--
+		if c.card <= arrayContainerMax {
+			c.toArray()
+		}
+		return true
+	}
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	if i == len(c.array) || c.array[i] != low {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.card--
+	return true
+}
+
+// rank 容器内小于等于low的元素个数
+func (c *container) rank(low uint16) int {
+	if c.isBitmap() {
+		n := 0
+		word := int(low >> 6)
+		for i := 0; i < word; i++ {
+			n += bits.OnesCount64(c.bitmap[i])
+		}
+		mask := uint64(1)<<(low&63+1) - 1 // low&63为63时左移64位得0，减1正好是全1
+		return n + bits.OnesCount64(c.bitmap[word]&mask)
+	}
+	return sort.Search(len(c.array), func(i int) bool { return c.array[i] > low })
+}
+
+func (c *container) toBitmap() {
+	c.bitmap = make([]uint64, bitmapWords)
+	for _, low := range c.array {
+		c.bitmap[low>>6] |= 1 << (low & 63)
+	}
+	c.array = nil
+}
+
+func (c *container) toArray() {
+	array := make([]uint16, 0, c.card)
+	c.forEach(func(low uint16) bool {
+		array = append(array, low)
+		return true
+	})
+	c.array = array
+	c.bitmap = nil
+}
+
+// fromWords 由位图构造容器，元素少时自动转成数组容器
+func fromWords(key uint64, words []uint64) *container {
+	card := 0
+	for _, w := range words {
+		card += bits.OnesCount64(w)
+	}
+	if card == 0 {
+		return nil
+	}
+	c := &container{key: key, bitmap: words, card: card}
+	if card <= arrayContainerMax {
+		c.toArray()
+	}
+	return c
+}
+
+// fromArray 由有序数组构造容器，元素多时自动转成位图容器
+func fromArray(key uint64, array []uint16) *container {
+	if len(array) == 0 {
+		return nil
+	}
+	c := &container{key: key, array: array, card: len(array)}
+	if c.card > arrayContainerMax {
+		c.toBitmap()
+	}
+	return c
+}
+
+func (c *container) forEach(fn func(low uint16) bool) bool {
+	if !c.isBitmap() {
+		for _, low := range c.array {
+			if !fn(low) {
+				return false
+			}
+		}
+		return true
+	}
+	for i, w := range c.bitmap {
+		for w != 0 {
+			t := bits.TrailingZeros64(w)
+			if !fn(uint16(i*64 + t)) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (c *container) clone() *container {
+	result := &container{key: c.key, card: c.card}
+	if c.isBitmap() {
+		result.bitmap = append([]uint64(nil), c.bitmap...)
+	} else {
+		result.array = append([]uint16(nil), c.array...)
+	}
+	return result
+}
+
+// words 以位图形式返回容器内容(会复制)
+func (c *container) words() []uint64 {
+	if c.isBitmap() {
+		return append([]uint64(nil), c.bitmap...)
+	}
+	words := make([]uint64, bitmapWords)
+	for _, low := range c.array {
+		words[low>>6] |= 1 << (low & 63)
+	}
+	return words
+}
+
+func andContainer(a, b *container) *container {
+	switch {
+	case a.isBitmap() && b.isBitmap():
+		words := make([]uint64, bitmapWords)
+		for i := range words {
+			words[i] = a.bitmap[i] & b.bitmap[i]
+		}
+		return fromWords(a.key, words)
+	case a.isBitmap() || b.isBitmap():
+		if a.isBitmap() {
+			a, b = b, a
+		}
+		array := make([]uint16, 0, len(a.array))
+		for _, low := range a.array {
+			if b.contains(low) {
+				array = append(array, low)
+			}
+		}
+		return fromArray(a.key, array)
+	default:
+		array := make([]uint16, 0, min(len(a.array), len(b.array)))
+		for i, j := 0, 0; i < len(a.array) && j < len(b.array); {
+			if a.array[i] < b.array[j] {
+				i++
+			} else if a.array[i] > b.array[j] {
+				j++
+			} else {
+				array = append(array, a.array[i])
+				i++
+				j++
+			}
+		}
+		return fromArray(a.key, array)
+	}
+}
+
+func orContainer(a, b *container) *container {
+	if a.isBitmap() || b.isBitmap() {
+		words := a.words()
+		if b.isBitmap() {
+			for i, w := range b.bitmap {
+				words[i] |= w
+			}
+		} else {
+			for _, low := range b.array {
+				words[low>>6] |= 1 << (low & 63)
+			}
+		}
+		return fromWords(a.key, words)
+	}
+	array := make([]uint16, 0, len(a.array)+len(b.array))
+	i, j := 0, 0
+	for i < len(a.array) && j < len(b.array) {
+		if a.array[i] < b.array[j] {
+			array = append(array, a.array[i])
+			i++
+		} else if a.array[i] > b.array[j] {
+			array = append(array, b.array[j])
+			j++
+		} else {
+			array = append(array, a.array[i])
+			i++
+			j++
+		}
+	}
+	array = append(array, a.array[i:]...)
+	array = append(array, b.array[j:]...)
+	return fromArray(a.key, array)
+}
+
+func andNotContainer(a, b *container) *container {
+	if a.isBitmap() {
+		words := a.words()
+		if b.isBitmap() {
+			for i, w := range b.bitmap {
+				words[i] &^= w
+			}
+		} else {
+			for _, low := range b.array {
+				words[low>>6] &^= 1 << (low & 63)
+			}
+		}
+		return fromWords(a.key, words)
+	}
+	array := make([]uint16, 0, len(a.array))
+	for _, low := range a.array {
+		if !b.contains(low) {
+			array = append(array, low)
+		}
+	}
+	return fromArray(a.key, array)
+}
+
+// Bitmap 压缩位图，非并发安全
+type Bitmap struct {
+	containers []*container // 按key升序
+}
+
+func NewBitmap() *Bitmap {
+	return new(Bitmap)
+}
+
+// BitmapOf 由若干元素构造位图
+func BitmapOf(values ...uint64) *Bitmap {
+	bm := NewBitmap()
+	for _, x := range values {
+		bm.Add(x)
+	}
+	return bm
+}
+
+func split(x uint64) (uint64, uint16) {
+	return x >> 16, uint16(x)
+}
+
+// find 二分查找高位key对应的容器，返回容器和它应在的下标
+func (bm *Bitmap) find(key uint64) (*container, int) {
+	i := sort.Search(len(bm.containers), func(i int) bool { return bm.containers[i].key >= key })
+	if i < len(bm.containers) && bm.containers[i].key == key {
+		return bm.containers[i], i
+	}
+	return nil, i
+}
+
+// Add 加入元素，返回是否是新加入的
+func (bm *Bitmap) Add(x uint64) bool {
+	key, low := split(x)
+	c, i := bm.find(key)
+	if c == nil {
+		c = &container{key: key}
+		bm.containers = append(bm.containers, nil)
+		copy(bm.containers[i+1:], bm.containers[i:])
+		bm.containers[i] = c
+	}
+	return c.add(low)
+}
+
+// Remove 删除元素，返回元素是否存在
+func (bm *Bitmap) Remove(x uint64) bool {
+	key, low := split(x)
+	c, i := bm.find(key)
+	if c == nil || !c.remove(low) {
+		return false
+	}
+	if c.card == 0 {
+		bm.containers = append(bm.containers[:i], bm.containers[i+1:]...)
+	}
+	return true
+}
+
+func (bm *Bitmap) Contains(x uint64) bool {
+	key, low := split(x)
+	c, _ := bm.find(key)
+	return c != nil && c.contains(low)
+}
+
+// Cardinality 元素个数
+func (bm *Bitmap) Cardinality() int {
+	n := 0
+	for _, c := range bm.containers {
+		n += c.card
+	}
+	return n
+}
+
+func (bm *Bitmap) IsEmpty() bool {
+	return len(bm.containers) == 0
+}
+
+// Rank 小于等于x的元素个数。x存在时，Rank(x)-1就是x在有序序列中的下标
+func (bm *Bitmap) Rank(x uint64) int {
+	key, low := split(x)
+	n := 0
+	for _, c := range bm.containers {
+		if c.key < key {
+			n += c.card
+		} else {
+			if c.key == key {
+				n += c.rank(low)
+			}
+			break
+		}
+	}
+	return n
+}
+
+// ForEach 按升序遍历元素，fn返回false时停止
+func (bm *Bitmap) ForEach(fn func(x uint64) bool) {
+	for _, c := range bm.containers {
+		high := c.key << 16
+		if !c.forEach(func(low uint16) bool {
+			return fn(high | uint64(low))
+		}) {
+			return
+		}
+	}
+}
+
+// ToArray 按升序返回所有元素
+func (bm *Bitmap) ToArray() []uint64 {
+	result := make([]uint64, 0, bm.Cardinality())
+	bm.ForEach(func(x uint64) bool {
+		result = append(result, x)
+		return true
+	})
+	return result
+}
+
+func (bm *Bitmap) Clone() *Bitmap {
+	result := &Bitmap{containers: make([]*container, 0, len(bm.containers))}
+	for _, c := range bm.containers {
+		result.containers = append(result.containers, c.clone())
+	}
+	return result
+}
+
+// And 交集，返回新的位图
+func And(a, b *Bitmap) *Bitmap {
+	result := NewBitmap()
+	for i, j := 0, 0; i < len(a.containers) && j < len(b.containers); {
+		ca, cb := a.containers[i], b.containers[j]
+		if ca.key < cb.key {
+			i++
+		} else if ca.key > cb.key {
+			j++
+		} else {
+			if c := andContainer(ca, cb); c != nil {
+				result.containers = append(result.containers, c)
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// Or 并集，返回新的位图
+func Or(a, b *Bitmap) *Bitmap {
+	result := &Bitmap{containers: make([]*container, 0, len(a.containers)+len(b.containers))}
+	i, j := 0, 0
+	for i < len(a.containers) && j < len(b.containers) {
+		ca, cb := a.containers[i], b.containers[j]
+		if ca.key < cb.key {
+			result.containers = append(result.containers, ca.clone())
+			i++
+		} else if ca.key > cb.key {
+			result.containers = append(result.containers, cb.clone())
+			j++
+		} else {
+			result.containers = append(result.containers, orContainer(ca, cb))
+			i++
+			j++
+		}
+	}
+	for ; i < len(a.containers); i++ {
+		result.containers = append(result.containers, a.containers[i].clone())
+	}
+	for ; j < len(b.containers); j++ {
+		result.containers = append(result.containers, b.containers[j].clone())
+	}
+	return result
+}
+
+// AndNot 差集a-b，返回新的位图
+func AndNot(a, b *Bitmap) *Bitmap {
+	result := &Bitmap{containers: make([]*container, 0, len(a.containers))}
+	j := 0
+	for _, ca := range a.containers {
+		for j < len(b.containers) && b.containers[j].key < ca.key {
+			j++
+		}
+		if j < len(b.containers) && b.containers[j].key == ca.key {
+			if c := andNotContainer(ca, b.containers[j]); c != nil {
+				result.containers = append(result.containers, c)
+			}
+		} else {
+			result.containers = append(result.containers, ca.clone())
+		}
+	}
+	return result
+}
+
+// AtLeast 至少出现在n个位图中的元素，n<=1时即为并集
+// levels[i]存放至少出现了i+1次的元素，每加入一个位图s，自高向低更新：levels[i] |= levels[i-1] & s，最后levels[n-1]就是结果
+func AtLeast(n int, bitmaps ...*Bitmap) *Bitmap {
+	if n > len(bitmaps) {
+		return NewBitmap()
+	}
+	n = max(n, 1)
+	levels := make([]*Bitmap, n)
+	for i := range levels {
+		levels[i] = NewBitmap()
+	}
+	for _, s := range bitmaps {
+		for i := n - 1; i > 0; i-- {
+			levels[i] = Or(levels[i], And(levels[i-1], s))
+		}
+		levels[0] = Or(levels[0], s)
+	}
+	return levels[n-1]
+}
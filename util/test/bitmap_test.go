@@ -0,0 +1,93 @@
+package test
+
+import (
+	"RADIC/util"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// randomSet 生成随机集合，dense为true时元素集中在少数几个桶里，会用到位图容器
+func randomSet(r *rand.Rand, n int, dense bool) map[uint64]struct{} {
+	set := make(map[uint64]struct{}, n)
+	for len(set) < n {
+		if dense {
+			set[r.Uint64N(3<<16)] = struct{}{}
+		} else {
+			set[r.Uint64N(1<<40)] = struct{}{}
+		}
+	}
+	return set
+}
+
+func toBitmap(set map[uint64]struct{}) *util.Bitmap {
+	bm := util.NewBitmap()
+	for x := range set {
+		bm.Add(x)
+	}
+	return bm
+}
+
+func sortedKeys(set map[uint64]struct{}) []uint64 {
+	keys := make([]uint64, 0, len(set))
+	for x := range set {
+		keys = append(keys, x)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func TestBitmap(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, dense := range []bool{false, true} {
+		a, b := randomSet(r, 20000, dense), randomSet(r, 20000, dense)
+		bmA, bmB := toBitmap(a), toBitmap(b)
+
+		and, or, andNot := map[uint64]struct{}{}, map[uint64]struct{}{}, map[uint64]struct{}{}
+		for x := range a {
+			or[x] = struct{}{}
+			if _, ok := b[x]; ok {
+				and[x] = struct{}{}
+			} else {
+				andNot[x] = struct{}{}
+			}
+		}
+		for x := range b {
+			or[x] = struct{}{}
+		}
+
+		if !slices.Equal(bmA.ToArray(), sortedKeys(a)) {
+			t.Errorf("dense=%v: ToArray mismatch", dense)
+		}
+		if !slices.Equal(util.And(bmA, bmB).ToArray(), sortedKeys(and)) {
+			t.Errorf("dense=%v: And mismatch", dense)
+		}
+		if !slices.Equal(util.Or(bmA, bmB).ToArray(), sortedKeys(or)) {
+			t.Errorf("dense=%v: Or mismatch", dense)
+		}
+		if !slices.Equal(util.AndNot(bmA, bmB).ToArray(), sortedKeys(andNot)) {
+			t.Errorf("dense=%v: AndNot mismatch", dense)
+		}
+		if !slices.Equal(util.AtLeast(2, bmA, bmB, util.NewBitmap()).ToArray(), sortedKeys(and)) {
+			t.Errorf("dense=%v: AtLeast mismatch", dense)
+		}
+
+		keys := sortedKeys(a)
+		for i := 0; i < len(keys); i += 997 {
+			if rank := bmA.Rank(keys[i]); rank != i+1 {
+				t.Fatalf("dense=%v: Rank(%d) = %d, want %d", dense, keys[i], rank, i+1)
+			}
+		}
+
+		// 删掉一大半，位图容器要能退化回数组容器且结果正确
+		for i, x := range keys {
+			if i%3 != 0 {
+				bmA.Remove(x)
+				delete(a, x)
+			}
+		}
+		if bmA.Cardinality() != len(a) || !slices.Equal(bmA.ToArray(), sortedKeys(a)) {
+			t.Errorf("dense=%v: mismatch after Remove", dense)
+		}
+	}
+}
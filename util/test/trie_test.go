@@ -0,0 +1,41 @@
+package test
+
+import (
+	"RADIC/util"
+	"slices"
+	"testing"
+)
+
+func TestTrie(t *testing.T) {
+	trie := util.NewTrie()
+	for _, word := range []string{"golang", "go", "gopher", "java", "分布式", "分词", "go"} {
+		trie.Insert(word)
+	}
+	if trie.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", trie.Len())
+	}
+
+	collect := func(prefix string) []string {
+		words := make([]string, 0)
+		trie.WalkPrefix(prefix, func(word string) bool {
+			words = append(words, word)
+			return true
+		})
+		return words
+	}
+	if words := collect("go"); !slices.Equal(words, []string{"go", "golang", "gopher"}) {
+		t.Errorf("WalkPrefix(go) = %v", words)
+	}
+	if words := collect("分"); !slices.Equal(words, []string{"分布式", "分词"}) {
+		t.Errorf("WalkPrefix(分) = %v", words)
+	}
+
+	if !trie.Delete("go") || trie.Delete("go") || trie.Contains("go") || !trie.Contains("golang") {
+		t.Error("Delete(go) should only remove go itself")
+	}
+	trie.Delete("golang")
+	trie.Delete("gopher")
+	if words := collect("g"); len(words) != 0 {
+		t.Errorf("WalkPrefix(g) after delete = %v", words)
+	}
+}
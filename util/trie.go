@@ -0,0 +1,161 @@
+package util
+
+import "sort"
+
+// 前缀树(字典树)，按rune拆分单词，子节点按rune有序存放，所以遍历的结果天然是字典序
+// 用作倒排索引的词典，支持按前缀查找所有单词；非并发安全，由使用方加锁
+
+type trieNode struct {
+	r        rune
+	children []*trieNode // 按r升序
+	terminal bool        // 从根到该节点的路径是一个完整的单词
+}
+
+// child 二分查找子节点，返回子节点和它应在的下标
+func (node *trieNode) child(r rune) (*trieNode, int) {
+	i := sort.Search(len(node.children), func(i int) bool {
+		return node.children[i].r >= r
+	})
+	if i < len(node.children) && node.children[i].r == r {
+		return node.children[i], i
+	}
+	return nil, i
+}
+
+// Trie 前缀树
+type Trie struct {
+	root *trieNode
+	size int
+}
+
+func NewTrie() *Trie {
+	return &Trie{root: new(trieNode)}
+}
+
+// Len 单词个数
+func (t *Trie) Len() int {
+	return t.size
+}
+
+// Insert 插入单词，单词已存在时返回false
+func (t *Trie) Insert(word string) bool {
+	node := t.root
+	for _, r := range word {
+		next, i := node.child(r)
+		if next == nil {
+			next = &trieNode{r: r}
+			node.children = append(node.children, nil)
+			copy(node.children[i+1:], node.children[i:])
+			node.children[i] = next
+		}
+		node = next
+	}
+	if node.terminal {
+		return false
+	}
+	node.terminal = true
+	t.size++
+	return true
+}
+
+// Delete 删除单词，并剪掉不再有用的节点。单词不存在时返回false
+func (t *Trie) Delete(word string) bool {
+	runes := []rune(word)
+	path := make([]*trieNode, 0, len(runes)+1) // 从根到单词末尾的节点
+	node := t.root
+	path = append(path, node)
+	for _, r := range runes {
+		if node, _ = node.child(r); node == nil {
+			return false
+		}
+		path = append(path, node)
+	}
+	if !node.terminal {
+		return false
+	}
+	node.terminal = false
+	t.size--
+
+	// 自底向上删除既不是单词结尾、也没有子节点的节点
+	for i := len(path) - 1; i > 0; i-- {
+		cur := path[i]
+		if cur.terminal || len(cur.children) > 0 {
+			break
+		}
+		parent := path[i-1]
+		_, j := parent.child(cur.r)
+		parent.children = append(parent.children[:j], parent.children[j+1:]...)
+	}
+	return true
+}
+
+// Contains 单词是否存在
+func (t *Trie) Contains(word string) bool {
+	node := t.find(word)
+	return node != nil && node.terminal
+}
+
+// find 找到前缀对应的节点，不存在时返回nil
+func (t *Trie) find(prefix string) *trieNode {
+	node := t.root
+	for _, r := range prefix {
+		if node, _ = node.child(r); node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// WalkPrefix 按字典序遍历所有以prefix开头的单词，fn返回false时停止遍历
+func (t *Trie) WalkPrefix(prefix string, fn func(word string) bool) {
+	node := t.find(prefix)
+	if node == nil {
+		return
+	}
+	buf := []rune(prefix)
+	var walk func(node *trieNode) bool
+	walk = func(node *trieNode) bool {
+		if node.terminal && !fn(string(buf)) {
+			return false
+		}
+		for _, child := range node.children {
+			buf = append(buf, child.r)
+			if !walk(child) {
+				return false
+			}
+			buf = buf[:len(buf)-1]
+		}
+		return true
+	}
+	walk(node)
+}
+
+// WalkTrie 带状态的深度优先遍历，用来在前缀树上跑自动机(比如Levenshtein自动机)
+// 从prefix对应的节点出发，start是读完prefix之后的状态；每往下走一个字符调用step得到新状态，
+// step返回false表示该分支不可能再匹配，整棵子树被剪掉；match判断当前状态是否接受，接受时把单词和状态交给fn，fn返回false时停止遍历
+func WalkTrie[S any](t *Trie, prefix string, start S, step func(state S, r rune) (S, bool), match func(state S) bool, fn func(word string, state S) bool) {
+	node := t.find(prefix)
+	if node == nil {
+		return
+	}
+	buf := []rune(prefix)
+	var walk func(node *trieNode, state S) bool
+	walk = func(node *trieNode, state S) bool {
+		if node.terminal && match(state) && !fn(string(buf), state) {
+			return false
+		}
+		for _, child := range node.children {
+			next, ok := step(state, child.r)
+			if !ok {
+				continue
+			}
+			buf = append(buf, child.r)
+			if !walk(child, next) {
+				return false
+			}
+			buf = buf[:len(buf)-1]
+		}
+		return true
+	}
+	walk(node, start)
+}